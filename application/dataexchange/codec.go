@@ -0,0 +1,119 @@
+package dataexchange
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxRecords - больше строк за одну загрузку не принимается
+const MaxRecords = 10000
+
+var ErrTooManyRecords = fmt.Errorf("в файле больше %d строк", MaxRecords)
+
+func DecodeEnclosures(r io.Reader, format Format) ([]EnclosureRecord, error) {
+	return decode(r, format, enclosureColumns)
+}
+
+func DecodeAnimals(r io.Reader, format Format) ([]AnimalRecord, error) {
+	return decode(r, format, animalColumns)
+}
+
+func EncodeEnclosures(w io.Writer, format Format, records []EnclosureRecord) error {
+	return encode(w, format, enclosureColumns, records)
+}
+
+func EncodeAnimals(w io.Writer, format Format, records []AnimalRecord) error {
+	return encode(w, format, animalColumns, records)
+}
+
+// decode - JSON-массив записей или CSV с заголовком; колонки CSV называются
+// как поля JSON, регистр не важен, лишние колонки пропускаются
+func decode[T any](r io.Reader, format Format, columns []column[T]) ([]T, error) {
+	if format == FormatJSON {
+		var records []T
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, fmt.Errorf("некорректный JSON: %w", err)
+		}
+		if len(records) > MaxRecords {
+			return nil, ErrTooManyRecords
+		}
+		return records, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("пустой файл: нет строки заголовка")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Excel добавляет BOM в начало файла в UTF-8
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	positions := make([]int, len(columns))
+	for i, column := range columns {
+		positions[i] = -1
+		for j, name := range header {
+			if strings.EqualFold(strings.TrimSpace(name), column.name) {
+				positions[i] = j
+				break
+			}
+		}
+		if positions[i] < 0 && column.required {
+			return nil, fmt.Errorf("нет колонки %q", column.name)
+		}
+	}
+
+	records := make([]T, 0)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == MaxRecords {
+			return nil, ErrTooManyRecords
+		}
+
+		var record T
+		for i, column := range columns {
+			if positions[i] >= 0 {
+				*column.field(&record) = strings.TrimSpace(row[positions[i]])
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+func encode[T any](w io.Writer, format Format, columns []column[T], records []T) error {
+	if format == FormatJSON {
+		if records == nil {
+			records = []T{}
+		}
+		return json.NewEncoder(w).Encode(records)
+	}
+
+	writer := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	writer.Write(header)
+	for i := range records {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = *column.field(&records[i])
+		}
+		writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,101 @@
+// Package dataexchange - строки таблиц животных и вольеров для массовой
+// загрузки и выгрузки в CSV и JSON. Поля хранятся как в файле, строками:
+// разбирает и проверяет их services.BulkService, чтобы ошибка в одной строке
+// попала в отчёт, а не прервала разбор всего файла.
+package dataexchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat - формат по имени (csv, json) или по Content-Type
+func ParseFormat(value string) (Format, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if mediaType, _, found := strings.Cut(value, ";"); found {
+		value = strings.TrimSpace(mediaType)
+	}
+	switch value {
+	case "csv", "text/csv":
+		return FormatCSV, nil
+	case "json", "application/json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("неизвестный формат %q, ожидается csv или json", value)
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json"
+}
+
+// EnclosureRecord - строка таблицы вольеров; id при загрузке не учитывается
+type EnclosureRecord struct {
+	ID          string      `json:"id,omitempty"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Length      json.Number `json:"length"`
+	Width       json.Number `json:"width"`
+	Height      json.Number `json:"height"`
+	MaxCapacity json.Number `json:"maxCapacity"`
+}
+
+// AnimalRecord - строка таблицы животных; id при загрузке не учитывается.
+// Enclosure - ID или название вольера, пусто - животное не заселяется.
+type AnimalRecord struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Species      string `json:"species"`
+	AnimalType   string `json:"animalType"`
+	BirthDate    string `json:"birthDate"`
+	Gender       string `json:"gender"`
+	HealthStatus string `json:"healthStatus"`
+	FoodType     string `json:"foodType"`
+	FavoriteFood string `json:"favoriteFood"`
+	Enclosure    string `json:"enclosure"`
+	State        string `json:"state,omitempty"`
+	ArrivalDate  string `json:"arrivalDate,omitempty"`
+}
+
+// column - колонка CSV и поле записи, в которое она читается
+type column[T any] struct {
+	name     string
+	required bool
+	field    func(record *T) *string
+}
+
+var enclosureColumns = []column[EnclosureRecord]{
+	{"id", false, func(r *EnclosureRecord) *string { return &r.ID }},
+	{"name", false, func(r *EnclosureRecord) *string { return &r.Name }},
+	{"type", true, func(r *EnclosureRecord) *string { return &r.Type }},
+	{"length", true, func(r *EnclosureRecord) *string { return (*string)(&r.Length) }},
+	{"width", true, func(r *EnclosureRecord) *string { return (*string)(&r.Width) }},
+	{"height", true, func(r *EnclosureRecord) *string { return (*string)(&r.Height) }},
+	{"maxCapacity", true, func(r *EnclosureRecord) *string { return (*string)(&r.MaxCapacity) }},
+}
+
+var animalColumns = []column[AnimalRecord]{
+	{"id", false, func(r *AnimalRecord) *string { return &r.ID }},
+	{"name", true, func(r *AnimalRecord) *string { return &r.Name }},
+	{"species", true, func(r *AnimalRecord) *string { return &r.Species }},
+	{"animalType", true, func(r *AnimalRecord) *string { return &r.AnimalType }},
+	{"birthDate", true, func(r *AnimalRecord) *string { return &r.BirthDate }},
+	{"gender", true, func(r *AnimalRecord) *string { return &r.Gender }},
+	{"healthStatus", false, func(r *AnimalRecord) *string { return &r.HealthStatus }},
+	{"foodType", true, func(r *AnimalRecord) *string { return &r.FoodType }},
+	{"favoriteFood", true, func(r *AnimalRecord) *string { return &r.FavoriteFood }},
+	{"enclosure", false, func(r *AnimalRecord) *string { return &r.Enclosure }},
+	{"state", false, func(r *AnimalRecord) *string { return &r.State }},
+	{"arrivalDate", false, func(r *AnimalRecord) *string { return &r.ArrivalDate }},
+}
@@ -0,0 +1,136 @@
+// Package fixtures - описание зоопарка в YAML или JSON для демонстрационных
+// и тестовых стендов: виды, вольеры, животные и кормления. Сущности ссылаются
+// друг на друга по символьным ключам, а не по ID, которых до загрузки ещё нет.
+// Загружает описание services.FixtureService.
+package fixtures
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Demo - имя встроенного демонстрационного зоопарка вместо пути к файлу
+const Demo = "demo"
+
+//go:embed demo.yaml
+var demo []byte
+
+type Fixture struct {
+	Species    []Species   `yaml:"species"`
+	Enclosures []Enclosure `yaml:"enclosures"`
+	Animals    []Animal    `yaml:"animals"`
+	Feedings   []Feeding   `yaml:"feedings"`
+}
+
+// Species - вид: тип и корм по умолчанию для животных этого вида
+type Species struct {
+	Key  string `yaml:"key"`
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	Food Food   `yaml:"food"`
+}
+
+type Food struct {
+	Type string `yaml:"type"`
+	Name string `yaml:"name"`
+}
+
+type Size struct {
+	Length int `yaml:"length"`
+	Width  int `yaml:"width"`
+	Height int `yaml:"height"`
+}
+
+type Enclosure struct {
+	Key      string `yaml:"key"`
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Size     Size   `yaml:"size"`
+	Capacity int    `yaml:"capacity"`
+}
+
+// Animal - Species и Enclosure - ключи; Food перекрывает корм вида,
+// пустой Enclosure - животное не заселяется, пустой State - on-display,
+// пустой Arrived - животное поступило в момент загрузки
+type Animal struct {
+	Key       string  `yaml:"key"`
+	Name      string  `yaml:"name"`
+	Species   string  `yaml:"species"`
+	Born      string  `yaml:"born"`
+	Arrived   string  `yaml:"arrived"`
+	Gender    string  `yaml:"gender"`
+	Health    string  `yaml:"health"`
+	Food      *Food   `yaml:"food"`
+	Enclosure string  `yaml:"enclosure"`
+	State     string  `yaml:"state"`
+	Sire      *Parent `yaml:"sire"`
+	Dam       *Parent `yaml:"dam"`
+}
+
+// Parent - Animal - ключ животного, описанного выше; без него - животное
+// другого учреждения по номеру в племенной книге
+type Parent struct {
+	Animal      string `yaml:"animal"`
+	Institution string `yaml:"institution"`
+	StudbookID  string `yaml:"studbookId"`
+	Name        string `yaml:"name"`
+}
+
+// Feeding - At - время RFC 3339 или "HH:MM", то есть ближайшее такое время
+// после загрузки; пустой Food - тип любимого корма животного
+type Feeding struct {
+	Animal string `yaml:"animal"`
+	At     string `yaml:"at"`
+	Food   string `yaml:"food"`
+}
+
+// EnclosureKey - ключ, по которому на вольер ссылаются животные; по умолчанию название
+func (e Enclosure) EnclosureKey() string {
+	if e.Key != "" {
+		return e.Key
+	}
+	return e.Name
+}
+
+// AnimalKey - ключ, по которому на животное ссылаются кормления; по умолчанию имя
+func (a Animal) AnimalKey() string {
+	if a.Key != "" {
+		return a.Key
+	}
+	return a.Name
+}
+
+// Read - содержимое файла или встроенного зоопарка, если source - Demo
+func Read(source string) ([]byte, error) {
+	if source == Demo {
+		return demo, nil
+	}
+	return os.ReadFile(source)
+}
+
+// Parse - YAML или JSON, который тоже является YAML; неизвестные поля - ошибка,
+// чтобы опечатка в ключе не превращалась молча в пустое значение
+func Parse(data []byte) (*Fixture, error) {
+	var fixture Fixture
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fixture); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("некорректное описание зоопарка: %w", err)
+	}
+	return &fixture, nil
+}
+
+// Load - Read и Parse
+func Load(source string) (*Fixture, error) {
+	data, err := Read(source)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
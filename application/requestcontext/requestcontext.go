@@ -0,0 +1,66 @@
+// Package requestcontext - данные запроса, которые передаются через context
+// от HTTP-слоя до сервисов и репозиториев
+package requestcontext
+
+import (
+	"context"
+	"kpo-mini-dz2/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	actorKey
+	actorHolderKey
+)
+
+// Actor - кто выполняет действие; у анонимного запроса пустой
+type Actor struct {
+	ID   uuid.UUID
+	Name string
+	Role model.Role
+}
+
+func (a Actor) IsAuthenticated() bool {
+	return a.ID != uuid.Nil
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithActor - пользователь запроса; если в ctx есть место из WithActorHolder,
+// пользователь записывается и туда
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	if holder, ok := ctx.Value(actorHolderKey).(*Actor); ok {
+		*holder = actor
+	}
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// WithActorHolder - место для пользователя, которого узнают дальше по цепочке:
+// так middleware, стоящее до аутентификации, видит его после обработки запроса
+func WithActorHolder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, actorHolderKey, new(Actor))
+}
+
+// HeldActor - пользователь, записанный в место из WithActorHolder
+func HeldActor(ctx context.Context) Actor {
+	if holder, ok := ctx.Value(actorHolderKey).(*Actor); ok {
+		return *holder
+	}
+	return Actor{}
+}
+
+func ActorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey).(Actor)
+	return actor
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnimalService - добавление и удаление животных вместе с
+// вольером и расписанием кормлений
+type AnimalService struct {
+	uow RP.IUnitOfWorkFactory
+}
+
+func NewAnimalService(uow RP.IUnitOfWorkFactory) *AnimalService {
+	return &AnimalService{uow: uow}
+}
+
+// AddAnimal - создаёт животное и, если указан вольер, сразу заселяет его.
+// Состояние здоровья, отличное от healthy, указывает только тот, кто ведёт
+// медицинские карты (checkArrivalHealth).
+func (s *AnimalService) AddAnimal(ctx context.Context, data model.Animal) (*model.Animal, error) {
+	if err := checkArrivalHealth(requestcontext.ActorFrom(ctx).Role, data.HealthStatus); err != nil {
+		return nil, err
+	}
+	animal, err := model.NewAnimal(
+		data.Name,
+		data.Species,
+		data.BirthDate,
+		uuid.Nil,
+		data.HealthStatus,
+		data.Gender,
+		data.FavoriteFood,
+	)
+	if err == nil {
+		err = animal.EnterLifecycle(data.State, data.ArrivalDate, model.ReasonArrival, requestcontext.ActorFrom(ctx).Name)
+	}
+	if err == nil {
+		err = animal.SetParents(data.Sire, data.Dam)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkParents(tx, animal); err != nil {
+		return nil, err
+	}
+	if err := tx.Animals().Save(*animal); err != nil {
+		return nil, err
+	}
+	if err := openArrivalCase(tx, *animal); err != nil {
+		return nil, err
+	}
+	if data.EnclosureID != uuid.Nil {
+		if err := moveAnimal(tx, animal.ID, data.EnclosureID, model.ReasonArrival, requestcontext.ActorFrom(ctx).Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Перечитываем, чтобы вернуть версию, присвоенную репозиторием
+	created, err := tx.Animals().FindByID(animal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateAnimal - меняет описание животного; вольер меняется только перемещением,
+// состояние здоровья - только случаями медицинской карты (MedicalRecordService),
+// родители - LineageService.SetParents. Смена вида или типа проверяется
+// по вольеру, в котором животное живёт.
+// version - версия, которую видел клиент, или model.AnyVersion
+func (s *AnimalService) UpdateAnimal(ctx context.Context, id uuid.UUID, data model.Animal, version int) (*model.Animal, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, animal.Version); err != nil {
+		return nil, err
+	}
+	if data.HealthStatus != "" && data.HealthStatus != animal.HealthStatus {
+		return nil, fmt.Errorf("%w: состояние здоровья следует из открытых случаев медицинской карты", model.ErrValidation)
+	}
+	if !requestcontext.ActorFrom(ctx).Role.Can(model.PermManageAnimals) {
+		return nil, fmt.Errorf("%w: описание животного меняют смотрители и кураторы", model.ErrForbidden)
+	}
+
+	speciesChanged := animal.Species != data.Species
+	animal.Name = data.Name
+	animal.Species = data.Species
+	animal.BirthDate = data.BirthDate
+	animal.Gender = data.Gender
+	animal.FavoriteFood = data.FavoriteFood
+	if err := animal.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+	if speciesChanged && animal.EnclosureID != uuid.Nil {
+		enclosure, err := tx.Enclosures().FindByID(animal.EnclosureID)
+		if err != nil {
+			return nil, err
+		}
+		// Место животного в вольере уже учтено, проверяется только совместимость
+		enclosure.DeleteAnimal(*animal)
+		if err := enclosure.CanAccept(*animal); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Animals().Save(*animal); err != nil {
+		return nil, err
+	}
+
+	updated, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteAnimal - мягко удаляет животное вместе с его кормлениями, освобождает
+// место в вольере и отменяет неподписанные заявки на его перемещение. Окончательно запись удалит ArchivePurger.
+func (s *AnimalService) DeleteAnimal(ctx context.Context, id uuid.UUID, version int) error {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := model.CheckVersion(version, animal.Version); err != nil {
+		return err
+	}
+
+	actor := requestcontext.ActorFrom(ctx).Name
+	if err := releaseAnimal(tx, animal, actor); err != nil {
+		return err
+	}
+	// Кормления удаляются с той же отметкой времени, по ней их и восстановят
+	now := time.Now()
+	schedules, err := tx.FeedingSchedules().GetSchedulesByAnimalID(id)
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if err := deleteSchedule(tx, schedule, now, actor); err != nil {
+			return err
+		}
+	}
+	// Восстановленное животное живёт без вольера, старые заявки к нему не относятся
+	if err := cancelTransferRequests(tx, id, actor, "животное перенесено в архив"); err != nil {
+		return err
+	}
+	animal.MarkDeleted(now, actor)
+	if err := tx.Animals().Save(*animal); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RestoreAnimal - возвращает удалённое животное вместе с кормлениями, удалёнными
+// вместе с ним. Вольер животное освободило при удалении, заселять его нужно заново.
+func (s *AnimalService) RestoreAnimal(ctx context.Context, id uuid.UUID, version int) (*model.Animal, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindDeletedByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, animal.Version); err != nil {
+		return nil, err
+	}
+
+	deletedAt := *animal.DeletedAt
+	schedules, err := tx.FeedingSchedules().GetDeletedSchedules()
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		if schedule.AnimalID != id || !schedule.DeletedAt.Equal(deletedAt) {
+			continue
+		}
+		schedule.Restore()
+		if err := tx.FeedingSchedules().UpdateSchedule(schedule); err != nil {
+			return nil, err
+		}
+	}
+	animal.Restore()
+	if err := tx.Animals().Save(*animal); err != nil {
+		return nil, err
+	}
+
+	restored, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// LifecycleChange - переход животного в другое состояние жизненного цикла.
+// EnclosureID - куда заселить животное, вернувшееся из другого зоопарка.
+type LifecycleChange struct {
+	State       model.LifecycleState
+	Date        time.Time
+	Reason      string
+	EnclosureID uuid.UUID
+}
+
+// ChangeLifecycleState - выбывшее животное (отдано на время, передано, пало)
+// освобождает вольер, его будущие кормления и незакрытые заявки на перемещение
+// отменяются; само животное, его история и медицинская карта остаются.
+// version - версия, которую видел клиент, или model.AnyVersion
+func (s *AnimalService) ChangeLifecycleState(ctx context.Context, id uuid.UUID, change LifecycleChange, version int) (*model.Animal, error) {
+	actor := requestcontext.ActorFrom(ctx).Name
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, animal.Version); err != nil {
+		return nil, err
+	}
+	wasPresent := animal.IsPresent()
+	if err := animal.ChangeState(change.State, change.Date, change.Reason, actor); err != nil {
+		if errors.Is(err, model.ErrInvalidTransition) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+	if change.EnclosureID != uuid.Nil && (wasPresent || !animal.IsPresent()) {
+		return nil, fmt.Errorf("%w: вольер указывается только при возвращении животного, иначе - перемещение", model.ErrValidation)
+	}
+
+	if !animal.IsPresent() {
+		if err := releaseAnimal(tx, animal, actor); err != nil {
+			return nil, err
+		}
+		if err := cancelAnimalPlans(tx, id, actor, fmt.Sprintf("животное выбыло из зоопарка (%s)", animal.State)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Animals().Save(*animal); err != nil {
+		return nil, err
+	}
+	if change.EnclosureID != uuid.Nil {
+		if err := moveAnimal(tx, id, change.EnclosureID, model.ReasonArrival, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// deleteSchedule - мягко удаляет кормление
+func deleteSchedule(tx RP.IUnitOfWork, schedule model.FeedingSchedule, at time.Time, actor string) error {
+	schedule.MarkDeleted(at, actor)
+	return tx.FeedingSchedules().UpdateSchedule(schedule)
+}
+
+// releaseAnimal - освобождает место в вольере и записывает выбытие;
+// животное не сохраняет, это делает вызывающий
+func releaseAnimal(tx RP.IUnitOfWork, animal *model.Animal, actor string) error {
+	if animal.EnclosureID == uuid.Nil {
+		return nil
+	}
+	enclosure, err := tx.Enclosures().FindByID(animal.EnclosureID)
+	switch {
+	case err == nil:
+		enclosure.DeleteAnimal(*animal)
+		if err := tx.Enclosures().Update(*enclosure); err != nil {
+			return err
+		}
+	case !errors.Is(err, model.ErrEnclosureNotFound):
+		return err
+	}
+
+	movement := model.NewMovement(animal.ID, animal.EnclosureID, uuid.Nil, model.ReasonRemoval, actor)
+	if err := tx.Movements().Add(*movement); err != nil {
+		return err
+	}
+	animal.EnclosureID = uuid.Nil
+	return nil
+}
+
+// cancelAnimalPlans - у выбывшего животного не бывает кормлений и перемещений;
+// выполненные кормления остаются в истории, reason - причина отмены заявок
+func cancelAnimalPlans(tx RP.IUnitOfWork, animalID uuid.UUID, actor string, reason string) error {
+	schedules, err := tx.FeedingSchedules().GetSchedulesByAnimalID(animalID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, schedule := range schedules {
+		if schedule.DoneAt != nil {
+			continue
+		}
+		if err := deleteSchedule(tx, schedule, now, actor); err != nil {
+			return err
+		}
+	}
+	return cancelTransferRequests(tx, animalID, actor, reason)
+}
+
+// cancelTransferRequests - отменяет все незакрытые заявки на перемещение животного:
+// и поданные, и уже одобренные или назначенные - переселить его больше нельзя.
+// В заявке остаётся, кто и почему её отменил.
+func cancelTransferRequests(tx RP.IUnitOfWork, animalID uuid.UUID, actor string, reason string) error {
+	requests, err := tx.TransferRequests().FindAll()
+	if err != nil {
+		return err
+	}
+	for _, request := range requests {
+		if request.AnimalID != animalID || !request.IsPending() {
+			continue
+		}
+		if err := request.Cancel(actor, reason); err != nil {
+			return err
+		}
+		if err := tx.TransferRequests().Save(request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAddAnimalArrivalHealthNeedsMedicalPermission(t *testing.T) {
+	tests := []struct {
+		role model.Role
+		want error
+	}{
+		{role: model.RoleVet},
+		{role: model.RoleAdmin},
+		{role: model.RoleKeeper, want: model.ErrForbidden},
+		{role: model.RoleCurator, want: model.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			z := newTestZoo(t)
+			enclosure := z.enclosure("Forest", model.Predator, 2)
+			if !tt.role.Can(model.PermAdmitAnimals) {
+				t.Fatalf("%s cannot admit animals", tt.role)
+			}
+
+			data := wolf("Patient", enclosure.ID)
+			data.HealthStatus = model.Sick
+			animal, err := z.animals.AddAnimal(actorContext(tt.role), data)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("AddAnimal() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if z.count(enclosure.ID) != 0 {
+					t.Fatal("a rejected animal was housed")
+				}
+				return
+			}
+			if record, _ := z.store.MedicalRecords.FindByAnimalID(animal.ID); len(record.Cases) != 1 {
+				t.Fatalf("medical record = %+v, want one arrival case", record)
+			}
+		})
+	}
+}
+
+func TestDeleteAnimalCancelsOpenTransferRequests(t *testing.T) {
+	z := newTestZoo(t)
+	home, other := z.enclosure("Forest", model.Predator, 2), z.enclosure("Rocks", model.Predator, 2)
+	animal := z.add(wolf("Grey", home.ID))
+	requests := NewTransferRequestService(z.uow, z.store.TransferRequests)
+	curator := requestcontext.WithActor(context.Background(), requestcontext.Actor{ID: uuid.New(), Name: "curator", Role: model.RoleCurator})
+
+	requested, err := requests.CreateRequest(z.ctx, animal.ID, other.ID, time.Now().AddDate(0, 1, 0), "swap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	approved, err := requests.CreateRequest(z.ctx, animal.ID, other.ID, time.Now().AddDate(0, 2, 0), "swap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := requests.Approve(curator, approved.ID, model.AnyVersion); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := z.animals.DeleteAnimal(z.ctx, animal.ID, model.AnyVersion); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []uuid.UUID{requested.ID, approved.ID} {
+		request, err := z.store.TransferRequests.FindByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if request.Status != model.TransferCancelled || request.CancelledBy != "test" || request.Comment == "" {
+			t.Fatalf("request after delete = %+v, want cancelled by test with a reason", request)
+		}
+	}
+}
+
+func TestAddAnimalRecordsArrivalDate(t *testing.T) {
+	z := newTestZoo(t)
+	enclosure := z.enclosure("Forest", model.Predator, 2)
+	arrived := time.Date(2023, 5, 20, 0, 0, 0, 0, time.UTC)
+
+	data := wolf("Akela", enclosure.ID)
+	data.ArrivalDate = arrived
+	animal := z.add(data)
+	if !animal.ArrivalDate.Equal(arrived) || len(animal.StateHistory) != 1 || !animal.StateHistory[0].Date.Equal(arrived) {
+		t.Fatalf("arrival = %v, history = %+v, want both on %v", animal.ArrivalDate, animal.StateHistory, arrived)
+	}
+	// Переход можно датировать днём после поступления, хотя внесён он сегодня
+	if err := animal.ChangeState(model.StateOffDisplay, arrived.AddDate(0, 0, 1), "acclimatisation", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now()
+	unknown := z.add(wolf("Raksha", enclosure.ID))
+	if unknown.ArrivalDate.Before(before) || !unknown.StateHistory[0].Date.Equal(unknown.ArrivalDate) {
+		t.Fatalf("arrival without a date = %v, want now", unknown.ArrivalDate)
+	}
+
+	for name, date := range map[string]time.Time{
+		"future":       time.Now().AddDate(0, 0, 1),
+		"before birth": time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC),
+	} {
+		data := wolf("Late", enclosure.ID)
+		data.ArrivalDate = date
+		if _, err := z.animals.AddAnimal(z.ctx, data); !errors.Is(err, model.ErrValidation) {
+			t.Errorf("%s arrival: AddAnimal() error = %v, want %v", name, err, model.ErrValidation)
+		}
+	}
+}
@@ -1,3 +1,325 @@
 package services
 
-//AnimalTransferService - просто 1 функция для пермещения?
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+
+	"github.com/google/uuid"
+)
+
+// AnimalTransferService - перемещение животного между вольерами.
+// Животное, старый и новый вольер меняются в одной транзакции.
+type AnimalTransferService struct {
+	uow RP.IUnitOfWorkFactory
+}
+
+func NewAnimalTransferService(uow RP.IUnitOfWorkFactory) *AnimalTransferService {
+	return &AnimalTransferService{uow: uow}
+}
+
+// TransferAnimal - reason и автор из ctx попадают в историю перемещений
+func (s *AnimalTransferService) TransferAnimal(ctx context.Context, animalID uuid.UUID, toEnclosureID uuid.UUID, reason string) error {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if reason == "" {
+		reason = model.ReasonTransfer
+	}
+	if err := moveAnimal(tx, animalID, toEnclosureID, reason, requestcontext.ActorFrom(ctx).Name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// TransferMove - одно перемещение в пакете
+type TransferMove struct {
+	AnimalID      uuid.UUID `json:"animalId"`
+	ToEnclosureID uuid.UUID `json:"toEnclosureId"`
+}
+
+// TransferMoveResult - итог проверки одного перемещения
+type TransferMoveResult struct {
+	TransferMove
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type BatchTransferResult struct {
+	Moves   []TransferMoveResult `json:"moves"`
+	Valid   bool                 `json:"valid"`
+	Applied bool                 `json:"applied"`
+}
+
+// TransferBatch - проверяет перемещения по очереди: каждое следующее видит
+// результат предыдущих. Перемещение в заполненный вольер откладывается, если
+// место в нём освобождают следующие перемещения пакета (обмен), и выполняется,
+// как только место освободилось. Если отложенное перемещение так и не выполнилось,
+// пакет проверяется заново, а это животное считается оставшимся на месте.
+// Если apply и все перемещения допустимы, они применяются вместе, иначе ни одно.
+func (s *AnimalTransferService) TransferBatch(ctx context.Context, moves []TransferMove, apply bool, reason string) (*BatchTransferResult, error) {
+	if len(moves) == 0 {
+		return nil, fmt.Errorf("%w: пустой список перемещений", model.ErrValidation)
+	}
+	if reason == "" {
+		reason = model.ReasonTransfer
+	}
+
+	// Каждый проход отбрасывает хотя бы одно перемещение, так что их не больше len(moves)
+	failed := make(map[int]error)
+	for {
+		result, done, err := s.runBatch(ctx, moves, failed, apply, reason)
+		if err != nil || done {
+			return result, err
+		}
+	}
+}
+
+// runBatch - один проход TransferBatch. Перемещения из failed не выполняются
+// и сразу считаются недопустимыми. Если отложенные перемещения остались
+// невыполненными, они добавляются в failed и done = false
+func (s *AnimalTransferService) runBatch(ctx context.Context, moves []TransferMove, failed map[int]error, apply bool, reason string) (*BatchTransferResult, bool, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	batch := &transferBatch{
+		tx:      tx,
+		moves:   moves,
+		reason:  reason,
+		actor:   requestcontext.ActorFrom(ctx).Name,
+		result:  &BatchTransferResult{Moves: make([]TransferMoveResult, len(moves)), Valid: true},
+		waiting: make(map[int]*model.Animal),
+	}
+	seen := make(map[uuid.UUID]bool, len(moves))
+	for i, move := range moves {
+		batch.result.Moves[i] = TransferMoveResult{TransferMove: move, OK: true}
+		if err, ok := failed[i]; ok {
+			batch.fail(i, err)
+			continue
+		}
+		if seen[move.AnimalID] {
+			batch.fail(i, fmt.Errorf("%w: животное уже перемещается в этом пакете", model.ErrValidation))
+			continue
+		}
+		seen[move.AnimalID] = true
+		if err := batch.move(i); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if len(batch.waiting) > 0 {
+		for i := range batch.waiting {
+			failed[i] = model.ErrEnclosureFull
+		}
+		return nil, false, nil
+	}
+	result := batch.result
+	if !apply || !result.Valid {
+		return result, true, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	result.Applied = true
+	return result, true, nil
+}
+
+// transferBatch - состояние одного прохода пакета
+type transferBatch struct {
+	tx     RP.IUnitOfWork
+	moves  []TransferMove
+	reason string
+	actor  string
+	result *BatchTransferResult
+	// Животные отложенных перемещений: они покинули свой вольер
+	// и ждут места в новом
+	waiting map[int]*model.Animal
+}
+
+func (b *transferBatch) fail(i int, err error) {
+	b.result.Moves[i].OK = false
+	b.result.Moves[i].Error = err.Error()
+	b.result.Valid = false
+}
+
+// move - выполняет перемещение i или откладывает его до освобождения места.
+// Ошибка возвращается, только если сломалось само хранилище
+func (b *transferBatch) move(i int) error {
+	move := b.moves[i]
+	animal, err := checkMove(b.tx, move)
+	if err != nil {
+		b.fail(i, err)
+		return nil
+	}
+	if animal == nil {
+		return nil
+	}
+	to, err := b.tx.Enclosures().FindByID(move.ToEnclosureID)
+	if err != nil {
+		return err
+	}
+	err = to.CanAccept(*animal)
+	if errors.Is(err, model.ErrEnclosureFull) {
+		vacating, err := b.vacatedLater(i)
+		if err != nil {
+			return err
+		}
+		if vacating {
+			b.waiting[i] = animal
+			return leaveEnclosure(b.tx, *animal)
+		}
+		b.fail(i, model.ErrEnclosureFull)
+		return nil
+	}
+	if err != nil {
+		b.fail(i, err)
+		return nil
+	}
+
+	from := animal.EnclosureID
+	if err := leaveEnclosure(b.tx, *animal); err != nil {
+		return err
+	}
+	if err := enterEnclosure(b.tx, animal, move.ToEnclosureID, b.reason, b.actor); err != nil {
+		return err
+	}
+	return b.settle(from)
+}
+
+// vacatedLater - забирает ли какое-то из следующих перемещений пакета
+// животное из вольера, куда ведёт перемещение i
+func (b *transferBatch) vacatedLater(i int) (bool, error) {
+	target := b.moves[i].ToEnclosureID
+	for _, later := range b.moves[i+1:] {
+		if later.ToEnclosureID == target {
+			continue
+		}
+		animal, err := b.tx.Animals().FindByID(later.AnimalID)
+		if errors.Is(err, model.ErrAnimalNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if animal.EnclosureID == target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// settle - заселяет в освободившийся вольер отложенные перемещения по порядку,
+// пока хватает места. Каждое заселённое животное освобождает место
+// в своём прежнем вольере
+func (b *transferBatch) settle(enclosureID uuid.UUID) error {
+	for i := range b.moves {
+		animal, ok := b.waiting[i]
+		if !ok || b.moves[i].ToEnclosureID != enclosureID {
+			continue
+		}
+		to, err := b.tx.Enclosures().FindByID(enclosureID)
+		if err != nil {
+			return err
+		}
+		if to.CanAccept(*animal) != nil {
+			return nil
+		}
+		from := animal.EnclosureID
+		if err := enterEnclosure(b.tx, animal, enclosureID, b.reason, b.actor); err != nil {
+			return err
+		}
+		delete(b.waiting, i)
+		if err := b.settle(from); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMove - животное, которое нужно переселить; nil, если оно уже в этом вольере
+func checkMove(tx RP.IUnitOfWork, move TransferMove) (*model.Animal, error) {
+	animal, err := tx.Animals().FindByID(move.AnimalID)
+	if err != nil {
+		return nil, err
+	}
+	if !animal.IsPresent() {
+		return nil, fmt.Errorf("%w: животное выбыло из зоопарка (%s)", model.ErrInvalidTransition, animal.State)
+	}
+	if animal.EnclosureID == move.ToEnclosureID {
+		return nil, nil
+	}
+	if _, err := tx.Enclosures().FindByID(move.ToEnclosureID); err != nil {
+		return nil, err
+	}
+	return animal, nil
+}
+
+// leaveEnclosure - освобождает место животного в его вольере; сам вольер
+// у животного меняет enterEnclosure
+func leaveEnclosure(tx RP.IUnitOfWork, animal model.Animal) error {
+	if animal.EnclosureID == uuid.Nil {
+		return nil
+	}
+	from, err := tx.Enclosures().FindByID(animal.EnclosureID)
+	if errors.Is(err, model.ErrEnclosureNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	from.DeleteAnimal(animal)
+	return tx.Enclosures().Update(*from)
+}
+
+// enterEnclosure - заселяет животное, уже покинувшее свой вольер,
+// и записывает перемещение в историю
+func enterEnclosure(tx RP.IUnitOfWork, animal *model.Animal, toEnclosureID uuid.UUID, reason string, actor string) error {
+	to, err := tx.Enclosures().FindByID(toEnclosureID)
+	if err != nil {
+		return err
+	}
+	if err := to.AddAnimal(*animal); err != nil {
+		return err
+	}
+	if err := tx.Enclosures().Update(*to); err != nil {
+		return err
+	}
+
+	movement := model.NewMovement(animal.ID, animal.EnclosureID, to.ID, reason, actor)
+	if err := tx.Movements().Add(*movement); err != nil {
+		return err
+	}
+
+	animal.Replace(to)
+	return tx.Animals().Save(*animal)
+}
+
+// moveAnimal - переселяет животное внутри уже открытой транзакции
+// и записывает перемещение в историю
+func moveAnimal(tx RP.IUnitOfWork, animalID uuid.UUID, toEnclosureID uuid.UUID, reason string, actor string) error {
+	animal, err := checkMove(tx, TransferMove{AnimalID: animalID, ToEnclosureID: toEnclosureID})
+	if err != nil || animal == nil {
+		return err
+	}
+	to, err := tx.Enclosures().FindByID(toEnclosureID)
+	if err != nil {
+		return err
+	}
+	// Вольер проверяется до того, как животное покинет свой
+	if err := to.CanAccept(*animal); err != nil {
+		return err
+	}
+	if err := leaveEnclosure(tx, *animal); err != nil {
+		return err
+	}
+	return enterEnclosure(tx, animal, toEnclosureID, reason, actor)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"testing"
+)
+
+func TestTransferBatchSwapsFullEnclosures(t *testing.T) {
+	z := newTestZoo(t)
+	transfers := NewAnimalTransferService(z.uow)
+	north, south := z.enclosure("North", model.Predator, 1), z.enclosure("South", model.Predator, 1)
+	a, b := z.add(wolf("A", north.ID)), z.add(wolf("B", south.ID))
+
+	result, err := transfers.TransferBatch(z.ctx, []TransferMove{
+		{AnimalID: a.ID, ToEnclosureID: south.ID},
+		{AnimalID: b.ID, ToEnclosureID: north.ID},
+	}, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || !result.Applied {
+		t.Fatalf("swap result = %+v, want valid and applied", result)
+	}
+	if moved, _ := z.store.Animals.FindByID(a.ID); moved.EnclosureID != south.ID {
+		t.Fatalf("A is in %s, want South", moved.EnclosureID)
+	}
+	if moved, _ := z.store.Animals.FindByID(b.ID); moved.EnclosureID != north.ID {
+		t.Fatalf("B is in %s, want North", moved.EnclosureID)
+	}
+	if z.count(north.ID) != 1 || z.count(south.ID) != 1 {
+		t.Fatalf("counts after swap: North %d, South %d, want 1 and 1", z.count(north.ID), z.count(south.ID))
+	}
+	if movements, _ := z.store.Movements.FindByAnimalID(a.ID); len(movements) != 2 || movements[1].FromEnclosureID != north.ID {
+		t.Fatalf("A's movements = %+v, want arrival and North -> South", movements)
+	}
+}
+
+func TestTransferBatchRejectsOverfilledEndState(t *testing.T) {
+	z := newTestZoo(t)
+	transfers := NewAnimalTransferService(z.uow)
+	north, south := z.enclosure("North", model.Predator, 1), z.enclosure("South", model.Predator, 2)
+	a, b, c := z.add(wolf("A", north.ID)), z.add(wolf("B", south.ID)), z.add(wolf("C", south.ID))
+
+	result, err := transfers.TransferBatch(z.ctx, []TransferMove{
+		{AnimalID: a.ID, ToEnclosureID: south.ID},
+		{AnimalID: b.ID, ToEnclosureID: north.ID},
+		{AnimalID: c.ID, ToEnclosureID: north.ID},
+	}, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid || result.Applied {
+		t.Fatalf("result = %+v, want invalid and not applied", result)
+	}
+	if !result.Moves[0].OK || !result.Moves[1].OK || result.Moves[2].OK {
+		t.Fatalf("moves = %+v, want only the third to fail", result.Moves)
+	}
+	if z.count(north.ID) != 1 || z.count(south.ID) != 2 {
+		t.Fatalf("counts changed after a rejected batch: North %d, South %d", z.count(north.ID), z.count(south.ID))
+	}
+}
+
+func TestTransferBatchRejectsRepeatedAnimal(t *testing.T) {
+	z := newTestZoo(t)
+	transfers := NewAnimalTransferService(z.uow)
+	north, south, east := z.enclosure("North", model.Predator, 2), z.enclosure("South", model.Predator, 2), z.enclosure("East", model.Predator, 2)
+	a := z.add(wolf("A", north.ID))
+
+	result, err := transfers.TransferBatch(z.ctx, []TransferMove{
+		{AnimalID: a.ID, ToEnclosureID: south.ID},
+		{AnimalID: a.ID, ToEnclosureID: east.ID},
+	}, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid || !result.Moves[0].OK || result.Moves[1].OK {
+		t.Fatalf("result = %+v, want the repeated move rejected", result)
+	}
+}
+
+func TestTransferBatchRotatesThroughFullEnclosures(t *testing.T) {
+	z := newTestZoo(t)
+	transfers := NewAnimalTransferService(z.uow)
+	north, south, east := z.enclosure("North", model.Predator, 1), z.enclosure("South", model.Predator, 1), z.enclosure("East", model.Predator, 1)
+	a, b, c := z.add(wolf("A", north.ID)), z.add(wolf("B", south.ID)), z.add(wolf("C", east.ID))
+
+	result, err := transfers.TransferBatch(z.ctx, []TransferMove{
+		{AnimalID: a.ID, ToEnclosureID: south.ID},
+		{AnimalID: b.ID, ToEnclosureID: east.ID},
+		{AnimalID: c.ID, ToEnclosureID: north.ID},
+	}, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || !result.Applied {
+		t.Fatalf("rotation result = %+v, want valid and applied", result)
+	}
+	for _, enclosure := range []*model.Enclosure{north, south, east} {
+		if z.count(enclosure.ID) != 1 {
+			t.Fatalf("%s holds %d animals after rotation, want 1", enclosure.Name, z.count(enclosure.ID))
+		}
+	}
+}
+
+func TestTransferBatchRejectsMoveIntoOccupantThatStays(t *testing.T) {
+	z := newTestZoo(t)
+	transfers := NewAnimalTransferService(z.uow)
+	north, south := z.enclosure("North", model.Predator, 1), z.enclosure("South", model.Predator, 1)
+	a := z.add(wolf("A", north.ID))
+	z.add(wolf("B", south.ID))
+
+	// Из South никто не уезжает, так что перемещение не откладывается
+	result, err := transfers.TransferBatch(z.ctx, []TransferMove{
+		{AnimalID: a.ID, ToEnclosureID: south.ID},
+	}, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid || result.Moves[0].Error != model.ErrEnclosureFull.Error() {
+		t.Fatalf("result = %+v, want the move rejected as full", result)
+	}
+	if z.count(north.ID) != 1 || z.count(south.ID) != 1 {
+		t.Fatalf("counts after a dry run: North %d, South %d", z.count(north.ID), z.count(south.ID))
+	}
+}
+
+func TestTransferBatchKeepsFailedSwapInPlace(t *testing.T) {
+	z := newTestZoo(t)
+	transfers := NewAnimalTransferService(z.uow)
+	north, south := z.enclosure("North", model.Predator, 1), z.enclosure("South", model.Predator, 1)
+	pasture := z.enclosure("Pasture", model.Herbivore, 5)
+	spare := z.enclosure("Spare", model.Predator, 1)
+	a, b, c := z.add(wolf("A", north.ID)), z.add(wolf("B", south.ID)), z.add(wolf("C", spare.ID))
+
+	// A ждёт, пока B освободит South, но B не может переехать на пастбище.
+	// Значит, A остаётся в North, и C туда не помещается
+	result, err := transfers.TransferBatch(z.ctx, []TransferMove{
+		{AnimalID: a.ID, ToEnclosureID: south.ID},
+		{AnimalID: c.ID, ToEnclosureID: north.ID},
+		{AnimalID: b.ID, ToEnclosureID: pasture.ID},
+	}, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{model.ErrEnclosureFull.Error(), model.ErrEnclosureFull.Error(), model.ErrIncompatibleEnclosure.Error()}
+	for i, move := range result.Moves {
+		if move.OK || move.Error != want[i] {
+			t.Fatalf("move %d = %+v, want error %q", i, move, want[i])
+		}
+	}
+}
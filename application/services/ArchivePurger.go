@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivePurger - окончательно удаляет животных, вольеры и кормления,
+// мягко удалённые раньше, чем retention назад. Вместе с животным удаляются
+// его медицинская карта, история перемещений и заявки на перемещение.
+// Очистка попадает в журнал аудита как удаление от имени автора из ctx.
+// Животное, которое записано родителем животного, оставшегося после очистки,
+// остаётся в архиве ради родословной.
+type ArchivePurger struct {
+	uow       RP.IUnitOfWorkFactory
+	retention time.Duration
+	interval  time.Duration
+}
+
+// PurgeReport - сколько записей очищено
+type PurgeReport struct {
+	Animals    int `json:"animals"`
+	Enclosures int `json:"enclosures"`
+	Schedules  int `json:"schedules"`
+}
+
+func (r PurgeReport) Total() int {
+	return r.Animals + r.Enclosures + r.Schedules
+}
+
+func NewArchivePurger(uow RP.IUnitOfWorkFactory, retention time.Duration, interval time.Duration) *ArchivePurger {
+	return &ArchivePurger{uow: uow, retention: retention, interval: interval}
+}
+
+// Run - очищает архив каждые interval, пока не отменён ctx
+func (p *ArchivePurger) Run(ctx context.Context) {
+	runEvery(ctx, p.interval, func(now time.Time) {
+		report, err := p.Purge(ctx, now)
+		if err != nil {
+			slog.Error("не удалось очистить удалённые записи", "error", err)
+			return
+		}
+		if report.Total() > 0 {
+			slog.Info("удалённые записи очищены", "animals", report.Animals,
+				"enclosures", report.Enclosures, "schedules", report.Schedules)
+		}
+	})
+}
+
+// Purge - очищает всё, что удалено раньше now - retention, в одной транзакции
+func (p *ArchivePurger) Purge(ctx context.Context, now time.Time) (*PurgeReport, error) {
+	cutoff := now.Add(-p.retention)
+
+	tx, err := p.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var report PurgeReport
+	schedules, err := tx.FeedingSchedules().GetDeletedSchedules()
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		if !schedule.DeletedBefore(cutoff) {
+			continue
+		}
+		if err := tx.FeedingSchedules().DeleteSchedule(schedule.ID); err != nil {
+			return nil, err
+		}
+		report.Schedules++
+	}
+
+	if report.Animals, err = purgeAnimals(tx, cutoff); err != nil {
+		return nil, err
+	}
+
+	enclosures, err := tx.Enclosures().FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+	for _, enclosure := range enclosures {
+		if !enclosure.DeletedBefore(cutoff) {
+			continue
+		}
+		if err := tx.Enclosures().Delete(enclosure.ID); err != nil {
+			return nil, err
+		}
+		report.Enclosures++
+	}
+
+	if report.Total() == 0 {
+		return &report, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// purgeAnimals - очищает животных проходами: родитель, чьё последнее потомство
+// очищено в этом же проходе, очищается в следующем
+func purgeAnimals(tx RP.IUnitOfWork, cutoff time.Time) (int, error) {
+	purged := 0
+	for {
+		animals, err := tx.Animals().FindDeleted()
+		if err != nil {
+			return 0, err
+		}
+		parents, err := recordedParents(tx)
+		if err != nil {
+			return 0, err
+		}
+		pass := 0
+		for _, animal := range animals {
+			// Родитель остаётся в архиве, пока на него ссылается родословная
+			if !animal.DeletedBefore(cutoff) || parents[animal.ID] {
+				continue
+			}
+			if err := purgeAnimalRecords(tx, animal.ID); err != nil {
+				return 0, err
+			}
+			if err := tx.Animals().Delete(animal.ID); err != nil {
+				return 0, err
+			}
+			pass++
+		}
+		if pass == 0 {
+			return purged, nil
+		}
+		purged += pass
+	}
+}
+
+// purgeAnimalRecords - записи, которые без животного ни к чему не относятся
+func purgeAnimalRecords(tx RP.IUnitOfWork, animalID uuid.UUID) error {
+	if err := tx.MedicalRecords().DeleteByAnimalID(animalID); err != nil {
+		return err
+	}
+	if err := tx.Movements().DeleteByAnimalID(animalID); err != nil {
+		return err
+	}
+	return tx.TransferRequests().DeleteByAnimalID(animalID)
+}
+
+// recordedParents - животные, указанные отцом или матерью у любого животного, в том числе удалённого
+func recordedParents(tx RP.IUnitOfWork) (map[uuid.UUID]bool, error) {
+	animals, err := pedigreeAnimals(tx.Animals())
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[uuid.UUID]bool)
+	for _, animal := range animals {
+		for _, parent := range []*model.Parent{animal.Sire, animal.Dam} {
+			if parent != nil && parent.AnimalID != nil {
+				parents[*parent.AnimalID] = true
+			}
+		}
+	}
+	return parents, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"testing"
+	"time"
+)
+
+func TestArchivePurgerRemovesAnimalRecords(t *testing.T) {
+	z := newTestZoo(t)
+	home := z.enclosure("Forest", model.Predator, 4)
+	other := z.enclosure("Rocks", model.Predator, 4)
+	add := func(name string, born time.Time, sire *model.Parent) *model.Animal {
+		animal := wolf(name, home.ID)
+		animal.BirthDate = born
+		animal.HealthStatus = model.Sick
+		animal.Sire = sire
+		return z.add(animal)
+	}
+	father := add("Father", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+	son := add("Son", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), &model.Parent{AnimalID: &father.ID})
+	add("Daughter", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), &model.Parent{AnimalID: &father.ID})
+	request, err := NewTransferRequestService(z.uow, z.store.TransferRequests).CreateRequest(z.ctx, son.ID, other.ID, time.Now().AddDate(0, 1, 0), "swap")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, animal := range []*model.Animal{son, father} {
+		if err := z.animals.DeleteAnimal(z.ctx, animal.ID, model.AnyVersion); err != nil {
+			t.Fatal(err)
+		}
+	}
+	report, err := NewArchivePurger(z.uow, time.Hour, time.Hour).Purge(z.ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Animals != 1 {
+		t.Fatalf("purged %d animals, want only the son", report.Animals)
+	}
+
+	if _, err := z.store.Animals.FindDeletedByID(son.ID); err != model.ErrAnimalNotFound {
+		t.Fatalf("son after purge: error = %v, want %v", err, model.ErrAnimalNotFound)
+	}
+	if record, _ := z.store.MedicalRecords.FindByAnimalID(son.ID); len(record.Cases) != 0 || len(record.Entries) != 0 {
+		t.Fatalf("son's medical record after purge = %+v", record)
+	}
+	if movements, _ := z.store.Movements.FindByAnimalID(son.ID); len(movements) != 0 {
+		t.Fatalf("son's movements after purge = %+v", movements)
+	}
+	if _, err := z.store.TransferRequests.FindByID(request.ID); err != model.ErrTransferNotFound {
+		t.Fatalf("son's transfer request after purge: error = %v, want %v", err, model.ErrTransferNotFound)
+	}
+
+	// Отец записан родителем дочери, которая осталась в зоопарке,
+	// и остаётся в архиве вместе со своими записями
+	if _, err := z.store.Animals.FindDeletedByID(father.ID); err != nil {
+		t.Fatalf("father after purge: %v", err)
+	}
+	if record, _ := z.store.MedicalRecords.FindByAnimalID(father.ID); len(record.Cases) == 0 {
+		t.Fatal("father's medical record was purged")
+	}
+	if movements, _ := z.store.Movements.FindByAnimalID(father.ID); len(movements) == 0 {
+		t.Fatal("father's movements were purged")
+	}
+}
+
+func TestArchivePurgerRemovesWholeArchivedLineage(t *testing.T) {
+	z := newTestZoo(t)
+	home := z.enclosure("Forest", model.Predator, 4)
+	var lineage []*model.Animal
+	for i, name := range []string{"Grandfather", "Father", "Son"} {
+		animal := wolf(name, home.ID)
+		animal.BirthDate = time.Date(2010+5*i, 1, 1, 0, 0, 0, 0, time.UTC)
+		if i > 0 {
+			animal.Sire = &model.Parent{AnimalID: &lineage[i-1].ID}
+		}
+		lineage = append(lineage, z.add(animal))
+	}
+	for _, animal := range lineage {
+		if err := z.animals.DeleteAnimal(z.ctx, animal.ID, model.AnyVersion); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Родословную больше никто не держит, поэтому уходят все три поколения за один запуск
+	report, err := NewArchivePurger(z.uow, time.Hour, time.Hour).Purge(z.ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Animals != 3 {
+		t.Fatalf("purged %d animals, want 3", report.Animals)
+	}
+	if archived, _ := z.store.Animals.FindDeleted(); len(archived) != 0 {
+		t.Fatalf("archive after purge = %+v, want empty", archived)
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"time"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// AuditService - чтение, проверка и выгрузка журнала изменений.
+// Записи создаёт сама транзакция при фиксации, поэтому изменять журнал здесь нельзя.
+type AuditService struct {
+	repo RP.IAuditRepository
+	key  ed25519.PrivateKey
+}
+
+// key - ключ, которым подписываются выгрузки для проверяющих
+func NewAuditService(repo RP.IAuditRepository, key ed25519.PrivateKey) *AuditService {
+	return &AuditService{repo: repo, key: key}
+}
+
+func (s *AuditService) Find(filter model.AuditFilter) ([]model.AuditEntry, error) {
+	switch filter.Action {
+	case "", model.AuditCreate, model.AuditUpdate, model.AuditDelete, model.AuditArchive, model.AuditRestore:
+	default:
+		return nil, fmt.Errorf("%w: неизвестное действие %q", model.ErrValidation, filter.Action)
+	}
+	if filter.Limit < 0 || filter.Limit > maxAuditLimit {
+		return nil, fmt.Errorf("%w: limit должен быть от 1 до %d", model.ErrValidation, maxAuditLimit)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	return s.repo.Find(filter)
+}
+
+// Verify - проверяет, что ни одна запись журнала не изменена и не удалена
+func (s *AuditService) Verify() (model.AuditVerification, error) {
+	entries, err := s.repo.All()
+	if err != nil {
+		return model.AuditVerification{}, err
+	}
+	return model.VerifyAuditChain(entries), nil
+}
+
+// Export - весь журнал, подписанный ключом зоопарка
+func (s *AuditService) Export() (*model.AuditBundle, error) {
+	entries, err := s.repo.All()
+	if err != nil {
+		return nil, err
+	}
+	return model.NewAuditBundle(entries, s.key, time.Now())
+}
+
+func (s *AuditService) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHasher - хеширование паролей
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash string, password string) bool
+}
+
+// TokenIssuer - выдача и проверка токенов доступа. generation - поколение
+// токенов пользователя (model.User.TokenGeneration) на момент выдачи
+type TokenIssuer interface {
+	Issue(userID uuid.UUID, generation int, now time.Time) (string, time.Time, error)
+	Parse(token string, now time.Time) (userID uuid.UUID, generation int, err error)
+}
+
+// AuthService - вход по паролю и проверка токенов
+type AuthService struct {
+	uow    RP.IUnitOfWorkFactory
+	users  RP.IUserRepository
+	hasher PasswordHasher
+	tokens TokenIssuer
+	// dummyHash - чтобы вход с несуществующим именем занимал столько же времени
+	dummyHash string
+}
+
+func NewAuthService(uow RP.IUnitOfWorkFactory, users RP.IUserRepository, hasher PasswordHasher, tokens TokenIssuer) (*AuthService, error) {
+	dummyHash, err := hasher.Hash("dummy-password")
+	if err != nil {
+		return nil, err
+	}
+	return &AuthService{uow: uow, users: users, hasher: hasher, tokens: tokens, dummyHash: dummyHash}, nil
+}
+
+type LoginResult struct {
+	Token     string     `json:"token"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	User      model.User `json:"user"`
+}
+
+func (s *AuthService) Login(username string, password string) (*LoginResult, error) {
+	user, err := s.users.FindByUsername(username)
+	if errors.Is(err, model.ErrUserNotFound) {
+		s.hasher.Verify(s.dummyHash, password)
+		return nil, model.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !s.hasher.Verify(user.PasswordHash, password) || user.Disabled {
+		return nil, model.ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := s.tokens.Issue(user.ID, user.TokenGeneration, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Token: token, ExpiresAt: expiresAt, User: *user}, nil
+}
+
+// Authenticate - пользователь по токену; роль и блокировка берутся из репозитория,
+// поэтому изменения вступают в силу сразу, без перевыпуска токена. Токен,
+// выданный до смены пароля, отклоняется.
+func (s *AuthService) Authenticate(token string) (requestcontext.Actor, error) {
+	userID, generation, err := s.tokens.Parse(token, time.Now())
+	if err != nil {
+		return requestcontext.Actor{}, fmt.Errorf("%w: %v", model.ErrUnauthorized, err)
+	}
+	user, err := s.users.FindByID(userID)
+	if errors.Is(err, model.ErrUserNotFound) {
+		return requestcontext.Actor{}, model.ErrUnauthorized
+	}
+	if err != nil {
+		return requestcontext.Actor{}, err
+	}
+	if user.Disabled {
+		return requestcontext.Actor{}, fmt.Errorf("%w: пользователь отключён", model.ErrUnauthorized)
+	}
+	if generation != user.TokenGeneration {
+		return requestcontext.Actor{}, fmt.Errorf("%w: токен отозван сменой пароля", model.ErrUnauthorized)
+	}
+	return requestcontext.Actor{ID: user.ID, Name: user.Username, Role: user.Role}, nil
+}
+
+// EnsureAdmin - при первом запуске, пока пользователей нет, создаёт администратора
+func (s *AuthService) EnsureAdmin(ctx context.Context, username string, password string) (bool, error) {
+	if err := model.ValidatePassword(password); err != nil {
+		return false, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	users, err := tx.Users().FindAll()
+	if err != nil {
+		return false, err
+	}
+	if len(users) > 0 {
+		return false, nil
+	}
+
+	admin, err := model.NewUser(username, model.RoleAdmin, hash)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+	if err := tx.Users().Save(*admin); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/infrastructure/auth"
+	"testing"
+	"time"
+)
+
+func newTestAuth(t *testing.T, z *testZoo) (*AuthService, *UserService) {
+	t.Helper()
+	hasher := auth.PBKDF2Hasher{}
+	authService, err := NewAuthService(z.uow, z.store.Users, hasher, auth.NewHMACTokenIssuer([]byte("test-secret"), time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authService, NewUserService(z.uow, z.store.Users, hasher)
+}
+
+func TestAuthServiceLogin(t *testing.T) {
+	z := newTestZoo(t)
+	authService, users := newTestAuth(t, z)
+	keeper, err := users.CreateUser(z.ctx, "keeper1", model.RoleKeeper, "correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, credentials := range [][2]string{{"keeper1", "wrong-password"}, {"nobody", "correct-horse"}} {
+		if _, err := authService.Login(credentials[0], credentials[1]); !errors.Is(err, model.ErrInvalidCredentials) {
+			t.Fatalf("Login(%q, %q) error = %v, want %v", credentials[0], credentials[1], err, model.ErrInvalidCredentials)
+		}
+	}
+	login, err := authService.Login("keeper1", "correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actor, err := authService.Authenticate(login.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actor.ID != keeper.ID || actor.Role != model.RoleKeeper {
+		t.Fatalf("Authenticate() = %+v, want keeper1", actor)
+	}
+	if _, err := authService.Authenticate(login.Token + "x"); !errors.Is(err, model.ErrUnauthorized) {
+		t.Fatalf("Authenticate() of a tampered token error = %v, want %v", err, model.ErrUnauthorized)
+	}
+
+	// Роль и блокировка читаются на каждом запросе
+	disabled := true
+	if _, err := users.UpdateUser(z.ctx, keeper.ID, UserUpdate{Disabled: &disabled}, model.AnyVersion); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authService.Authenticate(login.Token); !errors.Is(err, model.ErrUnauthorized) {
+		t.Fatalf("Authenticate() of a disabled user error = %v, want %v", err, model.ErrUnauthorized)
+	}
+	if _, err := authService.Login("keeper1", "correct-horse"); !errors.Is(err, model.ErrInvalidCredentials) {
+		t.Fatalf("Login() of a disabled user error = %v, want %v", err, model.ErrInvalidCredentials)
+	}
+}
+
+func TestAuthServicePasswordChangeRevokesTokens(t *testing.T) {
+	z := newTestZoo(t)
+	authService, users := newTestAuth(t, z)
+	keeper, err := users.CreateUser(z.ctx, "keeper1", model.RoleKeeper, "correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := authService.Login("keeper1", "correct-horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	password := "battery-staple"
+	if _, err := users.UpdateUser(z.ctx, keeper.ID, UserUpdate{Password: &password}, model.AnyVersion); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authService.Authenticate(before.Token); !errors.Is(err, model.ErrUnauthorized) {
+		t.Fatalf("Authenticate() of a token issued before the password change error = %v, want %v", err, model.ErrUnauthorized)
+	}
+	after, err := authService.Login("keeper1", password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authService.Authenticate(after.Token); err != nil {
+		t.Fatalf("Authenticate() of a new token error = %v", err)
+	}
+
+	// Смена роли токены не отзывает
+	role := model.RoleCurator
+	if _, err := users.UpdateUser(z.ctx, keeper.ID, UserUpdate{Role: &role}, model.AnyVersion); err != nil {
+		t.Fatal(err)
+	}
+	if actor, err := authService.Authenticate(after.Token); err != nil || actor.Role != model.RoleCurator {
+		t.Fatalf("Authenticate() after a role change = %+v, %v, want curator", actor, err)
+	}
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/dataexchange"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportMode - что делать со строками, которые не прошли проверку
+type ImportMode string
+
+const (
+	// ImportAllOrNothing - любая ошибочная строка отменяет загрузку целиком
+	ImportAllOrNothing ImportMode = "all-or-nothing"
+	// ImportSkipInvalid - ошибочные строки пропускаются, остальные загружаются
+	ImportSkipInvalid ImportMode = "skip-invalid"
+)
+
+// ParseImportMode - пустое значение - ImportAllOrNothing
+func ParseImportMode(value string) (ImportMode, error) {
+	switch mode := ImportMode(value); mode {
+	case "":
+		return ImportAllOrNothing, nil
+	case ImportAllOrNothing, ImportSkipInvalid:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: неизвестный режим загрузки %q", model.ErrValidation, value)
+	}
+}
+
+// ImportRowError - row - номер записи с 1; в CSV строка заголовка не считается
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type ImportReport struct {
+	Mode     ImportMode       `json:"mode"`
+	Total    int              `json:"total"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Applied  bool             `json:"applied"`
+	Created  []uuid.UUID      `json:"created"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// BulkService - массовая загрузка и выгрузка животных и вольеров.
+// Загрузка идёт одной транзакцией: каждая строка проверяется на состоянии
+// с учётом уже загруженных строк, например занятых ими мест в вольерах.
+type BulkService struct {
+	uow        RP.IUnitOfWorkFactory
+	animals    RP.IAnimalRepository
+	enclosures RP.IEnclosureRepository
+}
+
+func NewBulkService(uow RP.IUnitOfWorkFactory, animals RP.IAnimalRepository, enclosures RP.IEnclosureRepository) *BulkService {
+	return &BulkService{uow: uow, animals: animals, enclosures: enclosures}
+}
+
+// importRow - проверяет строку и возвращает действие, которое её загрузит.
+// Ошибка проверки попадает в отчёт, ошибка действия прерывает загрузку.
+type importRow func(tx RP.IUnitOfWork) (apply func() (uuid.UUID, error), err error)
+
+func (s *BulkService) ImportEnclosures(ctx context.Context, records []dataexchange.EnclosureRecord, mode ImportMode) (*ImportReport, error) {
+	rows := make([]importRow, len(records))
+	for i, record := range records {
+		rows[i] = func(tx RP.IUnitOfWork) (func() (uuid.UUID, error), error) {
+			return checkEnclosureRecord(tx, record)
+		}
+	}
+	return s.importRows(ctx, rows, mode)
+}
+
+func (s *BulkService) ImportAnimals(ctx context.Context, records []dataexchange.AnimalRecord, mode ImportMode) (*ImportReport, error) {
+	actor := requestcontext.ActorFrom(ctx)
+	rows := make([]importRow, len(records))
+	for i, record := range records {
+		rows[i] = func(tx RP.IUnitOfWork) (func() (uuid.UUID, error), error) {
+			return checkAnimalRecord(tx, record, actor)
+		}
+	}
+	return s.importRows(ctx, rows, mode)
+}
+
+func (s *BulkService) importRows(ctx context.Context, rows []importRow, mode ImportMode) (*ImportReport, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	report := &ImportReport{Mode: mode, Total: len(rows), Created: []uuid.UUID{}, Errors: []ImportRowError{}}
+	for i, row := range rows {
+		apply, err := row(tx)
+		if err != nil {
+			report.Errors = append(report.Errors, ImportRowError{Row: i + 1, Error: err.Error()})
+			continue
+		}
+		id, err := apply()
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %w", i+1, err)
+		}
+		report.Created = append(report.Created, id)
+	}
+	report.Failed = len(report.Errors)
+
+	if report.Failed > 0 && mode == ImportAllOrNothing {
+		report.Created = []uuid.UUID{}
+		return report, nil
+	}
+	if len(report.Created) > 0 {
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+	}
+	report.Imported = len(report.Created)
+	report.Applied = true
+	return report, nil
+}
+
+func checkEnclosureRecord(tx RP.IUnitOfWork, record dataexchange.EnclosureRecord) (func() (uuid.UUID, error), error) {
+	var size model.Size
+	var maxCapacity int
+	for _, field := range []struct {
+		name   string
+		value  json.Number
+		target *int
+	}{
+		{"length", record.Length, &size.Lenght},
+		{"width", record.Width, &size.Width},
+		{"height", record.Height, &size.Height},
+		{"maxCapacity", record.MaxCapacity, &maxCapacity},
+	} {
+		value, err := parseInt(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+		*field.target = value
+	}
+
+	enclosure, err := model.NewEnclosure(record.Name, enumValue[model.AnimalType](record.Type), size, maxCapacity)
+	if err != nil {
+		return nil, err
+	}
+	if enclosure.Name != "" {
+		if _, err := tx.Enclosures().FindByName(enclosure.Name); err == nil {
+			return nil, fmt.Errorf("name: %w", model.ErrEnclosureNameTaken)
+		}
+	}
+
+	return func() (uuid.UUID, error) {
+		return enclosure.ID, tx.Enclosures().Save(*enclosure)
+	}, nil
+}
+
+func checkAnimalRecord(tx RP.IUnitOfWork, record dataexchange.AnimalRecord, actor requestcontext.Actor) (func() (uuid.UUID, error), error) {
+	if err := checkArrivalHealth(actor.Role, enumValue[model.HealthStatus](record.HealthStatus)); err != nil {
+		return nil, fmt.Errorf("healthStatus: %w", err)
+	}
+	birthDate, err := parseDate(record.BirthDate)
+	if err != nil {
+		return nil, fmt.Errorf("birthDate: %w", err)
+	}
+	animal, err := model.NewAnimal(
+		record.Name,
+		model.Species{AnimalType: enumValue[model.AnimalType](record.AnimalType), Name: record.Species},
+		birthDate,
+		uuid.Nil,
+		enumValue[model.HealthStatus](record.HealthStatus),
+		enumValue[model.Gender](record.Gender),
+		model.Food{FoodType: enumValue[model.FoodType](record.FoodType), Name: record.FavoriteFood},
+	)
+	if err != nil {
+		return nil, err
+	}
+	arrived, err := parseOptionalDate(record.ArrivalDate)
+	if err != nil {
+		return nil, fmt.Errorf("arrivalDate: %w", err)
+	}
+	if err := animal.EnterLifecycle(enumValue[model.LifecycleState](record.State), arrived, model.ReasonArrival, actor.Name); err != nil {
+		return nil, fmt.Errorf("state: %w", err)
+	}
+
+	var enclosure *model.Enclosure
+	if record.Enclosure != "" {
+		if enclosure, err = findEnclosure(tx, record.Enclosure); err != nil {
+			return nil, fmt.Errorf("enclosure %q: %w", record.Enclosure, err)
+		}
+		if err := enclosure.CanAccept(*animal); err != nil {
+			return nil, fmt.Errorf("enclosure %q: %w", record.Enclosure, err)
+		}
+	}
+
+	return func() (uuid.UUID, error) {
+		if err := tx.Animals().Save(*animal); err != nil {
+			return uuid.Nil, err
+		}
+		if err := openArrivalCase(tx, *animal); err != nil {
+			return uuid.Nil, err
+		}
+		if enclosure != nil {
+			if err := moveAnimal(tx, animal.ID, enclosure.ID, model.ReasonArrival, actor.Name); err != nil {
+				return uuid.Nil, err
+			}
+		}
+		return animal.ID, nil
+	}, nil
+}
+
+// findEnclosure - ссылка на вольер: ID или название
+func findEnclosure(tx RP.IUnitOfWork, reference string) (*model.Enclosure, error) {
+	if id, err := uuid.Parse(reference); err == nil {
+		return tx.Enclosures().FindByID(id)
+	}
+	return tx.Enclosures().FindByName(reference)
+}
+
+// enumValue - значения перечислений в таблицах принимаются в любом регистре
+func enumValue[T ~string](value string) T {
+	return T(strings.ToLower(strings.TrimSpace(value)))
+}
+
+func parseInt(value json.Number) (int, error) {
+	if value == "" {
+		return 0, errors.New("обязательное поле")
+	}
+	number, err := value.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("ожидается целое число, получено %q", value)
+	}
+	return int(number), nil
+}
+
+// parseDate - дата YYYY-MM-DD или время RFC 3339
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("обязательное поле")
+	}
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ожидается дата YYYY-MM-DD, получено %q", value)
+	}
+	return date, nil
+}
+
+// parseOptionalDate - как parseDate, но пустое значение - нулевая дата
+func parseOptionalDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseDate(value)
+}
+
+func formatOptionalDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(time.DateOnly)
+}
+
+// ExportEnclosures - записи в том же виде, в каком их принимает ImportEnclosures
+func (s *BulkService) ExportEnclosures() ([]dataexchange.EnclosureRecord, error) {
+	enclosures, err := s.enclosures.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(enclosures, func(i, j int) bool {
+		if enclosures[i].Name != enclosures[j].Name {
+			return enclosures[i].Name < enclosures[j].Name
+		}
+		return enclosures[i].ID.String() < enclosures[j].ID.String()
+	})
+
+	records := make([]dataexchange.EnclosureRecord, 0, len(enclosures))
+	for _, enclosure := range enclosures {
+		records = append(records, dataexchange.EnclosureRecord{
+			ID:          enclosure.ID.String(),
+			Name:        enclosure.Name,
+			Type:        string(enclosure.Type),
+			Length:      intNumber(enclosure.Size.Lenght),
+			Width:       intNumber(enclosure.Size.Width),
+			Height:      intNumber(enclosure.Size.Height),
+			MaxCapacity: intNumber(enclosure.MaxCapacity),
+		})
+	}
+	return records, nil
+}
+
+// ExportAnimals - вольер указывается названием, если оно есть, иначе ID.
+// Выбывшие животные не выгружаются: загрузить их обратно всё равно нельзя.
+func (s *BulkService) ExportAnimals() ([]dataexchange.AnimalRecord, error) {
+	tx, err := s.uow.BeginRead(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	all, err := tx.Animals().FindAll()
+	if err != nil {
+		return nil, err
+	}
+	animals := model.PresentAnimals(all)
+	enclosures, err := tx.Enclosures().FindAll()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(enclosures))
+	for _, enclosure := range enclosures {
+		names[enclosure.ID] = enclosure.Name
+	}
+	sort.Slice(animals, func(i, j int) bool {
+		if animals[i].Name != animals[j].Name {
+			return animals[i].Name < animals[j].Name
+		}
+		return animals[i].ID.String() < animals[j].ID.String()
+	})
+
+	records := make([]dataexchange.AnimalRecord, 0, len(animals))
+	for _, animal := range animals {
+		var enclosure string
+		if animal.EnclosureID != uuid.Nil {
+			enclosure = names[animal.EnclosureID]
+			if enclosure == "" {
+				enclosure = animal.EnclosureID.String()
+			}
+		}
+		records = append(records, dataexchange.AnimalRecord{
+			ID:           animal.ID.String(),
+			Name:         animal.Name,
+			Species:      animal.Species.Name,
+			AnimalType:   string(animal.Species.AnimalType),
+			BirthDate:    animal.BirthDate.Format(time.DateOnly),
+			Gender:       string(animal.Gender),
+			HealthStatus: string(animal.HealthStatus),
+			FoodType:     string(animal.FavoriteFood.FoodType),
+			FavoriteFood: animal.FavoriteFood.Name,
+			Enclosure:    enclosure,
+			State:        string(animal.State),
+			ArrivalDate:  formatOptionalDate(animal.ArrivalDate),
+		})
+	}
+	return records, nil
+}
+
+func intNumber(value int) json.Number {
+	return json.Number(fmt.Sprint(value))
+}
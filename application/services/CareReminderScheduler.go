@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"kpo-mini-dz2/domain/model"
+	"log/slog"
+	"time"
+)
+
+// CareReminderScheduler - как FeedingScheduler, только для профилактики:
+// сообщает о процедурах, срок которых наступил с прошлой проверки.
+// Просроченные до запуска процедуры повторно не напоминаются,
+// они видны в списке PreventiveCareService.GetDueTasks.
+type CareReminderScheduler struct {
+	care     *PreventiveCareService
+	interval time.Duration
+	handler  func(model.CareTask)
+	lastTick time.Time
+}
+
+func NewCareReminderScheduler(care *PreventiveCareService, interval time.Duration, handler func(model.CareTask)) *CareReminderScheduler {
+	return &CareReminderScheduler{
+		care:     care,
+		interval: interval,
+		handler:  handler,
+		lastTick: time.Now(),
+	}
+}
+
+// Run - проверяет сроки каждые interval, пока не отменён ctx
+func (s *CareReminderScheduler) Run(ctx context.Context) {
+	runEvery(ctx, s.interval, s.Tick)
+}
+
+// Tick - сообщает о процедурах со сроком в (прошлая проверка, now]
+func (s *CareReminderScheduler) Tick(now time.Time) {
+	// Статус считается на момент прошлой проверки: к ней процедура ещё не просрочена
+	tasks, err := s.care.tasks(s.lastTick)
+	if err != nil {
+		slog.Error("не удалось получить сроки профилактики", "error", err)
+		return
+	}
+
+	for _, task := range tasks {
+		if task.DueAt.After(s.lastTick) && !task.DueAt.After(now) {
+			s.handler(task)
+		}
+	}
+	s.lastTick = now
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EnclosureService - добавление и удаление вольеров
+type EnclosureService struct {
+	uow RP.IUnitOfWorkFactory
+}
+
+func NewEnclosureService(uow RP.IUnitOfWorkFactory) *EnclosureService {
+	return &EnclosureService{uow: uow}
+}
+
+func (s *EnclosureService) AddEnclosure(ctx context.Context, name string, enclosureType model.AnimalType, size model.Size, maxCapacity int) (*model.Enclosure, error) {
+	enclosure, err := model.NewEnclosure(name, enclosureType, size, maxCapacity)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Enclosures().Save(*enclosure); err != nil {
+		return nil, err
+	}
+
+	created, err := tx.Enclosures().FindByID(enclosure.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateEnclosure - меняет название, размер и вместимость вольера.
+// Тип можно сменить только у пустого вольера.
+func (s *EnclosureService) UpdateEnclosure(ctx context.Context, id uuid.UUID, name string, enclosureType model.AnimalType, size model.Size, maxCapacity int, version int) (*model.Enclosure, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	enclosure, err := tx.Enclosures().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, enclosure.Version); err != nil {
+		return nil, err
+	}
+	if maxCapacity < enclosure.CurrentCount {
+		return nil, fmt.Errorf("%w: вместимость меньше числа животных в вольере", model.ErrValidation)
+	}
+	if enclosureType != enclosure.Type && enclosure.CurrentCount > 0 {
+		return nil, fmt.Errorf("%w: нельзя сменить тип непустого вольера", model.ErrValidation)
+	}
+
+	enclosure.Name = strings.TrimSpace(name)
+	enclosure.Type = enclosureType
+	enclosure.Size = size
+	enclosure.MaxCapacity = maxCapacity
+	if err := enclosure.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+	if err := tx.Enclosures().Update(*enclosure); err != nil {
+		return nil, err
+	}
+
+	updated, err := tx.Enclosures().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteEnclosure - мягко удаляет вольер; его животные остаются без вольера,
+// а восстановленный вольер будет пустым
+func (s *EnclosureService) DeleteEnclosure(ctx context.Context, id uuid.UUID, version int) error {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	enclosure, err := tx.Enclosures().FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := model.CheckVersion(version, enclosure.Version); err != nil {
+		return err
+	}
+
+	actor := requestcontext.ActorFrom(ctx).Name
+	for _, animalID := range enclosure.AnimalsID {
+		animal, err := tx.Animals().FindByID(animalID)
+		if errors.Is(err, model.ErrAnimalNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		animal.EnclosureID = uuid.Nil
+		if err := tx.Animals().Save(*animal); err != nil {
+			return err
+		}
+
+		movement := model.NewMovement(animalID, id, uuid.Nil, model.ReasonEnclosureRemoved, actor)
+		if err := tx.Movements().Add(*movement); err != nil {
+			return err
+		}
+	}
+
+	enclosure.AnimalsID = nil
+	enclosure.CurrentCount = 0
+	enclosure.MarkDeleted(time.Now(), actor)
+	if err := tx.Enclosures().Save(*enclosure); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RestoreEnclosure - model.ErrEnclosureNameTaken, если название уже занял другой вольер
+func (s *EnclosureService) RestoreEnclosure(ctx context.Context, id uuid.UUID, version int) (*model.Enclosure, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	enclosure, err := tx.Enclosures().FindDeletedByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, enclosure.Version); err != nil {
+		return nil, err
+	}
+	enclosure.Restore()
+	if err := tx.Enclosures().Save(*enclosure); err != nil {
+		return nil, err
+	}
+
+	restored, err := tx.Enclosures().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"sync"
+	"time"
+)
+
+// subscriberBuffer - сколько событий может ждать медленный подписчик,
+// прежде чем его отключат
+const subscriberBuffer = 64
+
+// EventBus - раздаёт события подписчикам и хранит последние replaySize
+// событий, чтобы переподключившийся клиент получил пропущенное.
+type EventBus struct {
+	mu          sync.Mutex
+	replay      []model.ZooEvent
+	next        int // куда писать следующее событие в кольцевом буфере
+	lastID      int64
+	subscribers map[*EventSubscription]struct{}
+}
+
+func NewEventBus(replaySize int) *EventBus {
+	return &EventBus{
+		replay:      make([]model.ZooEvent, 0, replaySize),
+		subscribers: make(map[*EventSubscription]struct{}),
+	}
+}
+
+// EventSubscription - подписка на события. Канал закрывается, когда
+// подписку отменили или подписчик не успевает читать.
+type EventSubscription struct {
+	bus    *EventBus
+	filter model.EventFilter
+	events chan model.ZooEvent
+}
+
+func (s *EventSubscription) Events() <-chan model.ZooEvent {
+	return s.events
+}
+
+// Close - отменяет подписку; повторный вызов ничего не делает
+func (s *EventSubscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.drop(s)
+}
+
+// Publish - присваивает событиям номера и рассылает их подписчикам.
+// Не блокируется: подписчик с переполненным каналом отключается.
+func (b *EventBus) Publish(events ...model.ZooEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, event := range events {
+		b.lastID++
+		event.ID = b.lastID
+		if event.At.IsZero() {
+			event.At = time.Now()
+		}
+		b.remember(event)
+
+		for subscription := range b.subscribers {
+			if !subscription.filter.Matches(event) {
+				continue
+			}
+			select {
+			case subscription.events <- event:
+			default:
+				b.drop(subscription)
+			}
+		}
+	}
+}
+
+// Subscribe - подписывает на события после lastEventID (0 - только новые).
+// Возвращает подходящие под фильтр события из буфера, которые клиент пропустил,
+// и truncated, если часть пропущенного уже вытеснена из буфера.
+func (b *EventBus) Subscribe(filter model.EventFilter, lastEventID int64) (subscription *EventSubscription, missed []model.ZooEvent, truncated bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription = &EventSubscription{
+		bus:    b,
+		filter: filter,
+		events: make(chan model.ZooEvent, subscriberBuffer),
+	}
+	b.subscribers[subscription] = struct{}{}
+
+	if lastEventID <= 0 {
+		return subscription, nil, false
+	}
+	// Номер из будущего - шина перезапускалась, клиент мог пропустить что угодно
+	if lastEventID > b.lastID {
+		truncated = true
+		lastEventID = 0
+	}
+	buffered := b.buffered()
+	if len(buffered) > 0 && buffered[0].ID > lastEventID+1 {
+		truncated = true
+	}
+	for _, event := range buffered {
+		if event.ID > lastEventID && filter.Matches(event) {
+			missed = append(missed, event)
+		}
+	}
+	return subscription, missed, truncated
+}
+
+func (b *EventBus) drop(subscription *EventSubscription) {
+	if _, ok := b.subscribers[subscription]; !ok {
+		return
+	}
+	delete(b.subscribers, subscription)
+	close(subscription.events)
+}
+
+func (b *EventBus) remember(event model.ZooEvent) {
+	if cap(b.replay) == 0 {
+		return
+	}
+	if len(b.replay) < cap(b.replay) {
+		b.replay = append(b.replay, event)
+		return
+	}
+	b.replay[b.next] = event
+	b.next = (b.next + 1) % len(b.replay)
+}
+
+// buffered - события из буфера от старых к новым
+func (b *EventBus) buffered() []model.ZooEvent {
+	if len(b.replay) < cap(b.replay) {
+		return b.replay
+	}
+	result := make([]model.ZooEvent, 0, len(b.replay))
+	result = append(result, b.replay[b.next:]...)
+	return append(result, b.replay[:b.next]...)
+}
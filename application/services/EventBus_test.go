@@ -0,0 +1,127 @@
+package services
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func eventIDs(events []model.ZooEvent) []int64 {
+	ids := make([]int64, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestEventBusReplaysAfterLastEventID(t *testing.T) {
+	enclosure := uuid.New()
+	moved := model.ZooEvent{Type: model.EventAnimalMoved, EnclosureIDs: []uuid.UUID{enclosure}}
+	created := model.ZooEvent{Type: model.EventAnimalCreated}
+
+	tests := []struct {
+		name          string
+		replaySize    int
+		published     []model.ZooEvent
+		filter        model.EventFilter
+		lastEventID   int64
+		wantMissed    []int64
+		wantTruncated bool
+	}{
+		{
+			name:       "new subscriber gets nothing from the buffer",
+			replaySize: 10,
+			published:  []model.ZooEvent{created, moved},
+		},
+		{
+			name:        "events after the last seen one",
+			replaySize:  10,
+			published:   []model.ZooEvent{created, moved, created, moved},
+			lastEventID: 2,
+			wantMissed:  []int64{3, 4},
+		},
+		{
+			name:        "nothing missed",
+			replaySize:  10,
+			published:   []model.ZooEvent{created, moved},
+			lastEventID: 2,
+		},
+		{
+			name:        "filtered",
+			replaySize:  10,
+			published:   []model.ZooEvent{created, moved, created, moved},
+			filter:      model.EventFilter{EnclosureID: enclosure},
+			lastEventID: 1,
+			wantMissed:  []int64{2, 4},
+		},
+		{
+			name:          "part of the missed events evicted",
+			replaySize:    3,
+			published:     []model.ZooEvent{created, moved, created, moved, created},
+			lastEventID:   1,
+			wantMissed:    []int64{3, 4, 5},
+			wantTruncated: true,
+		},
+		{
+			name:        "evicted events were already seen",
+			replaySize:  3,
+			published:   []model.ZooEvent{created, moved, created, moved, created},
+			lastEventID: 2,
+			wantMissed:  []int64{3, 4, 5},
+		},
+		{
+			name:          "id from before a restart",
+			replaySize:    10,
+			published:     []model.ZooEvent{created, moved},
+			lastEventID:   50,
+			wantMissed:    []int64{1, 2},
+			wantTruncated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewEventBus(tt.replaySize)
+			bus.Publish(tt.published...)
+
+			subscription, missed, truncated := bus.Subscribe(tt.filter, tt.lastEventID)
+			defer subscription.Close()
+			if ids := eventIDs(missed); !slices.Equal(ids, tt.wantMissed) {
+				t.Fatalf("missed = %v, want %v", ids, tt.wantMissed)
+			}
+			if truncated != tt.wantTruncated {
+				t.Fatalf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+
+			// Новые события приходят в канал, без повторов пропущенных
+			bus.Publish(moved)
+			if event := <-subscription.Events(); event.ID != int64(len(tt.published)+1) {
+				t.Fatalf("live event id = %d, want %d", event.ID, len(tt.published)+1)
+			}
+		})
+	}
+}
+
+func TestEventBusSlowSubscriberResumesWithoutLoss(t *testing.T) {
+	bus := NewEventBus(2 * subscriberBuffer)
+	subscription, _, _ := bus.Subscribe(model.EventFilter{}, 0)
+
+	for range subscriberBuffer + 1 {
+		bus.Publish(model.ZooEvent{Type: model.EventAnimalCreated})
+	}
+	var received []model.ZooEvent
+	for event := range subscription.Events() {
+		received = append(received, event)
+	}
+	if len(received) != subscriberBuffer {
+		t.Fatalf("received %d events before being dropped, want %d", len(received), subscriberBuffer)
+	}
+
+	// Клиент переподключается с номером последнего полученного события
+	resumed, missed, truncated := bus.Subscribe(model.EventFilter{}, received[len(received)-1].ID)
+	defer resumed.Close()
+	if truncated || len(missed) != 1 || missed[0].ID != subscriberBuffer+1 {
+		t.Fatalf("missed after resume = %v, truncated = %v, want [%d]", eventIDs(missed), truncated, subscriberBuffer+1)
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
 	"kpo-mini-dz2/domain/model"
 	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
 	"time"
@@ -8,28 +11,174 @@ import (
 	"github.com/google/uuid"
 )
 
+// FeedingService - изменения расписаний кормлений; все изменения
+// идут через транзакции и попадают в журнал аудита
 type FeedingService struct {
+	uow  RP.IUnitOfWorkFactory
 	repo RP.IFeedingScheduleRepository
 }
 
-func NewFeedingService(repo RP.IFeedingScheduleRepository) *FeedingService {
-	return &FeedingService{repo: repo}
+func NewFeedingService(uow RP.IUnitOfWorkFactory, repo RP.IFeedingScheduleRepository) *FeedingService {
+	return &FeedingService{uow: uow, repo: repo}
 }
 
-func (s *FeedingService) AddFeedingSchedule(animalID uuid.UUID, feedingTime time.Time, foodType model.FoodType) error {
+func (s *FeedingService) AddFeedingSchedule(ctx context.Context, animalID uuid.UUID, feedingTime time.Time, foodType model.FoodType) (*model.FeedingSchedule, error) {
 	schedule := model.FeedingSchedule{
+		ID:          uuid.New(),
 		AnimalID:    animalID,
 		FeedingTime: feedingTime,
 		FoodType:    foodType,
 	}
 
-	return s.repo.AddSchedule(schedule)
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindByID(animalID)
+	if err != nil {
+		return nil, err
+	}
+	if !animal.IsPresent() {
+		return nil, fmt.Errorf("%w: животное выбыло из зоопарка (%s)", model.ErrInvalidTransition, animal.State)
+	}
+	if err := tx.FeedingSchedules().AddSchedule(schedule); err != nil {
+		return nil, err
+	}
+	created, err := tx.FeedingSchedules().GetScheduleByID(schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateFeedingSchedule - меняет время и корм.
+// version - версия, которую видел клиент, или model.AnyVersion
+func (s *FeedingService) UpdateFeedingSchedule(ctx context.Context, id uuid.UUID, feedingTime time.Time, foodType model.FoodType, version int) (*model.FeedingSchedule, error) {
+	return s.change(ctx, id, version, func(schedule *model.FeedingSchedule) error {
+		if err := schedule.ChangeSchedule(feedingTime); err != nil {
+			return fmt.Errorf("%w: %v", model.ErrValidation, err)
+		}
+		schedule.FoodType = foodType
+		return nil
+	})
 }
 
-func (s *FeedingService) RemoveFeedingSchedule(animalID uuid.UUID, feedingTime time.Time) error {
-	return s.repo.RemoveSchedule(animalID, feedingTime)
+func (s *FeedingService) MarkFeedingDone(ctx context.Context, id uuid.UUID) (*model.FeedingSchedule, error) {
+	return s.change(ctx, id, model.AnyVersion, func(schedule *model.FeedingSchedule) error {
+		return schedule.PingExecution()
+	})
+}
+
+// RemoveFeedingSchedule - мягко удаляет кормления животного на это время
+func (s *FeedingService) RemoveFeedingSchedule(ctx context.Context, animalID uuid.UUID, feedingTime time.Time) error {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	schedules, err := tx.FeedingSchedules().GetSchedulesByAnimalID(animalID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	actor := requestcontext.ActorFrom(ctx).Name
+	for _, schedule := range schedules {
+		if !schedule.FeedingTime.Equal(feedingTime) {
+			continue
+		}
+		if err := deleteSchedule(tx, schedule, now, actor); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// RestoreFeedingSchedule - кормление удалённого животного восстанавливается вместе с ним
+func (s *FeedingService) RestoreFeedingSchedule(ctx context.Context, id uuid.UUID, version int) (*model.FeedingSchedule, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	schedule, err := tx.FeedingSchedules().GetDeletedScheduleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, schedule.Version); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Animals().FindByID(schedule.AnimalID); err != nil {
+		return nil, err
+	}
+	schedule.Restore()
+	if err := tx.FeedingSchedules().UpdateSchedule(*schedule); err != nil {
+		return nil, err
+	}
+
+	restored, err := tx.FeedingSchedules().GetScheduleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// GetDeletedSchedules - удалённые кормления животного
+func (s *FeedingService) GetDeletedSchedules(animalID uuid.UUID) ([]model.FeedingSchedule, error) {
+	deleted, err := s.repo.GetDeletedSchedules()
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]model.FeedingSchedule, 0)
+	for _, schedule := range deleted {
+		if schedule.AnimalID == animalID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
 }
 
 func (s *FeedingService) GetAnimalSchedules(animalID uuid.UUID) ([]model.FeedingSchedule, error) {
 	return s.repo.GetSchedulesByAnimalID(animalID)
 }
+
+// change - загружает расписание, применяет к нему действие и сохраняет в одной транзакции
+func (s *FeedingService) change(ctx context.Context, id uuid.UUID, version int, action func(schedule *model.FeedingSchedule) error) (*model.FeedingSchedule, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	schedule, err := tx.FeedingSchedules().GetScheduleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, schedule.Version); err != nil {
+		return nil, err
+	}
+	if err := action(schedule); err != nil {
+		return nil, err
+	}
+	if err := tx.FeedingSchedules().UpdateSchedule(*schedule); err != nil {
+		return nil, err
+	}
+
+	updated, err := tx.FeedingSchedules().GetScheduleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"log/slog"
+	"time"
+)
+
+// FeedingScheduler - периодически проверяет расписание и сообщает
+// о наступившем времени кормления через FeedingTimeEvent
+type FeedingScheduler struct {
+	repo     RP.IFeedingScheduleRepository
+	interval time.Duration
+	handler  func(model.FeedingTimeEvent)
+	lastTick time.Time
+}
+
+func NewFeedingScheduler(repo RP.IFeedingScheduleRepository, interval time.Duration, handler func(model.FeedingTimeEvent)) *FeedingScheduler {
+	return &FeedingScheduler{
+		repo:     repo,
+		interval: interval,
+		handler:  handler,
+		lastTick: time.Now(),
+	}
+}
+
+// Run - проверяет расписание каждые interval, пока не отменён ctx
+func (s *FeedingScheduler) Run(ctx context.Context) {
+	runEvery(ctx, s.interval, s.Tick)
+}
+
+// runEvery - общий цикл планировщиков: вызывает tick каждые interval, пока не отменён ctx
+func runEvery(ctx context.Context, interval time.Duration, tick func(now time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			tick(now)
+		}
+	}
+}
+
+// Tick - сообщает о кормлениях, время которых наступило с прошлой проверки
+func (s *FeedingScheduler) Tick(now time.Time) {
+	all, err := s.repo.GetAllSchedules()
+	if err != nil {
+		slog.Error("не удалось получить расписание кормлений", "error", err)
+		return
+	}
+
+	for _, schedules := range all {
+		for _, schedule := range schedules {
+			if schedule.FeedingTime.After(s.lastTick) && !schedule.FeedingTime.After(now) {
+				s.handler(model.FeedingTimeEvent{
+					ScheduleID:  schedule.ID,
+					AnimalID:    schedule.AnimalID,
+					FeedingTime: schedule.FeedingTime,
+					FoodType:    schedule.FoodType,
+				})
+			}
+		}
+	}
+	s.lastTick = now
+}
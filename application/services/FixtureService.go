@@ -0,0 +1,349 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/fixtures"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FixtureReport - созданные сущности; ключи - символьные ключи из описания
+type FixtureReport struct {
+	Species    int                  `json:"species"`
+	Enclosures map[string]uuid.UUID `json:"enclosures"`
+	Animals    map[string]uuid.UUID `json:"animals"`
+	Feedings   int                  `json:"feedings"`
+}
+
+// FixtureService - загрузка описания зоопарка из пакета fixtures.
+// Загружается всё или ничего, одной транзакцией: ошибки всех записей
+// собираются и возвращаются вместе как model.ErrValidation.
+type FixtureService struct {
+	uow        RP.IUnitOfWorkFactory
+	animals    RP.IAnimalRepository
+	enclosures RP.IEnclosureRepository
+}
+
+func NewFixtureService(uow RP.IUnitOfWorkFactory, animals RP.IAnimalRepository, enclosures RP.IEnclosureRepository) *FixtureService {
+	return &FixtureService{uow: uow, animals: animals, enclosures: enclosures}
+}
+
+// LoadIfEmpty - для запуска сервера: в зоопарк, где уже есть животные или
+// вольеры, ничего не загружается, чтобы не дублировать данные при каждом старте
+func (s *FixtureService) LoadIfEmpty(ctx context.Context, fixture *fixtures.Fixture, now time.Time) (*FixtureReport, bool, error) {
+	animals, err := s.animals.FindAll()
+	if err != nil {
+		return nil, false, err
+	}
+	enclosures, err := s.enclosures.FindAll()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(animals) > 0 || len(enclosures) > 0 {
+		return nil, false, nil
+	}
+	report, err := s.Load(ctx, fixture, now)
+	return report, err == nil, err
+}
+
+// Load - now - от него отсчитываются кормления, заданные как "HH:MM"
+func (s *FixtureService) Load(ctx context.Context, fixture *fixtures.Fixture, now time.Time) (*FixtureReport, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	loader := &fixtureLoader{
+		tx:         tx,
+		actor:      requestcontext.ActorFrom(ctx).Name,
+		role:       requestcontext.ActorFrom(ctx).Role,
+		now:        now,
+		species:    make(map[string]*fixtures.Species),
+		enclosures: make(map[string]uuid.UUID),
+		animals:    make(map[string]*model.Animal),
+		report: &FixtureReport{
+			Enclosures: make(map[string]uuid.UUID),
+			Animals:    make(map[string]uuid.UUID),
+		},
+	}
+	if err := loader.load(fixture); err != nil {
+		return nil, err
+	}
+	if len(loader.errs) > 0 {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, errors.Join(loader.errs...))
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return loader.report, nil
+}
+
+// fixtureLoader - состояние одной загрузки. Ключ записи, которая не прошла
+// проверку, остаётся в карте с пустым значением: ссылки на неё пропускаются
+// молча, чтобы одна ошибка не размножалась по всем зависимым записям.
+type fixtureLoader struct {
+	tx         RP.IUnitOfWork
+	actor      string
+	role       model.Role
+	now        time.Time
+	species    map[string]*fixtures.Species
+	enclosures map[string]uuid.UUID
+	animals    map[string]*model.Animal
+	report     *FixtureReport
+	errs       []error
+}
+
+// load - ошибки записей копятся в errs; возвращается только ошибка хранилища
+func (l *fixtureLoader) load(fixture *fixtures.Fixture) error {
+	for i := range fixture.Species {
+		l.addSpecies(i, &fixture.Species[i])
+	}
+	for i, enclosure := range fixture.Enclosures {
+		if err := l.addEnclosure(i, enclosure); err != nil {
+			return err
+		}
+	}
+	for i, animal := range fixture.Animals {
+		if err := l.addAnimal(i, animal); err != nil {
+			return err
+		}
+	}
+	for i, feeding := range fixture.Feedings {
+		if err := l.addFeeding(i, feeding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *fixtureLoader) fail(section string, index int, key string, err error) {
+	l.errs = append(l.errs, fmt.Errorf("%s[%d] %q: %w", section, index, key, err))
+}
+
+func (l *fixtureLoader) addSpecies(index int, species *fixtures.Species) {
+	key := species.Key
+	if key == "" {
+		key = species.Name
+	}
+	if _, exists := l.species[key]; exists || key == "" {
+		l.fail("species", index, key, errors.New("ключ пустой или повторяется"))
+		return
+	}
+	l.species[key] = nil
+	switch {
+	case species.Name == "":
+		l.fail("species", index, key, errors.New("название вида не может быть пустым"))
+	case !enumValue[model.AnimalType](species.Type).IsValid():
+		l.fail("species", index, key, fmt.Errorf("неизвестный тип животного %q", species.Type))
+	default:
+		l.species[key] = species
+		l.report.Species++
+	}
+}
+
+func (l *fixtureLoader) addEnclosure(index int, record fixtures.Enclosure) error {
+	key := record.EnclosureKey()
+	if _, exists := l.enclosures[key]; exists || key == "" {
+		l.fail("enclosures", index, key, errors.New("ключ пустой или повторяется"))
+		return nil
+	}
+	l.enclosures[key] = uuid.Nil
+
+	size := model.Size{Lenght: record.Size.Length, Width: record.Size.Width, Height: record.Size.Height}
+	enclosure, err := model.NewEnclosure(record.Name, enumValue[model.AnimalType](record.Type), size, record.Capacity)
+	if err != nil {
+		l.fail("enclosures", index, key, err)
+		return nil
+	}
+	if enclosure.Name != "" {
+		if _, err := l.tx.Enclosures().FindByName(enclosure.Name); err == nil {
+			l.fail("enclosures", index, key, model.ErrEnclosureNameTaken)
+			return nil
+		}
+	}
+	if err := l.tx.Enclosures().Save(*enclosure); err != nil {
+		return err
+	}
+	l.enclosures[key] = enclosure.ID
+	l.report.Enclosures[key] = enclosure.ID
+	return nil
+}
+
+func (l *fixtureLoader) addAnimal(index int, record fixtures.Animal) error {
+	key := record.AnimalKey()
+	if _, exists := l.animals[key]; exists || key == "" {
+		l.fail("animals", index, key, errors.New("ключ пустой или повторяется"))
+		return nil
+	}
+	l.animals[key] = nil
+
+	species, known := l.species[record.Species]
+	if !known {
+		l.fail("animals", index, key, fmt.Errorf("неизвестный вид %q", record.Species))
+		return nil
+	}
+	enclosureID, known := l.enclosures[record.Enclosure]
+	if record.Enclosure != "" && !known {
+		l.fail("animals", index, key, fmt.Errorf("неизвестный вольер %q", record.Enclosure))
+		return nil
+	}
+	if species == nil || (record.Enclosure != "" && enclosureID == uuid.Nil) {
+		return nil
+	}
+
+	if err := checkArrivalHealth(l.role, enumValue[model.HealthStatus](record.Health)); err != nil {
+		l.fail("animals", index, key, fmt.Errorf("health: %w", err))
+		return nil
+	}
+	born, err := parseDate(record.Born)
+	if err != nil {
+		l.fail("animals", index, key, fmt.Errorf("born: %w", err))
+		return nil
+	}
+	arrived, err := parseOptionalDate(record.Arrived)
+	if err != nil {
+		l.fail("animals", index, key, fmt.Errorf("arrived: %w", err))
+		return nil
+	}
+	food := species.Food
+	if record.Food != nil {
+		food = *record.Food
+	}
+	animal, err := model.NewAnimal(
+		record.Name,
+		model.Species{AnimalType: enumValue[model.AnimalType](species.Type), Name: species.Name},
+		born,
+		uuid.Nil,
+		enumValue[model.HealthStatus](record.Health),
+		enumValue[model.Gender](record.Gender),
+		model.Food{FoodType: enumValue[model.FoodType](food.Type), Name: food.Name},
+	)
+	if err == nil {
+		err = animal.EnterLifecycle(enumValue[model.LifecycleState](record.State), arrived, model.ReasonArrival, l.actor)
+	}
+	if err == nil {
+		err = l.setParents(animal, record)
+	}
+	if err != nil {
+		l.fail("animals", index, key, err)
+		return nil
+	}
+	if err := checkParents(l.tx, animal); err != nil {
+		if !errors.Is(err, model.ErrValidation) && !errors.Is(err, model.ErrCircularParentage) {
+			return err
+		}
+		l.fail("animals", index, key, err)
+		return nil
+	}
+
+	if enclosureID != uuid.Nil {
+		enclosure, err := l.tx.Enclosures().FindByID(enclosureID)
+		if err != nil {
+			return err
+		}
+		if err := enclosure.CanAccept(*animal); err != nil {
+			l.fail("animals", index, key, fmt.Errorf("вольер %q: %w", record.Enclosure, err))
+			return nil
+		}
+	}
+	if err := l.tx.Animals().Save(*animal); err != nil {
+		return err
+	}
+	if err := openArrivalCase(l.tx, *animal); err != nil {
+		return err
+	}
+	if enclosureID != uuid.Nil {
+		if err := moveAnimal(l.tx, animal.ID, enclosureID, model.ReasonArrival, l.actor); err != nil {
+			return err
+		}
+	}
+	l.animals[key] = animal
+	l.report.Animals[key] = animal.ID
+	return nil
+}
+
+// setParents - родитель из описания должен быть описан выше потомка
+func (l *fixtureLoader) setParents(animal *model.Animal, record fixtures.Animal) error {
+	var parents [2]*model.Parent
+	for i, parent := range []*fixtures.Parent{record.Sire, record.Dam} {
+		switch {
+		case parent == nil:
+		case parent.Animal != "":
+			found, known := l.animals[parent.Animal]
+			if !known {
+				return fmt.Errorf("неизвестный родитель %q", parent.Animal)
+			}
+			if found == nil {
+				return fmt.Errorf("родитель %q не загружен", parent.Animal)
+			}
+			id := found.ID
+			parents[i] = &model.Parent{AnimalID: &id}
+		default:
+			parents[i] = &model.Parent{External: &model.ExternalAnimal{
+				Institution: parent.Institution,
+				StudbookID:  parent.StudbookID,
+				Name:        parent.Name,
+			}}
+		}
+	}
+	return animal.SetParents(parents[0], parents[1])
+}
+
+func (l *fixtureLoader) addFeeding(index int, record fixtures.Feeding) error {
+	animal, known := l.animals[record.Animal]
+	if !known {
+		l.fail("feedings", index, record.Animal, fmt.Errorf("неизвестное животное %q", record.Animal))
+		return nil
+	}
+	if animal == nil {
+		return nil
+	}
+
+	at, err := l.feedingTime(record.At)
+	if err != nil {
+		l.fail("feedings", index, record.Animal, fmt.Errorf("at: %w", err))
+		return nil
+	}
+	foodType := animal.FavoriteFood.FoodType
+	if record.Food != "" {
+		foodType = enumValue[model.FoodType](record.Food)
+	}
+	if !foodType.IsValid() {
+		l.fail("feedings", index, record.Animal, fmt.Errorf("неизвестный тип корма %q", record.Food))
+		return nil
+	}
+	schedule, err := model.NewFeedingSchedule(animal.ID, at, foodType)
+	if err != nil {
+		l.fail("feedings", index, record.Animal, err)
+		return nil
+	}
+	if err := l.tx.FeedingSchedules().AddSchedule(*schedule); err != nil {
+		return err
+	}
+	l.report.Feedings++
+	return nil
+}
+
+// feedingTime - RFC 3339 или "HH:MM" - ближайшее такое время после l.now
+func (l *fixtureLoader) feedingTime(value string) (time.Time, error) {
+	if clock, err := time.ParseInLocation("15:04", value, time.Local); err == nil {
+		local := l.now.In(time.Local)
+		at := time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local)
+		if !at.After(l.now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ожидается время RFC 3339 или HH:MM, получено %q", value)
+	}
+	return at, nil
+}
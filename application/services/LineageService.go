@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLineageGenerations - сколько поколений показывать, если не указано
+	DefaultLineageGenerations = 3
+	maxLineageGenerations     = 10
+)
+
+// LineageService - родители животных, родословные и родство внутри видов
+// для программ разведения. В родословной остаются выбывшие и удалённые
+// в архив животные, но родство считается только среди тех, кто в зоопарке.
+type LineageService struct {
+	uow     RP.IUnitOfWorkFactory
+	animals RP.IAnimalRepository
+}
+
+func NewLineageService(uow RP.IUnitOfWorkFactory, animals RP.IAnimalRepository) *LineageService {
+	return &LineageService{uow: uow, animals: animals}
+}
+
+// SetParents - задаёт отца и мать (nil - неизвестен). Родитель из зоопарка
+// должен быть нужного пола, того же вида, старше потомка и не его потомком.
+// version - версия, которую видел клиент, или model.AnyVersion
+func (s *LineageService) SetParents(ctx context.Context, id uuid.UUID, sire, dam *model.Parent, version int) (*model.Animal, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, animal.Version); err != nil {
+		return nil, err
+	}
+	if err := animal.SetParents(sire, dam); err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	if err := checkParents(tx, animal); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Animals().Save(*animal); err != nil {
+		return nil, err
+	}
+	updated, err := tx.Animals().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// checkParents - родители животного из зоопарка существуют, подходят ему
+// и не оказываются его потомками
+func checkParents(tx RP.IUnitOfWork, animal *model.Animal) error {
+	if (animal.Sire == nil || animal.Sire.AnimalID == nil) && (animal.Dam == nil || animal.Dam.AnimalID == nil) {
+		return nil
+	}
+	animals, err := pedigreeAnimals(tx.Animals())
+	if err != nil {
+		return err
+	}
+	pedigree := model.NewPedigree(animals)
+	for _, parent := range []struct {
+		ref    *model.Parent
+		gender model.Gender
+	}{{animal.Sire, model.Male}, {animal.Dam, model.Female}} {
+		if parent.ref == nil || parent.ref.AnimalID == nil {
+			continue
+		}
+		found, err := tx.Animals().FindByID(*parent.ref.AnimalID)
+		if errors.Is(err, model.ErrAnimalNotFound) {
+			return fmt.Errorf("%w: родитель %s не найден", model.ErrValidation, parent.ref.AnimalID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := animal.CheckParent(*found, parent.gender); err != nil {
+			return fmt.Errorf("%w: %v", model.ErrValidation, err)
+		}
+		if pedigree.IsAncestor(animal.ID, found.ID) {
+			return fmt.Errorf("%w: %s - потомок %s", model.ErrCircularParentage, found.Name, animal.Name)
+		}
+	}
+	return nil
+}
+
+// GetLineage - предки и потомки животного на generations поколений
+func (s *LineageService) GetLineage(id uuid.UUID, generations int) (*model.LineageNode, error) {
+	if generations < 1 || generations > maxLineageGenerations {
+		return nil, fmt.Errorf("%w: число поколений - от 1 до %d", model.ErrValidation, maxLineageGenerations)
+	}
+	if _, err := s.animals.FindByID(id); err != nil {
+		return nil, err
+	}
+	pedigree, _, err := s.pedigree()
+	if err != nil {
+		return nil, err
+	}
+	return pedigree.Lineage(id, generations), nil
+}
+
+// GetKinship - среднее родство и инбридинг животных, которые сейчас в зоопарке;
+// species ограничивает одним видом
+func (s *LineageService) GetKinship(species string) ([]model.SpeciesKinship, error) {
+	pedigree, animals, err := s.pedigree()
+	if err != nil {
+		return nil, err
+	}
+	return pedigree.SpeciesKinship(ofSpecies(model.PresentAnimals(animals), species)), nil
+}
+
+// GetIssues - животные с неизвестными, ненайденными, несовместимыми
+// или замкнутыми в цикл родителями
+func (s *LineageService) GetIssues(species string) ([]model.LineageIssue, error) {
+	pedigree, animals, err := s.pedigree()
+	if err != nil {
+		return nil, err
+	}
+	return pedigree.Issues(ofSpecies(animals, species)), nil
+}
+
+// RecommendPairs - пары для разведения среди животных, которые сейчас в зоопарке;
+// species ограничивает одним видом, ages (если задан) заменяет возраст размножения по умолчанию
+func (s *LineageService) RecommendPairs(species string, ages *model.AgeRange, now time.Time) ([]model.BreedingRecommendations, error) {
+	if ages != nil {
+		if err := ages.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+		}
+	}
+	pedigree, animals, err := s.pedigree()
+	if err != nil {
+		return nil, err
+	}
+	return pedigree.RecommendPairs(ofSpecies(model.PresentAnimals(animals), species), ages, now), nil
+}
+
+// pedigree - родословная всех животных и животные, которые не удалены в архив;
+// обе выборки читаются в одной транзакции только для чтения
+func (s *LineageService) pedigree() (*model.Pedigree, []model.Animal, error) {
+	tx, err := s.uow.BeginRead(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	all, err := pedigreeAnimals(tx.Animals())
+	if err != nil {
+		return nil, nil, err
+	}
+	animals := make([]model.Animal, 0, len(all))
+	for _, animal := range all {
+		if !animal.IsDeleted() {
+			animals = append(animals, animal)
+		}
+	}
+	return model.NewPedigree(all), animals, nil
+}
+
+// pedigreeAnimals - животные для родословной вместе с удалёнными в архив:
+// без удалённого родителя его потомки перестали бы считаться родственниками
+func pedigreeAnimals(animals RP.IAnimalRepository) ([]model.Animal, error) {
+	all, err := animals.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := animals.FindDeleted()
+	if err != nil {
+		return nil, err
+	}
+	return append(all, deleted...), nil
+}
+
+// ofSpecies - животные вида species; пустой species не ограничивает
+func ofSpecies(animals []model.Animal, species string) []model.Animal {
+	species = strings.TrimSpace(species)
+	if species == "" {
+		return animals
+	}
+	result := make([]model.Animal, 0)
+	for _, animal := range animals {
+		if strings.EqualFold(animal.Species.Name, species) {
+			result = append(result, animal)
+		}
+	}
+	return result
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+
+	"github.com/google/uuid"
+)
+
+// Случаи, которые открываются без ветеринара: животное уже больно
+// при поступлении или было отмечено больным до появления медицинских карт
+const (
+	CaseTitleArrivedSick  = "болен при поступлении"
+	CaseTitleCarriedOver  = "перенесено из карточки животного"
+	automaticCaseOpenedBy = "system"
+)
+
+// MedicalRecordService - медицинские карты животных. Состояние здоровья
+// животного не меняется напрямую: открытие случая делает его больным,
+// закрытие последнего открытого случая - здоровым.
+type MedicalRecordService struct {
+	uow     RP.IUnitOfWorkFactory
+	animals RP.IAnimalRepository
+	repo    RP.IMedicalRecordRepository
+}
+
+func NewMedicalRecordService(uow RP.IUnitOfWorkFactory, animals RP.IAnimalRepository, repo RP.IMedicalRecordRepository) *MedicalRecordService {
+	return &MedicalRecordService{uow: uow, animals: animals, repo: repo}
+}
+
+func (s *MedicalRecordService) GetRecord(animalID uuid.UUID) (*model.MedicalRecord, error) {
+	if _, err := s.animals.FindByID(animalID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByAnimalID(animalID)
+}
+
+func (s *MedicalRecordService) GetOpenCases() ([]model.MedicalCase, error) {
+	return s.repo.FindOpenCases()
+}
+
+// OpenCase - открывает случай; здоровое животное становится больным
+func (s *MedicalRecordService) OpenCase(ctx context.Context, animalID uuid.UUID, title string) (*model.MedicalCase, error) {
+	medicalCase, err := model.NewMedicalCase(animalID, title, requestcontext.ActorFrom(ctx).Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindByID(animalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.MedicalRecords().SaveCase(*medicalCase); err != nil {
+		return nil, err
+	}
+	if animal.HealthStatus != model.Sick {
+		animal.FallIll()
+		if err := tx.Animals().Save(*animal); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := tx.MedicalRecords().FindCaseByID(medicalCase.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// CloseCase - закрывает случай; если открытых случаев не осталось, животное вылечено.
+// version - версия, которую видел клиент, или model.AnyVersion
+func (s *MedicalRecordService) CloseCase(ctx context.Context, animalID uuid.UUID, caseID uuid.UUID, resolution string, version int) (*model.MedicalCase, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	medicalCase, err := findAnimalCase(tx, animalID, caseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, medicalCase.Version); err != nil {
+		return nil, err
+	}
+	if err := medicalCase.Close(requestcontext.ActorFrom(ctx).Name, resolution); err != nil {
+		return nil, err
+	}
+	if err := tx.MedicalRecords().SaveCase(*medicalCase); err != nil {
+		return nil, err
+	}
+
+	record, err := tx.MedicalRecords().FindByAnimalID(animalID)
+	if err != nil {
+		return nil, err
+	}
+	if record.HealthStatus() == model.Healthy {
+		animal, err := tx.Animals().FindByID(animalID)
+		if err != nil {
+			return nil, err
+		}
+		if animal.HealthStatus != model.Healthy {
+			animal.Heal()
+			if err := tx.Animals().Save(*animal); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	closed, err := tx.MedicalRecords().FindCaseByID(caseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
+
+// AddEntry - добавляет запись в карту; запись можно привязать
+// только к открытому случаю этого же животного
+func (s *MedicalRecordService) AddEntry(ctx context.Context, data model.MedicalEntry) (*model.MedicalEntry, error) {
+	entry, err := model.NewMedicalEntry(data, requestcontext.ActorFrom(ctx).Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Animals().FindByID(entry.AnimalID); err != nil {
+		return nil, err
+	}
+	if entry.CaseID != nil {
+		medicalCase, err := findAnimalCase(tx, entry.AnimalID, *entry.CaseID)
+		if err != nil {
+			return nil, err
+		}
+		if !medicalCase.IsOpen() {
+			return nil, fmt.Errorf("%w: случай уже закрыт", model.ErrInvalidTransition)
+		}
+	}
+	if err := tx.MedicalRecords().AddEntry(*entry); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// CarryOverHealthStatus - для данных, записанных до появления медицинских карт:
+// больным животным без открытых случаев открывается случай, чтобы состояние
+// здоровья снова следовало из карты. Возвращает число открытых случаев.
+func (s *MedicalRecordService) CarryOverHealthStatus(ctx context.Context) (int, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	animals, err := tx.Animals().FindAll()
+	if err != nil {
+		return 0, err
+	}
+	opened := 0
+	for _, animal := range model.PresentAnimals(animals) {
+		if animal.HealthStatus != model.Sick {
+			continue
+		}
+		record, err := tx.MedicalRecords().FindByAnimalID(animal.ID)
+		if err != nil {
+			return 0, err
+		}
+		if record.HealthStatus() == model.Sick {
+			continue
+		}
+		if err := openAutomaticCase(tx, animal.ID, CaseTitleCarriedOver); err != nil {
+			return 0, err
+		}
+		opened++
+	}
+	if opened == 0 {
+		return 0, nil
+	}
+	return opened, tx.Commit()
+}
+
+// checkArrivalHealth - больным животное при создании отмечает только тот,
+// кто ведёт медицинские карты: такое состояние открывает случай
+func checkArrivalHealth(role model.Role, status model.HealthStatus) error {
+	if status != "" && status != model.Healthy && !role.Can(model.PermManageMedical) {
+		return fmt.Errorf("%w: состояние здоровья %q при поступлении указывают ветеринары", model.ErrForbidden, status)
+	}
+	return nil
+}
+
+// openArrivalCase - животное, которое поступает больным, сразу получает
+// открытый случай; вызывается в транзакции, где животное создаётся
+func openArrivalCase(tx RP.IUnitOfWork, animal model.Animal) error {
+	if animal.HealthStatus != model.Sick {
+		return nil
+	}
+	return openAutomaticCase(tx, animal.ID, CaseTitleArrivedSick)
+}
+
+func openAutomaticCase(tx RP.IUnitOfWork, animalID uuid.UUID, title string) error {
+	medicalCase, err := model.NewMedicalCase(animalID, title, automaticCaseOpenedBy)
+	if err != nil {
+		return err
+	}
+	return tx.MedicalRecords().SaveCase(*medicalCase)
+}
+
+// findAnimalCase - случай другого животного считается ненайденным
+func findAnimalCase(tx RP.IUnitOfWork, animalID uuid.UUID, caseID uuid.UUID) (*model.MedicalCase, error) {
+	medicalCase, err := tx.MedicalRecords().FindCaseByID(caseID)
+	if err != nil {
+		return nil, err
+	}
+	if medicalCase.AnimalID != animalID {
+		return nil, model.ErrMedicalCaseNotFound
+	}
+	return medicalCase, nil
+}
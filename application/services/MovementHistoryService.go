@@ -0,0 +1,80 @@
+package services
+
+import (
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovementHistoryService - история перемещений и заселённость вольеров во времени
+type MovementHistoryService struct {
+	repo RP.IMovementRepository
+}
+
+func NewMovementHistoryService(repo RP.IMovementRepository) *MovementHistoryService {
+	return &MovementHistoryService{repo: repo}
+}
+
+// GetAnimalMovements - перемещения животного в хронологическом порядке
+func (s *MovementHistoryService) GetAnimalMovements(animalID uuid.UUID) ([]model.Movement, error) {
+	movements, err := s.repo.FindByAnimalID(animalID)
+	if err != nil {
+		return nil, err
+	}
+	sortMovements(movements)
+	return movements, nil
+}
+
+// GetMovementsByAnimal - история перемещений всех животных за одно обращение
+// к репозиторию, в хронологическом порядке
+func (s *MovementHistoryService) GetMovementsByAnimal() (map[uuid.UUID][]model.Movement, error) {
+	movements, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sortMovements(movements)
+
+	result := make(map[uuid.UUID][]model.Movement)
+	for _, movement := range movements {
+		result[movement.AnimalID] = append(result[movement.AnimalID], movement)
+	}
+	return result, nil
+}
+
+// GetOccupancy - кто находился в вольере в момент at.
+// Для каждого животного берётся последнее перемещение не позже at.
+func (s *MovementHistoryService) GetOccupancy(enclosureID uuid.UUID, at time.Time) ([]model.Occupancy, error) {
+	movements, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sortMovements(movements)
+
+	last := make(map[uuid.UUID]model.Movement)
+	for _, movement := range movements {
+		if movement.MovedAt.After(at) {
+			break
+		}
+		last[movement.AnimalID] = movement
+	}
+
+	result := make([]model.Occupancy, 0)
+	for animalID, movement := range last {
+		if movement.ToEnclosureID == enclosureID {
+			result = append(result, model.Occupancy{AnimalID: animalID, Since: movement.MovedAt})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Since.Before(result[j].Since)
+	})
+	return result, nil
+}
+
+func sortMovements(movements []model.Movement) {
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].MovedAt.Before(movements[j].MovedAt)
+	})
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PreventiveCareService - планы профилактики по видам и процедуры,
+// которые по ним пора выполнить. Выполненной процедура считается по
+// записи в медицинской карте, отдельно отмечать её не нужно.
+type PreventiveCareService struct {
+	uow     RP.IUnitOfWorkFactory
+	plans   RP.ICarePlanRepository
+	animals RP.IAnimalRepository
+	medical RP.IMedicalRecordRepository
+}
+
+func NewPreventiveCareService(uow RP.IUnitOfWorkFactory, plans RP.ICarePlanRepository, animals RP.IAnimalRepository, medical RP.IMedicalRecordRepository) *PreventiveCareService {
+	return &PreventiveCareService{uow: uow, plans: plans, animals: animals, medical: medical}
+}
+
+// CreatePlan - у вида может быть только один план
+func (s *PreventiveCareService) CreatePlan(ctx context.Context, species string, items []model.CarePlanItem) (*model.CarePlan, error) {
+	plan, err := model.NewCarePlan(species, items, requestcontext.ActorFrom(ctx).Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.CarePlans().FindBySpecies(plan.Species); err == nil {
+		return nil, model.ErrCarePlanExists
+	}
+	if err := tx.CarePlans().Save(*plan); err != nil {
+		return nil, err
+	}
+	created, err := tx.CarePlans().FindByID(plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdatePlan - заменяет процедуры плана целиком
+func (s *PreventiveCareService) UpdatePlan(ctx context.Context, id uuid.UUID, items []model.CarePlanItem, version int) (*model.CarePlan, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	plan, err := tx.CarePlans().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, plan.Version); err != nil {
+		return nil, err
+	}
+	if err := plan.SetItems(items, requestcontext.ActorFrom(ctx).Name); err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	if err := tx.CarePlans().Save(*plan); err != nil {
+		return nil, err
+	}
+	updated, err := tx.CarePlans().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *PreventiveCareService) DeletePlan(ctx context.Context, id uuid.UUID) error {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.CarePlans().Delete(id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *PreventiveCareService) GetPlan(id uuid.UUID) (*model.CarePlan, error) {
+	return s.plans.FindByID(id)
+}
+
+func (s *PreventiveCareService) GetPlans() ([]model.CarePlan, error) {
+	plans, err := s.plans.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return strings.ToLower(plans[i].Species) < strings.ToLower(plans[j].Species)
+	})
+	return plans, nil
+}
+
+// CareTaskFilter - пустые поля не ограничивают
+type CareTaskFilter struct {
+	AnimalID uuid.UUID
+	Status   model.CareTaskStatus
+}
+
+// GetDueTasks - просроченные процедуры и те, срок которых наступит в ближайшие within
+func (s *PreventiveCareService) GetDueTasks(now time.Time, within time.Duration, filter CareTaskFilter) ([]model.CareTask, error) {
+	if filter.Status != "" && filter.Status != model.CareDue && filter.Status != model.CareOverdue {
+		return nil, fmt.Errorf("%w: неизвестный статус %q", model.ErrValidation, filter.Status)
+	}
+	if within < 0 {
+		return nil, fmt.Errorf("%w: горизонт не может быть отрицательным", model.ErrValidation)
+	}
+
+	tasks, err := s.tasks(now)
+	if err != nil {
+		return nil, err
+	}
+	horizon := now.Add(within)
+	due := make([]model.CareTask, 0)
+	for _, task := range tasks {
+		if task.DueAt.After(horizon) ||
+			(filter.AnimalID != uuid.Nil && task.AnimalID != filter.AnimalID) ||
+			(filter.Status != "" && task.Status != filter.Status) {
+			continue
+		}
+		due = append(due, task)
+	}
+	model.SortCareTasks(due)
+	return due, nil
+}
+
+// tasks - ближайшая процедура по каждому пункту плана каждого животного;
+// планы, животные и карты читаются в одной транзакции только для чтения
+func (s *PreventiveCareService) tasks(now time.Time) ([]model.CareTask, error) {
+	tx, err := s.uow.BeginRead(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	plans, err := tx.CarePlans().FindAll()
+	if err != nil || len(plans) == 0 {
+		return nil, err
+	}
+	animals, err := tx.Animals().FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []model.CareTask
+	for _, animal := range model.PresentAnimals(animals) {
+		for _, plan := range plans {
+			if !plan.AppliesTo(animal) {
+				continue
+			}
+			record, err := tx.MedicalRecords().FindByAnimalID(animal.ID)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, plan.CareTasks(animal, *record, now)...)
+			break
+		}
+	}
+	return tasks, nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferRequestService - заявки на перемещение с подписью куратора.
+// Исполнение одобренной заявки идёт через ту же логику, что и прямое перемещение.
+type TransferRequestService struct {
+	uow  RP.IUnitOfWorkFactory
+	repo RP.ITransferRequestRepository
+}
+
+func NewTransferRequestService(uow RP.IUnitOfWorkFactory, repo RP.ITransferRequestRepository) *TransferRequestService {
+	return &TransferRequestService{uow: uow, repo: repo}
+}
+
+func (s *TransferRequestService) CreateRequest(ctx context.Context, animalID uuid.UUID, toEnclosureID uuid.UUID, plannedDate time.Time, reason string) (*model.TransferRequest, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	animal, err := tx.Animals().FindByID(animalID)
+	if err != nil {
+		return nil, err
+	}
+	if !animal.IsPresent() {
+		return nil, fmt.Errorf("%w: животное выбыло из зоопарка (%s)", model.ErrInvalidTransition, animal.State)
+	}
+	if _, err := tx.Enclosures().FindByID(toEnclosureID); err != nil {
+		return nil, err
+	}
+
+	request, err := model.NewTransferRequest(animalID, animal.EnclosureID, toEnclosureID, plannedDate, reason, requestcontext.ActorFrom(ctx).Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+	if err := tx.TransferRequests().Save(*request); err != nil {
+		return nil, err
+	}
+
+	created, err := tx.TransferRequests().FindByID(request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *TransferRequestService) Approve(ctx context.Context, id uuid.UUID, version int) (*model.TransferRequest, error) {
+	approver := requestcontext.ActorFrom(ctx)
+	return s.change(ctx, id, version, func(tx RP.IUnitOfWork, request *model.TransferRequest) error {
+		return request.Approve(approver.Name, approver.Role)
+	})
+}
+
+func (s *TransferRequestService) Reject(ctx context.Context, id uuid.UUID, version int, comment string) (*model.TransferRequest, error) {
+	approver := requestcontext.ActorFrom(ctx)
+	return s.change(ctx, id, version, func(tx RP.IUnitOfWork, request *model.TransferRequest) error {
+		return request.Reject(approver.Name, approver.Role, comment)
+	})
+}
+
+func (s *TransferRequestService) Schedule(ctx context.Context, id uuid.UUID, version int, plannedDate time.Time) (*model.TransferRequest, error) {
+	if plannedDate.IsZero() {
+		return nil, fmt.Errorf("%w: не указана дата перемещения", model.ErrValidation)
+	}
+	return s.change(ctx, id, version, func(tx RP.IUnitOfWork, request *model.TransferRequest) error {
+		return request.Schedule(plannedDate)
+	})
+}
+
+func (s *TransferRequestService) Cancel(ctx context.Context, id uuid.UUID, version int) (*model.TransferRequest, error) {
+	actor := requestcontext.ActorFrom(ctx).Name
+	return s.change(ctx, id, version, func(tx RP.IUnitOfWork, request *model.TransferRequest) error {
+		return request.Cancel(actor, "")
+	})
+}
+
+// Execute - перемещает животное и закрывает заявку в одной транзакции
+func (s *TransferRequestService) Execute(ctx context.Context, id uuid.UUID, version int) (*model.TransferRequest, error) {
+	actor := requestcontext.ActorFrom(ctx).Name
+	return s.change(ctx, id, version, func(tx RP.IUnitOfWork, request *model.TransferRequest) error {
+		if err := request.MarkExecuted(); err != nil {
+			return err
+		}
+		reason := request.Reason
+		if reason == "" {
+			reason = model.ReasonTransfer
+		}
+		return moveAnimal(tx, request.AnimalID, request.ToEnclosureID, reason, actor)
+	})
+}
+
+func (s *TransferRequestService) GetRequest(id uuid.UUID) (*model.TransferRequest, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *TransferRequestService) GetAllRequests() ([]model.TransferRequest, error) {
+	requests, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sortTransferRequests(requests)
+	return requests, nil
+}
+
+// GetPendingByEnclosure - незакрытые заявки, затрагивающие вольер
+func (s *TransferRequestService) GetPendingByEnclosure(enclosureID uuid.UUID) ([]model.TransferRequest, error) {
+	requests, err := s.repo.FindPendingByEnclosure(enclosureID)
+	if err != nil {
+		return nil, err
+	}
+	sortTransferRequests(requests)
+	return requests, nil
+}
+
+// change - загружает заявку, применяет к ней действие и сохраняет в одной транзакции
+func (s *TransferRequestService) change(ctx context.Context, id uuid.UUID, version int, action func(tx RP.IUnitOfWork, request *model.TransferRequest) error) (*model.TransferRequest, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	request, err := tx.TransferRequests().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, request.Version); err != nil {
+		return nil, err
+	}
+	if err := action(tx, request); err != nil {
+		return nil, err
+	}
+	if err := tx.TransferRequests().Save(*request); err != nil {
+		return nil, err
+	}
+
+	updated, err := tx.TransferRequests().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func sortTransferRequests(requests []model.TransferRequest) {
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+}
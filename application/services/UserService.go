@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// UserService - управление сотрудниками
+type UserService struct {
+	uow    RP.IUnitOfWorkFactory
+	repo   RP.IUserRepository
+	hasher PasswordHasher
+}
+
+func NewUserService(uow RP.IUnitOfWorkFactory, repo RP.IUserRepository, hasher PasswordHasher) *UserService {
+	return &UserService{uow: uow, repo: repo, hasher: hasher}
+}
+
+func (s *UserService) CreateUser(ctx context.Context, username string, role model.Role, password string) (*model.User, error) {
+	if err := model.ValidatePassword(password); err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+	user, err := model.NewUser(username, role, hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Users().Save(*user); err != nil {
+		return nil, err
+	}
+	created, err := tx.Users().FindByID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UserUpdate - изменяемые поля; nil - оставить как есть
+type UserUpdate struct {
+	Role     *model.Role
+	Disabled *bool
+	Password *string
+}
+
+// UpdateUser - меняет роль, блокировку или пароль; смена пароля отзывает
+// все токены пользователя.
+// Последнего активного администратора нельзя ни отключить, ни понизить.
+func (s *UserService) UpdateUser(ctx context.Context, id uuid.UUID, update UserUpdate, version int) (*model.User, error) {
+	var hash string
+	if update.Password != nil {
+		if err := model.ValidatePassword(*update.Password); err != nil {
+			return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+		}
+		var err error
+		if hash, err = s.hasher.Hash(*update.Password); err != nil {
+			return nil, err
+		}
+	}
+	if update.Role != nil && !update.Role.IsValid() {
+		return nil, fmt.Errorf("%w: неизвестная роль", model.ErrValidation)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	user, err := tx.Users().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, user.Version); err != nil {
+		return nil, err
+	}
+
+	wasActiveAdmin := user.Role == model.RoleAdmin && !user.Disabled
+	if update.Role != nil {
+		user.Role = *update.Role
+	}
+	if update.Disabled != nil {
+		user.Disabled = *update.Disabled
+	}
+	if update.Password != nil {
+		user.SetPassword(hash)
+	}
+	if wasActiveAdmin && (user.Role != model.RoleAdmin || user.Disabled) {
+		if err := ensureAnotherAdmin(tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Users().Save(*user); err != nil {
+		return nil, err
+	}
+	updated, err := tx.Users().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *UserService) GetUser(id uuid.UUID) (*model.User, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *UserService) GetUsers() ([]model.User, error) {
+	users, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+	return users, nil
+}
+
+func ensureAnotherAdmin(tx RP.IUnitOfWork, exceptID uuid.UUID) error {
+	users, err := tx.Users().FindAll()
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if user.ID != exceptID && user.Role == model.RoleAdmin && !user.Disabled {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: нельзя отключить или понизить последнего администратора", model.ErrValidation)
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// WebhookSender - отправляет подписанный запрос получателю и возвращает HTTP-код ответа
+type WebhookSender interface {
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+// WebhookRetryPolicy - повторы неудачных доставок: после n-й попытки ждём
+// InitialBackoff * 2^(n-1), но не больше MaxBackoff; после MaxAttempts - в недоставленные
+type WebhookRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration
+}
+
+// Backoff - пауза после attempt-й неудачной попытки, с разбросом ±20%,
+// чтобы повторы к одному получателю не шли пачкой
+func (p WebhookRetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.MaxBackoff)
+	jitter := time.Duration(rand.Int64N(int64(backoff)/5+1)*2) - backoff/5
+	return backoff + jitter
+}
+
+// pollInterval - как часто проверять очередь повторов
+const pollInterval = time.Second
+
+// WebhookDispatcher - ставит события шины в очередь доставок подписчикам
+// и отправляет их, повторяя неудачные попытки по WebhookRetryPolicy
+type WebhookDispatcher struct {
+	bus        *EventBus
+	webhooks   RP.IWebhookRepository
+	deliveries RP.IWebhookDeliveryRepository
+	sender     WebhookSender
+	policy     WebhookRetryPolicy
+	workers    int
+	wake       chan struct{}
+}
+
+func NewWebhookDispatcher(
+	bus *EventBus,
+	webhooks RP.IWebhookRepository,
+	deliveries RP.IWebhookDeliveryRepository,
+	sender WebhookSender,
+	policy WebhookRetryPolicy,
+	workers int,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		bus:        bus,
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		sender:     sender,
+		policy:     policy,
+		workers:    workers,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Run - принимает события и отправляет доставки, пока не отменён ctx.
+// Отправки, начатые до отмены, прерываются и дожидаются завершения;
+// прерванная отправка возвращается в очередь без учёта попытки.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	subscription, _, _ := d.bus.Subscribe(model.EventFilter{}, 0)
+	defer func() { subscription.Close() }()
+	events := subscription.Events()
+	var lastEventID int64
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	slots := make(chan struct{}, d.workers)
+	var sending sync.WaitGroup
+	defer sending.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				events = d.resubscribe(&subscription, lastEventID)
+				continue
+			}
+			lastEventID = event.ID
+			d.enqueue(event)
+		case <-d.wake:
+		case <-ticker.C:
+		}
+		d.dispatch(ctx, slots, &sending)
+	}
+}
+
+// resubscribe - шина отключила отстающую подписку; продолжаем с последнего
+// полученного события
+func (d *WebhookDispatcher) resubscribe(subscription **EventSubscription, lastEventID int64) <-chan model.ZooEvent {
+	next, missed, truncated := d.bus.Subscribe(model.EventFilter{}, lastEventID)
+	if truncated {
+		slog.Warn("часть событий вытеснена из буфера и не будет отправлена подписчикам вебхуков", "after", lastEventID)
+	}
+	for _, event := range missed {
+		d.enqueue(event)
+	}
+	*subscription = next
+	return next.Events()
+}
+
+// enqueue - создаёт доставки события для всех заинтересованных подписчиков
+func (d *WebhookDispatcher) enqueue(event model.ZooEvent) {
+	subscriptions, err := d.webhooks.FindAll()
+	if err != nil {
+		slog.Error("не удалось получить подписки на вебхуки", "error", err)
+		return
+	}
+
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				slog.Error("не удалось сериализовать событие", "event", event.ID, "error", err)
+				return
+			}
+		}
+		delivery := model.NewWebhookDelivery(subscription.ID, event, payload)
+		if err := d.deliveries.Add(*delivery); err != nil {
+			slog.Error("не удалось поставить доставку в очередь", "webhook", subscription.ID, "error", err)
+		}
+	}
+}
+
+// dispatch - запускает отправку наступивших доставок, пока есть свободные обработчики
+func (d *WebhookDispatcher) dispatch(ctx context.Context, slots chan struct{}, sending *sync.WaitGroup) {
+	free := cap(slots) - len(slots)
+	if free == 0 {
+		return
+	}
+	due, err := d.deliveries.ClaimDue(time.Now(), free)
+	if err != nil {
+		slog.Error("не удалось получить очередь доставок", "error", err)
+		return
+	}
+	for _, delivery := range due {
+		slots <- struct{}{}
+		sending.Add(1)
+		go func() {
+			defer sending.Done()
+			defer func() { <-slots }()
+			d.attempt(ctx, delivery)
+			// Освободился обработчик - возможно, в очереди ещё есть наступившие доставки
+			select {
+			case d.wake <- struct{}{}:
+			default:
+			}
+		}()
+	}
+}
+
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery model.WebhookDelivery) {
+	logger := slog.With("webhook", delivery.SubscriptionID, "delivery", delivery.ID, "event", delivery.EventID)
+
+	subscription, err := d.webhooks.FindByID(delivery.SubscriptionID)
+	if err != nil || !subscription.Active {
+		reason := "подписка отключена"
+		if err != nil {
+			reason = err.Error()
+		}
+		delivery.Record(model.WebhookAttempt{At: time.Now(), Error: reason}, false, nil)
+		d.save(logger, delivery)
+		return
+	}
+
+	started := time.Now()
+	headers := map[string]string{
+		"Content-Type":               "application/json",
+		model.WebhookEventHeader:     string(delivery.EventType),
+		model.WebhookDeliveryHeader:  delivery.ID.String(),
+		model.WebhookSignatureHeader: model.SignWebhook(subscription.Secret, started, delivery.Payload),
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, d.policy.Timeout)
+	status, err := d.sender.Send(sendCtx, subscription.URL, headers, delivery.Payload)
+	cancel()
+	if err != nil && ctx.Err() != nil {
+		// Отправку оборвала остановка, а не получатель - это не попытка
+		delivery.Interrupt()
+		d.save(logger, delivery)
+		logger.Info("отправка вебхука прервана остановкой, будет повтор", "attempt", delivery.AttemptCount+1)
+		return
+	}
+
+	attempt := model.WebhookAttempt{At: started, StatusCode: status, DurationMs: time.Since(started).Milliseconds()}
+	delivered := err == nil && status >= 200 && status < 300
+	if err != nil {
+		attempt.Error = err.Error()
+	} else if !delivered {
+		attempt.Error = fmt.Sprintf("получатель ответил %d", status)
+	}
+
+	var next *time.Time
+	if !delivered && delivery.AttemptCount+1 < d.policy.MaxAttempts {
+		at := time.Now().Add(d.policy.Backoff(delivery.AttemptCount + 1))
+		next = &at
+	}
+	delivery.Record(attempt, delivered, next)
+	d.save(logger, delivery)
+
+	switch delivery.Status {
+	case model.DeliveryDelivered:
+		logger.Debug("вебхук доставлен", "status", status)
+	case model.DeliveryDead:
+		logger.Warn("вебхук не доставлен, попытки исчерпаны", "attempts", delivery.AttemptCount, "error", attempt.Error)
+	default:
+		logger.Info("вебхук не доставлен, будет повтор", "attempt", delivery.AttemptCount, "next", next, "error", attempt.Error)
+	}
+}
+
+func (d *WebhookDispatcher) save(logger *slog.Logger, delivery model.WebhookDelivery) {
+	if err := d.deliveries.Save(delivery); err != nil {
+		logger.Error("не удалось сохранить результат доставки", "error", err)
+	}
+}
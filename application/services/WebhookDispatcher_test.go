@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"io"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/infrastructure/repositories"
+	"kpo-mini-dz2/infrastructure/webhooks"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWebhookRetryPolicyBackoff(t *testing.T) {
+	policy := WebhookRetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	tests := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{attempt: 1, base: time.Second},
+		{attempt: 2, base: 2 * time.Second},
+		{attempt: 3, base: 4 * time.Second},
+		{attempt: 4, base: 8 * time.Second},
+		{attempt: 5, base: 10 * time.Second},
+		{attempt: 50, base: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		low, high := tt.base-tt.base/5, tt.base+tt.base/5
+		for range 200 {
+			if got := policy.Backoff(tt.attempt); got < low || got > high {
+				t.Fatalf("Backoff(%d) = %v, want within [%v, %v]", tt.attempt, got, low, high)
+			}
+		}
+	}
+}
+
+func TestWebhookRetryPolicyBackoffJitter(t *testing.T) {
+	policy := WebhookRetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute}
+	seen := make(map[time.Duration]bool)
+	for range 50 {
+		seen[policy.Backoff(1)] = true
+	}
+	if len(seen) < 2 {
+		t.Fatal("Backoff() has no jitter")
+	}
+}
+
+// webhookReceiver - получатель, который отвечает кодами из statuses по очереди,
+// а после них - последним кодом
+type webhookReceiver struct {
+	*httptest.Server
+	mu         sync.Mutex
+	statuses   []int
+	requests   int
+	signatures []string
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	receiver := &webhookReceiver{statuses: statuses}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		status := receiver.statuses[min(receiver.requests, len(receiver.statuses)-1)]
+		receiver.requests++
+		receiver.signatures = append(receiver.signatures, r.Header.Get(model.WebhookSignatureHeader))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests
+}
+
+type dispatcherFixture struct {
+	bus        *EventBus
+	webhooks   *repositories.InMemoryWebhookRepository
+	deliveries *repositories.InMemoryWebhookDeliveryRepository
+	dispatcher *WebhookDispatcher
+	webhook    model.WebhookSubscription
+	// stop - останавливает Run и ждёт его завершения
+	stop func()
+}
+
+func startDispatcher(t *testing.T, target string, maxAttempts int) *dispatcherFixture {
+	f := &dispatcherFixture{
+		bus:        NewEventBus(16),
+		webhooks:   repositories.NewInMemoryWebhookRepository(),
+		deliveries: repositories.NewInMemoryWebhookDeliveryRepository(100),
+	}
+	subscription, err := model.NewWebhookSubscription(target, nil, "whsec_0123456789abcdef", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.webhooks.Save(*subscription); err != nil {
+		t.Fatal(err)
+	}
+	f.webhook = *subscription
+	policy := WebhookRetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Timeout:        time.Second,
+	}
+	f.dispatcher = NewWebhookDispatcher(f.bus, f.webhooks, f.deliveries, webhooks.NewHTTPSender(), policy, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f.dispatcher.Run(ctx)
+	}()
+	f.stop = sync.OnceFunc(func() {
+		cancel()
+		<-done
+	})
+	t.Cleanup(f.stop)
+	// Run подписывается на шину в своей горутине; ждём подписку, чтобы не потерять событие
+	waitFor(t, "dispatcher subscription", func() bool {
+		f.bus.mu.Lock()
+		defer f.bus.mu.Unlock()
+		return len(f.bus.subscribers) > 0
+	})
+	return f
+}
+
+// delivery - единственная доставка подписки, когда она дошла до статуса status
+func (f *dispatcherFixture) delivery(t *testing.T, status model.DeliveryStatus) model.WebhookDelivery {
+	var found model.WebhookDelivery
+	waitFor(t, "delivery "+string(status), func() bool {
+		deliveries, _ := f.deliveries.FindBySubscription(f.webhook.ID, 10)
+		if len(deliveries) != 1 || deliveries[0].Status != status {
+			return false
+		}
+		found = deliveries[0]
+		return true
+	})
+	return found
+}
+
+func waitFor(t *testing.T, what string, ready func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !ready() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebhookDispatcherRetriesUntilDelivered(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusOK)
+	f := startDispatcher(t, receiver.URL, 5)
+
+	f.bus.Publish(model.ZooEvent{Type: model.EventAnimalCreated, AnimalID: uuid.New()})
+	delivery := f.delivery(t, model.DeliveryDelivered)
+
+	if delivery.AttemptCount != 2 || receiver.count() != 2 {
+		t.Fatalf("attempts = %d, requests = %d, want 2 and 2", delivery.AttemptCount, receiver.count())
+	}
+	if delivery.Attempts[0].StatusCode != http.StatusInternalServerError || delivery.Attempts[1].StatusCode != http.StatusOK {
+		t.Fatalf("attempt log = %+v", delivery.Attempts)
+	}
+	for _, signature := range receiver.signatures {
+		if err := model.VerifyWebhookSignature(f.webhook.Secret, signature, delivery.Payload, time.Minute, time.Now()); err != nil {
+			t.Fatalf("receiver got an invalid signature %q: %v", signature, err)
+		}
+	}
+}
+
+func TestWebhookDispatcherDeadLetterAndRequeue(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK)
+	f := startDispatcher(t, receiver.URL, 3)
+
+	f.bus.Publish(model.ZooEvent{Type: model.EventAnimalCreated, AnimalID: uuid.New()})
+	dead := f.delivery(t, model.DeliveryDead)
+	if dead.AttemptCount != 3 || dead.NextAttemptAt != nil {
+		t.Fatalf("dead letter attempts = %d, next = %v, want 3 and nil", dead.AttemptCount, dead.NextAttemptAt)
+	}
+	if letters, _ := f.deliveries.FindDead(); len(letters) != 1 || letters[0].ID != dead.ID {
+		t.Fatalf("dead letters = %+v", letters)
+	}
+	// Исчерпанная доставка сама больше не отправляется
+	time.Sleep(50 * time.Millisecond)
+	if receiver.count() != 3 {
+		t.Fatalf("requests after dead letter = %d, want 3", receiver.count())
+	}
+
+	service := NewWebhookService(nil, f.webhooks, f.deliveries)
+	if _, err := service.Redeliver(dead.ID); err != nil {
+		t.Fatalf("Redeliver() error = %v", err)
+	}
+	// Доставка подхватывается при следующей проверке очереди
+	delivered := f.delivery(t, model.DeliveryDelivered)
+	if delivered.AttemptCount != 1 || len(delivered.Attempts) != 4 || receiver.count() != 4 {
+		t.Fatalf("after requeue attempts = %d, log = %d, requests = %d, want 1, 4 and 4",
+			delivered.AttemptCount, len(delivered.Attempts), receiver.count())
+	}
+	if _, err := service.Redeliver(dead.ID); err != model.ErrInvalidTransition {
+		t.Fatalf("Redeliver() of a delivered event error = %v, want %v", err, model.ErrInvalidTransition)
+	}
+}
+
+func TestWebhookDispatcherShutdownDoesNotCountInterruptedSend(t *testing.T) {
+	started := make(chan struct{}, 1)
+	// Получатель не отвечает, пока отправитель не оборвёт запрос
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Тело читается до конца, иначе сервер не заметит обрыв соединения
+		io.Copy(io.Discard, r.Body)
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-r.Context().Done()
+	}))
+	t.Cleanup(receiver.Close)
+	f := startDispatcher(t, receiver.URL, 1)
+
+	f.bus.Publish(model.ZooEvent{Type: model.EventAnimalCreated, AnimalID: uuid.New()})
+	select {
+	case <-started:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the send to start")
+	}
+	f.stop()
+
+	// Единственная попытка не потрачена: после запуска доставка уйдёт снова
+	deliveries, _ := f.deliveries.FindBySubscription(f.webhook.ID, 10)
+	if len(deliveries) != 1 {
+		t.Fatalf("deliveries = %+v, want one", deliveries)
+	}
+	if interrupted := deliveries[0]; interrupted.Status != model.DeliveryPending || interrupted.AttemptCount != 0 || len(interrupted.Attempts) != 0 {
+		t.Fatalf("interrupted delivery = %s after %d attempts %+v, want pending without attempts",
+			interrupted.Status, interrupted.AttemptCount, interrupted.Attempts)
+	}
+}
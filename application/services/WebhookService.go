@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultDeliveryLimit = 100
+	maxDeliveryLimit     = 1000
+)
+
+// WebhookService - управление подписками на вебхуки и журналом доставок
+type WebhookService struct {
+	uow        RP.IUnitOfWorkFactory
+	repo       RP.IWebhookRepository
+	deliveries RP.IWebhookDeliveryRepository
+}
+
+func NewWebhookService(uow RP.IUnitOfWorkFactory, repo RP.IWebhookRepository, deliveries RP.IWebhookDeliveryRepository) *WebhookService {
+	return &WebhookService{uow: uow, repo: repo, deliveries: deliveries}
+}
+
+// CreateWebhook - пустой secret генерируется; вызывающий получает его
+// в возвращённой подписке и должен передать получателю
+func (s *WebhookService) CreateWebhook(ctx context.Context, target string, eventTypes []model.EventType, secret string) (*model.WebhookSubscription, error) {
+	if secret == "" {
+		secret = newWebhookSecret()
+	}
+	subscription, err := model.NewWebhookSubscription(target, eventTypes, secret, requestcontext.ActorFrom(ctx).Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+	}
+
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Webhooks().Save(*subscription); err != nil {
+		return nil, err
+	}
+	created, err := tx.Webhooks().FindByID(subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// WebhookUpdate - изменяемые поля; nil - оставить как есть.
+// RotateSecret выдаёт новый секрет, старые подписи перестают проходить проверку.
+type WebhookUpdate struct {
+	URL          *string
+	EventTypes   *[]model.EventType
+	Active       *bool
+	RotateSecret bool
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id uuid.UUID, update WebhookUpdate, version int) (*model.WebhookSubscription, error) {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	subscription, err := tx.Webhooks().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.CheckVersion(version, subscription.Version); err != nil {
+		return nil, err
+	}
+
+	if update.URL != nil {
+		if err := subscription.SetURL(*update.URL); err != nil {
+			return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+		}
+	}
+	if update.EventTypes != nil {
+		if err := subscription.SetEventTypes(*update.EventTypes); err != nil {
+			return nil, fmt.Errorf("%w: %v", model.ErrValidation, err)
+		}
+	}
+	if update.Active != nil {
+		subscription.Active = *update.Active
+	}
+	if update.RotateSecret {
+		if err := subscription.RotateSecret(newWebhookSecret()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Webhooks().Save(*subscription); err != nil {
+		return nil, err
+	}
+	updated, err := tx.Webhooks().FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteWebhook - удаляет подписку; её недоставленные события
+// при следующей попытке уйдут в список недоставленных
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	tx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Webhooks().Delete(id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *WebhookService) GetWebhook(id uuid.UUID) (*model.WebhookSubscription, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *WebhookService) GetWebhooks() ([]model.WebhookSubscription, error) {
+	subscriptions, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+// GetDeliveries - журнал доставок подписки, от новых к старым
+func (s *WebhookService) GetDeliveries(subscriptionID uuid.UUID, limit int) ([]model.WebhookDelivery, error) {
+	if _, err := s.repo.FindByID(subscriptionID); err != nil {
+		return nil, err
+	}
+	switch {
+	case limit <= 0:
+		limit = defaultDeliveryLimit
+	case limit > maxDeliveryLimit:
+		limit = maxDeliveryLimit
+	}
+	return s.deliveries.FindBySubscription(subscriptionID, limit)
+}
+
+// GetDeadLetters - доставки, исчерпавшие попытки
+func (s *WebhookService) GetDeadLetters() ([]model.WebhookDelivery, error) {
+	return s.deliveries.FindDead()
+}
+
+// Redeliver - ставит недоставленное событие в очередь заново
+func (s *WebhookService) Redeliver(deliveryID uuid.UUID) (*model.WebhookDelivery, error) {
+	delivery, err := s.deliveries.FindByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := delivery.Requeue(); err != nil {
+		return nil, err
+	}
+	if err := s.deliveries.Save(*delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func newWebhookSecret() string {
+	return "whsec_" + rand.Text()
+}
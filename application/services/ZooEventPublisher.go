@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ZooEventPublisher - превращает зафиксированные изменения и срабатывания
+// планировщика в события шины. События строятся по результату транзакции,
+// поэтому не зависят от того, какой сервис внёс изменение.
+type ZooEventPublisher struct {
+	bus     *EventBus
+	animals RP.IAnimalRepository
+}
+
+func NewZooEventPublisher(bus *EventBus, animals RP.IAnimalRepository) *ZooEventPublisher {
+	return &ZooEventPublisher{bus: bus, animals: animals}
+}
+
+// OnCommit - хук фиксации транзакции
+func (p *ZooEventPublisher) OnCommit(ctx context.Context, changes []model.EntityChange) {
+	now := time.Now()
+	actor := requestcontext.ActorFrom(ctx).Name
+
+	var events []model.ZooEvent
+	for _, change := range changes {
+		events = append(events, p.eventsFor(change)...)
+	}
+	for i := range events {
+		events[i].At = now
+		events[i].Actor = actor
+	}
+	if len(events) > 0 {
+		p.bus.Publish(events...)
+	}
+}
+
+// OnFeedingTime - обработчик FeedingScheduler
+func (p *ZooEventPublisher) OnFeedingTime(e model.FeedingTimeEvent) {
+	p.bus.Publish(model.ZooEvent{
+		Type:         model.EventFeedingDue,
+		AnimalID:     e.AnimalID,
+		EnclosureIDs: p.enclosureOf(e.AnimalID),
+		Data:         e,
+	})
+}
+
+// OnCareDue - обработчик CareReminderScheduler
+func (p *ZooEventPublisher) OnCareDue(task model.CareTask) {
+	p.bus.Publish(model.ZooEvent{
+		Type:         model.EventCareDue,
+		AnimalID:     task.AnimalID,
+		EnclosureIDs: p.enclosureOf(task.AnimalID),
+		Data:         task,
+	})
+}
+
+func (p *ZooEventPublisher) eventsFor(change model.EntityChange) []model.ZooEvent {
+	switch change.Entity {
+	case model.EntityAnimal:
+		return animalEvents(change)
+	case model.EntityMovement:
+		movement, ok := change.After.(model.Movement)
+		// Поступление и выбытие уже видны как animal.created/animal.deleted
+		if !ok || movement.FromEnclosureID == uuid.Nil || movement.ToEnclosureID == uuid.Nil {
+			return nil
+		}
+		return []model.ZooEvent{{
+			Type:         model.EventAnimalMoved,
+			AnimalID:     movement.AnimalID,
+			EnclosureIDs: []uuid.UUID{movement.FromEnclosureID, movement.ToEnclosureID},
+			Data:         movement,
+		}}
+	case model.EntityFeedingSchedule:
+		before, hadBefore := change.Before.(model.FeedingSchedule)
+		after, ok := change.After.(model.FeedingSchedule)
+		if !ok || after.DoneAt == nil || (hadBefore && before.DoneAt != nil) {
+			return nil
+		}
+		return []model.ZooEvent{{
+			Type:         model.EventFeedingCompleted,
+			AnimalID:     after.AnimalID,
+			EnclosureIDs: p.enclosureOf(after.AnimalID),
+			Data:         after,
+		}}
+	}
+	return nil
+}
+
+func animalEvents(change model.EntityChange) []model.ZooEvent {
+	before, hadBefore := change.Before.(model.Animal)
+	after, hasAfter := change.After.(model.Animal)
+
+	switch {
+	case !hadBefore && hasAfter:
+		return []model.ZooEvent{{
+			Type:         model.EventAnimalCreated,
+			AnimalID:     after.ID,
+			EnclosureIDs: enclosureIDs(after.EnclosureID),
+			Data:         after,
+		}}
+	case hadBefore && !hasAfter:
+		// Окончательная очистка удалённого животного - о его удалении уже сообщили
+		if before.IsDeleted() {
+			return nil
+		}
+		return []model.ZooEvent{{
+			Type:         model.EventAnimalDeleted,
+			AnimalID:     before.ID,
+			EnclosureIDs: enclosureIDs(before.EnclosureID),
+			Data:         before,
+		}}
+	case !hadBefore || !hasAfter:
+		return nil
+	case change.Action == model.AuditArchive:
+		return []model.ZooEvent{{
+			Type:         model.EventAnimalDeleted,
+			AnimalID:     after.ID,
+			EnclosureIDs: enclosureIDs(before.EnclosureID),
+			Data:         after,
+		}}
+	case change.Action == model.AuditRestore:
+		return []model.ZooEvent{{
+			Type:     model.EventAnimalRestored,
+			AnimalID: after.ID,
+			Data:     after,
+		}}
+	}
+
+	var events []model.ZooEvent
+	if before.HealthStatus != after.HealthStatus {
+		events = append(events, model.ZooEvent{
+			Type:         model.EventHealthChanged,
+			AnimalID:     after.ID,
+			EnclosureIDs: enclosureIDs(after.EnclosureID),
+			Data:         model.HealthChange{Before: before.HealthStatus, After: after.HealthStatus},
+		})
+	}
+	if before.State != after.State {
+		// Выбывшее животное уже не в вольере, вернувшееся - ещё не было;
+		// событие получат подписчики и прежнего, и нового вольера
+		ids := enclosureIDs(before.EnclosureID)
+		if after.EnclosureID != before.EnclosureID {
+			ids = append(ids, enclosureIDs(after.EnclosureID)...)
+		}
+		events = append(events, model.ZooEvent{
+			Type:         model.EventStateChanged,
+			AnimalID:     after.ID,
+			EnclosureIDs: ids,
+			Data:         after.LastTransition(),
+		})
+	}
+	return events
+}
+
+func (p *ZooEventPublisher) enclosureOf(animalID uuid.UUID) []uuid.UUID {
+	animal, err := p.animals.FindByID(animalID)
+	if err != nil {
+		return nil
+	}
+	return enclosureIDs(animal.EnclosureID)
+}
+
+func enclosureIDs(id uuid.UUID) []uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return []uuid.UUID{id}
+}
@@ -1,8 +1,14 @@
 package services
 
 import (
+	"context"
+	"fmt"
 	"kpo-mini-dz2/domain/model"
 	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 /*
@@ -12,16 +18,32 @@ ZooStatisticsService - функции:
 вывод по видам
 */
 type ZooStatisticsService struct {
+	uow           RP.IUnitOfWorkFactory
 	AnimalRepo    RP.IAnimalRepository
 	EnclosureRepo RP.IEnclosureRepository
+	FeedingRepo   RP.IFeedingScheduleRepository
+	MovementRepo  RP.IMovementRepository
 }
 
-func NewZooStatisticsService(animalRepo RP.IAnimalRepository, enclousureRepo RP.IEnclosureRepository) *ZooStatisticsService {
-	return &ZooStatisticsService{AnimalRepo: animalRepo, EnclosureRepo: enclousureRepo}
+func NewZooStatisticsService(
+	uow RP.IUnitOfWorkFactory,
+	animalRepo RP.IAnimalRepository,
+	enclousureRepo RP.IEnclosureRepository,
+	feedingRepo RP.IFeedingScheduleRepository,
+	movementRepo RP.IMovementRepository,
+) *ZooStatisticsService {
+	return &ZooStatisticsService{
+		uow:           uow,
+		AnimalRepo:    animalRepo,
+		EnclosureRepo: enclousureRepo,
+		FeedingRepo:   feedingRepo,
+		MovementRepo:  movementRepo,
+	}
 }
 
 func GetAllAnimals(z *ZooStatisticsService) ([]model.Animal, error) {
-	return z.AnimalRepo.FindAll()
+	animals, err := z.AnimalRepo.FindAll()
+	return model.PresentAnimals(animals), err
 }
 
 func GetAllEnclousure(z ZooStatisticsService) ([]model.Enclosure, error) { //reedit
@@ -31,10 +53,184 @@ func GetAllEnclousure(z ZooStatisticsService) ([]model.Enclosure, error) { //ree
 func GetAnimalBySpecies(z *ZooStatisticsService, s model.Species) ([]model.Animal, error) {
 	animals, _ := z.AnimalRepo.FindAll()
 	AnimalForSpecies := make([]model.Animal, 0)
-	for _, animal := range animals {
+	for _, animal := range model.PresentAnimals(animals) {
 		if animal.Species == s {
 			AnimalForSpecies = append(AnimalForSpecies, animal)
 		}
 	}
 	return AnimalForSpecies, nil
 }
+
+type SpeciesHealthCount struct {
+	Species      string             `json:"species"`
+	HealthStatus model.HealthStatus `json:"healthStatus"`
+	Count        int                `json:"count"`
+}
+
+type EnclosureOccupancy struct {
+	EnclosureID  uuid.UUID        `json:"enclosureID"`
+	Type         model.AnimalType `json:"type"`
+	CurrentCount int              `json:"currentCount"`
+	MaxCapacity  int              `json:"maxCapacity"`
+	Ratio        float64          `json:"ratio"`
+}
+
+type FeedingCounts struct {
+	Upcoming int `json:"upcoming"`
+	Missed   int `json:"missed"`
+}
+
+type DailyCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// ZooStatistics - сводка по зоопарку для GET /api/statistics и zooctl stats
+type ZooStatistics struct {
+	AnimalCount      int                  `json:"animalCount"`
+	EnclosureCount   int                  `json:"enclosureCount"`
+	AnimalsBySpecies []SpeciesHealthCount `json:"animalsBySpecies"`
+	Occupancy        []EnclosureOccupancy `json:"occupancy"`
+	Feedings         FeedingCounts        `json:"feedings"`
+	TransfersPerDay  []DailyCount         `json:"transfersPerDay"`
+}
+
+// MaxTransferDays - самый длинный период, за который считаются перемещения
+const MaxTransferDays = 366
+
+// GetStatistics - все показатели сразу; перемещения - за последние transferDays дней.
+// Показатели считаются в одной транзакции только для чтения и сходятся между собой.
+func (z *ZooStatisticsService) GetStatistics(now time.Time, transferDays int) (*ZooStatistics, error) {
+	if transferDays < 1 || transferDays > MaxTransferDays {
+		return nil, fmt.Errorf("%w: период перемещений должен быть от 1 до %d дней", model.ErrValidation, MaxTransferDays)
+	}
+
+	tx, err := z.uow.BeginRead(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	view := NewZooStatisticsService(z.uow, tx.Animals(), tx.Enclosures(), tx.FeedingSchedules(), tx.Movements())
+
+	var result ZooStatistics
+	result.AnimalCount = view.AnimalRepo.AnimalCount()
+	if result.AnimalsBySpecies, err = view.CountAnimalsBySpeciesAndHealth(); err != nil {
+		return nil, err
+	}
+	if result.Occupancy, err = view.GetEnclosureOccupancy(); err != nil {
+		return nil, err
+	}
+	result.EnclosureCount = len(result.Occupancy)
+	if result.Feedings, err = view.CountFeedings(now); err != nil {
+		return nil, err
+	}
+	if result.TransfersPerDay, err = view.CountTransfersPerDay(now, transferDays); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CountAnimalsBySpeciesAndHealth - число животных по виду и состоянию здоровья
+func (z *ZooStatisticsService) CountAnimalsBySpeciesAndHealth() ([]SpeciesHealthCount, error) {
+	animals, err := z.AnimalRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		species string
+		health  model.HealthStatus
+	}
+	counts := make(map[key]int)
+	for _, animal := range model.PresentAnimals(animals) {
+		counts[key{animal.Species.Name, animal.HealthStatus}]++
+	}
+
+	result := make([]SpeciesHealthCount, 0, len(counts))
+	for k, count := range counts {
+		result = append(result, SpeciesHealthCount{Species: k.species, HealthStatus: k.health, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Species != result[j].Species {
+			return result[i].Species < result[j].Species
+		}
+		return result[i].HealthStatus < result[j].HealthStatus
+	})
+	return result, nil
+}
+
+// GetEnclosureOccupancy - заполненность каждого вольера
+func (z *ZooStatisticsService) GetEnclosureOccupancy() ([]EnclosureOccupancy, error) {
+	enclosures, err := z.EnclosureRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]EnclosureOccupancy, 0, len(enclosures))
+	for _, enclosure := range enclosures {
+		occupancy := EnclosureOccupancy{
+			EnclosureID:  enclosure.ID,
+			Type:         enclosure.Type,
+			CurrentCount: enclosure.CurrentCount,
+			MaxCapacity:  enclosure.MaxCapacity,
+		}
+		if enclosure.MaxCapacity > 0 {
+			occupancy.Ratio = float64(enclosure.CurrentCount) / float64(enclosure.MaxCapacity)
+		}
+		result = append(result, occupancy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].EnclosureID.String() < result[j].EnclosureID.String()
+	})
+	return result, nil
+}
+
+// CountFeedings - предстоящие и пропущенные кормления на момент now
+func (z *ZooStatisticsService) CountFeedings(now time.Time) (FeedingCounts, error) {
+	all, err := z.FeedingRepo.GetAllSchedules()
+	if err != nil {
+		return FeedingCounts{}, err
+	}
+
+	var counts FeedingCounts
+	for _, schedules := range all {
+		for _, schedule := range schedules {
+			switch {
+			case schedule.IsMissed(now):
+				counts.Missed++
+			case schedule.DoneAt == nil:
+				counts.Upcoming++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// CountTransfersPerDay - перемещения между вольерами за последние days дней
+func (z *ZooStatisticsService) CountTransfersPerDay(now time.Time, days int) ([]DailyCount, error) {
+	movements, err := z.MovementRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, days)
+	result := make([]DailyCount, 0, days)
+	for i := days - 1; i >= 0; i-- {
+		date := now.AddDate(0, 0, -i).Format(time.DateOnly)
+		counts[date] = 0
+		result = append(result, DailyCount{Date: date})
+	}
+	for _, movement := range movements {
+		if movement.FromEnclosureID == uuid.Nil || movement.ToEnclosureID == uuid.Nil {
+			continue
+		}
+		date := movement.MovedAt.In(now.Location()).Format(time.DateOnly)
+		if _, ok := counts[date]; ok {
+			counts[date]++
+		}
+	}
+	for i := range result {
+		result[i].Count = counts[result[i].Date]
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"kpo-mini-dz2/infrastructure/repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// testZoo - зоопарк в памяти для тестов сервисов; действия выполняет администратор
+type testZoo struct {
+	t          *testing.T
+	ctx        context.Context
+	store      *repositories.InMemoryStore
+	uow        RP.IUnitOfWorkFactory
+	animals    *AnimalService
+	enclosures *EnclosureService
+}
+
+func newTestZoo(t *testing.T) *testZoo {
+	store := repositories.NewInMemoryStore(100)
+	uow := repositories.NewInMemoryUnitOfWorkFactory(store)
+	return &testZoo{
+		t:          t,
+		ctx:        actorContext(model.RoleAdmin),
+		store:      store,
+		uow:        uow,
+		animals:    NewAnimalService(uow),
+		enclosures: NewEnclosureService(uow),
+	}
+}
+
+// actorContext - контекст запроса сотрудника с ролью role
+func actorContext(role model.Role) context.Context {
+	return requestcontext.WithActor(context.Background(), requestcontext.Actor{ID: uuid.New(), Name: "test", Role: role})
+}
+
+// enclosure - вольер 10x10x3 заданного типа
+func (z *testZoo) enclosure(name string, animalType model.AnimalType, capacity int) *model.Enclosure {
+	z.t.Helper()
+	enclosure, err := z.enclosures.AddEnclosure(z.ctx, name, animalType, model.Size{Lenght: 10, Width: 10, Height: 3}, capacity)
+	if err != nil {
+		z.t.Fatal(err)
+	}
+	return enclosure
+}
+
+// wolf - данные здорового волка 2020 года рождения; тест меняет нужные поля
+func wolf(name string, enclosureID uuid.UUID) model.Animal {
+	return model.Animal{
+		Name:         name,
+		Species:      model.Species{AnimalType: model.Predator, Name: "Grey wolf"},
+		BirthDate:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		EnclosureID:  enclosureID,
+		Gender:       model.Male,
+		FavoriteFood: model.Food{FoodType: model.Meat, Name: "beef"},
+	}
+}
+
+func (z *testZoo) add(animal model.Animal) *model.Animal {
+	z.t.Helper()
+	added, err := z.animals.AddAnimal(z.ctx, animal)
+	if err != nil {
+		z.t.Fatal(err)
+	}
+	return added
+}
+
+// count - число животных в вольере по данным хранилища
+func (z *testZoo) count(id uuid.UUID) int {
+	z.t.Helper()
+	enclosure, err := z.store.Enclosures.FindByID(id)
+	if err != nil {
+		z.t.Fatal(err)
+	}
+	return enclosure.CurrentCount
+}
@@ -0,0 +1,103 @@
+// auditverify - проверка журнала аудита без запущенного сервера.
+//
+// Проверить подписанную выгрузку (GET /api/audit/export):
+//
+//	auditverify -public-key zoo-audit.pub zoo-audit-20250101-120000.json
+//
+// Проверить журнал в файле данных файлового хранилища:
+//
+//	auditverify -data zoo-data.json
+//
+// Код выхода: 0 - журнал цел, 1 - цепочка или подпись нарушены, 2 - ошибка запуска.
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/infrastructure/signing"
+	"os"
+)
+
+func main() {
+	dataPath := flag.String("data", "", "data file of the file storage backend")
+	publicKeyPath := flag.String("public-key", "", "PEM public key of the zoo (GET /api/audit/public-key)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: auditverify [-public-key file] bundle.json | auditverify -data zoo-data.json")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var (
+		verification model.AuditVerification
+		err          error
+	)
+	switch {
+	case *dataPath != "" && flag.NArg() == 0:
+		verification, err = verifyData(*dataPath)
+	case *dataPath == "" && flag.NArg() == 1:
+		verification, err = verifyBundle(flag.Arg(0), *publicKeyPath)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Ошибка:", err)
+		os.Exit(2)
+	}
+
+	if !verification.Valid {
+		if verification.BrokenAt > 0 {
+			fmt.Printf("НАРУШЕНО: записей %d, первая недостоверная запись %d: %s\n",
+				verification.Entries, verification.BrokenAt, verification.Reason)
+		} else {
+			fmt.Printf("НАРУШЕНО: записей %d: %s\n", verification.Entries, verification.Reason)
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("OK: записей %d, хеш последней записи %s\n", verification.Entries, verification.HeadHash)
+}
+
+func verifyBundle(path string, publicKeyPath string) (model.AuditVerification, error) {
+	var bundle model.AuditBundle
+	if err := readJSON(path, &bundle); err != nil {
+		return model.AuditVerification{}, err
+	}
+
+	var trusted ed25519.PublicKey
+	if publicKeyPath != "" {
+		data, err := os.ReadFile(publicKeyPath)
+		if err != nil {
+			return model.AuditVerification{}, err
+		}
+		if trusted, err = signing.ParsePublicKey(data); err != nil {
+			return model.AuditVerification{}, fmt.Errorf("%s: %w", publicKeyPath, err)
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, "Внимание: без -public-key проверяется только целостность, но не то, кто подписал выгрузку")
+	}
+	return bundle.Verify(trusted)
+}
+
+func verifyData(path string) (model.AuditVerification, error) {
+	var data struct {
+		Audit []model.AuditEntry `json:"audit"`
+	}
+	if err := readJSON(path, &data); err != nil {
+		return model.AuditVerification{}, err
+	}
+	return model.VerifyAuditChain(data.Audit), nil
+}
+
+func readJSON(path string, target any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"kpo-mini-dz2/application/dataexchange"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// backend - операции zooctl; выполняются через REST API или прямо над файлом данных
+type backend interface {
+	// Animals - животные в состоянии state; пустое - все, кто сейчас в зоопарке
+	Animals(state model.LifecycleState) ([]model.Animal, error)
+	CreateAnimal(data model.Animal) (*model.Animal, error)
+	DeleteAnimal(id uuid.UUID) error
+	ChangeAnimalState(id uuid.UUID, change services.LifecycleChange) (*model.Animal, error)
+
+	Enclosures() ([]model.Enclosure, error)
+	CreateEnclosure(name string, enclosureType model.AnimalType, size model.Size, maxCapacity int) (*model.Enclosure, error)
+	DeleteEnclosure(id uuid.UUID) error
+
+	Transfer(animalID uuid.UUID, toEnclosureID uuid.UUID, reason string) error
+
+	Schedules(animalID uuid.UUID) ([]model.FeedingSchedule, error)
+	AddSchedule(animalID uuid.UUID, feedingTime time.Time, foodType model.FoodType) error
+	RemoveSchedule(animalID uuid.UUID, feedingTime time.Time) error
+	MarkDone(id uuid.UUID) (*model.FeedingSchedule, error)
+
+	Statistics(transferDays int) (*services.ZooStatistics, error)
+
+	ImportEnclosures(records []dataexchange.EnclosureRecord, mode services.ImportMode) (*services.ImportReport, error)
+	ImportAnimals(records []dataexchange.AnimalRecord, mode services.ImportMode) (*services.ImportReport, error)
+	ExportEnclosures() ([]dataexchange.EnclosureRecord, error)
+	ExportAnimals() ([]dataexchange.AnimalRecord, error)
+
+	LoadFixture(data []byte) (*services.FixtureReport, error)
+}
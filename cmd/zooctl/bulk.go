@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"kpo-mini-dz2/application/dataexchange"
+	"kpo-mini-dz2/application/services"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// bulkImport - файл разбирается здесь же, на сервер уходят записи в JSON,
+// поэтому CSV загружается одинаково через REST API и в файл данных
+func bulkImport[T any](name string, args []string, decode func(io.Reader, dataexchange.Format) ([]T, error),
+	load func([]T, services.ImportMode) (*services.ImportReport, error), out *printer) error {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	mode := flags.String("mode", string(services.ImportAllOrNothing), "all-or-nothing or skip-invalid")
+	formatName := flags.String("format", "", "csv or json; by file extension by default")
+	positional, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	importMode, err := services.ParseImportMode(*mode)
+	if err != nil {
+		return err
+	}
+	format, err := fileFormat(*formatName, positional[0])
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	records, err := decode(file, format)
+	if err != nil {
+		return fmt.Errorf("%s: %w", positional[0], err)
+	}
+
+	report, err := load(records, importMode)
+	if err != nil {
+		return err
+	}
+	if err := printReport(out, report); err != nil {
+		return err
+	}
+	if !report.Applied {
+		return fmt.Errorf("ничего не загружено: ошибочных строк %d из %d", report.Failed, report.Total)
+	}
+	return nil
+}
+
+func printReport(out *printer, report *services.ImportReport) error {
+	summary := table{header: []string{"MODE", "TOTAL", "IMPORTED", "FAILED", "APPLIED"}}
+	summary.add(string(report.Mode), strconv.Itoa(report.Total), strconv.Itoa(report.Imported),
+		strconv.Itoa(report.Failed), strconv.FormatBool(report.Applied))
+	if len(report.Errors) == 0 {
+		return out.print(report, summary)
+	}
+	errorsTable := table{header: []string{"ROW", "ERROR"}}
+	for _, rowError := range report.Errors {
+		errorsTable.add(strconv.Itoa(rowError.Row), rowError.Error)
+	}
+	return out.print(report, summary, errorsTable)
+}
+
+// bulkExport - без файла записи печатаются в stdout, -o не учитывается
+func bulkExport[T any](name string, args []string, fetch func() ([]T, error),
+	encode func(io.Writer, dataexchange.Format, []T) error, out *printer) error {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	formatName := flags.String("format", "", "csv or json; by file extension, csv by default")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if flags.NArg() > 1 {
+		return usageError("%s: ожидается не больше одного файла", name)
+	}
+	path := flags.Arg(0)
+	if *formatName == "" && path == "" {
+		*formatName = string(dataexchange.FormatCSV)
+	}
+	format, err := fileFormat(*formatName, path)
+	if err != nil {
+		return err
+	}
+
+	records, err := fetch()
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return encode(out.w, format, records)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := encode(file, format, records); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Выгружено строк: %d\n", len(records))
+	return nil
+}
+
+// fileFormat - явный -format или расширение файла
+func fileFormat(value string, path string) (dataexchange.Format, error) {
+	if value != "" {
+		return dataexchange.ParseFormat(value)
+	}
+	extension := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if extension == "" {
+		return "", errors.New("не удалось определить формат по имени файла, укажите -format")
+	}
+	format, err := dataexchange.ParseFormat(extension)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w, укажите -format", path, err)
+	}
+	return format, nil
+}
@@ -0,0 +1,511 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"kpo-mini-dz2/application/dataexchange"
+	"kpo-mini-dz2/application/fixtures"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+func run(client backend, out *printer, args []string) error {
+	command, args := args[0], args[1:]
+	switch command {
+	case "login":
+		return login(client, out)
+	case "animals":
+		return subcommand(args, map[string]func([]string) error{
+			"list":   func(args []string) error { return listAnimals(client, out, args) },
+			"create": func(args []string) error { return createAnimal(client, out, args) },
+			"delete": func(args []string) error { return deleteAnimal(client, out, args) },
+			"state":  func(args []string) error { return changeAnimalState(client, out, args) },
+			"import": func(args []string) error {
+				return bulkImport("animals import", args, dataexchange.DecodeAnimals, client.ImportAnimals, out)
+			},
+			"export": func(args []string) error {
+				return bulkExport("animals export", args, client.ExportAnimals, dataexchange.EncodeAnimals, out)
+			},
+		})
+	case "enclosures":
+		return subcommand(args, map[string]func([]string) error{
+			"list":   func(args []string) error { return listEnclosures(client, out, args) },
+			"create": func(args []string) error { return createEnclosure(client, out, args) },
+			"delete": func(args []string) error { return deleteEnclosure(client, out, args) },
+			"import": func(args []string) error {
+				return bulkImport("enclosures import", args, dataexchange.DecodeEnclosures, client.ImportEnclosures, out)
+			},
+			"export": func(args []string) error {
+				return bulkExport("enclosures export", args, client.ExportEnclosures, dataexchange.EncodeEnclosures, out)
+			},
+		})
+	case "transfer":
+		return transfer(client, out, args)
+	case "schedules":
+		return subcommand(args, map[string]func([]string) error{
+			"list":   func(args []string) error { return listSchedules(client, out, args) },
+			"add":    func(args []string) error { return addSchedule(client, out, args) },
+			"remove": func(args []string) error { return removeSchedule(client, out, args) },
+			"done":   func(args []string) error { return markDone(client, out, args) },
+		})
+	case "stats":
+		return stats(client, out, args)
+	case "fixtures":
+		return subcommand(args, map[string]func([]string) error{
+			"load": func(args []string) error { return loadFixture(client, out, args) },
+		})
+	case "export":
+		return exportData(client, out, args)
+	case "import":
+		return importData(client, out, args)
+	default:
+		return usageError("неизвестная команда %q", command)
+	}
+}
+
+func subcommand(args []string, commands map[string]func([]string) error) error {
+	if len(args) == 0 {
+		return usageError("не указана подкоманда")
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		return usageError("неизвестная подкоманда %q", args[0])
+	}
+	return command(args[1:])
+}
+
+// usageError - печатает причину и общую подсказку
+func usageError(format string, args ...any) error {
+	fmt.Fprintf(os.Stderr, "Ошибка: "+format+"\n\n", args...)
+	fmt.Fprint(os.Stderr, usage)
+	return errUsage
+}
+
+// parseFlags - флаги подкоманды; positional - сколько ожидается аргументов после них
+func parseFlags(flags *flag.FlagSet, args []string, positional int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	if flags.NArg() != positional {
+		fmt.Fprintf(os.Stderr, "Ошибка: %s: ожидается аргументов: %d\n", flags.Name(), positional)
+		flags.Usage()
+		return nil, errUsage
+	}
+	return flags.Args(), nil
+}
+
+func parseUUID(value string, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: некорректный идентификатор %q", name, value)
+	}
+	return id, nil
+}
+
+func login(client backend, out *printer) error {
+	rest, ok := client.(*restBackend)
+	if !ok {
+		return errors.New("login работает только с REST API")
+	}
+	if rest.token == "" {
+		return errors.New("укажите -user и -password")
+	}
+	return out.print(map[string]string{"token": rest.token}, table{header: []string{"TOKEN"}, rows: [][]string{{rest.token}}})
+}
+
+func listAnimals(client backend, out *printer, args []string) error {
+	flags := flag.NewFlagSet("animals list", flag.ContinueOnError)
+	state := flags.String("state", "", "only animals in this lifecycle state; animals in the zoo by default")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if *state != "" && !model.LifecycleState(*state).IsValid() {
+		return fmt.Errorf("-state: неизвестное состояние %q", *state)
+	}
+	animals, err := client.Animals(model.LifecycleState(*state))
+	if err != nil {
+		return err
+	}
+	return out.print(animals, animalTable(animals))
+}
+
+func animalTable(animals []model.Animal) table {
+	t := table{header: []string{"ID", "NAME", "SPECIES", "TYPE", "GENDER", "BIRTH", "HEALTH", "STATE", "ENCLOSURE", "FOOD", "VERSION"}}
+	for _, animal := range animals {
+		t.add(
+			animal.ID.String(),
+			animal.Name,
+			animal.Species.Name,
+			string(animal.Species.AnimalType),
+			string(animal.Gender),
+			animal.BirthDate.Format(dateLayout),
+			string(animal.HealthStatus),
+			string(animal.State),
+			optionalID(animal.EnclosureID),
+			fmt.Sprintf("%s (%s)", animal.FavoriteFood.Name, animal.FavoriteFood.FoodType),
+			strconv.Itoa(animal.Version),
+		)
+	}
+	return t
+}
+
+func createAnimal(client backend, out *printer, args []string) error {
+	flags := flag.NewFlagSet("animals create", flag.ContinueOnError)
+	name := flags.String("name", "", "name")
+	species := flags.String("species", "", "species name")
+	animalType := flags.String("type", "", "predator, herbivore, omnivore, aquatic or avian")
+	gender := flags.String("gender", "", "male or female")
+	birth := flags.String("birth", "", "birth date, YYYY-MM-DD")
+	health := flags.String("health", string(model.Healthy), "healthy or sick")
+	foodType := flags.String("food-type", "", "meat, grass, fish, fruit or vegetable")
+	food := flags.String("food", "", "favorite food")
+	enclosure := flags.String("enclosure", "", "enclosure ID to settle the animal in")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	birthDate, err := time.Parse(dateLayout, *birth)
+	if err != nil {
+		return fmt.Errorf("-birth: ожидается дата YYYY-MM-DD, получено %q", *birth)
+	}
+	data := model.Animal{
+		Name:         *name,
+		Species:      model.Species{AnimalType: model.AnimalType(*animalType), Name: *species},
+		BirthDate:    birthDate,
+		HealthStatus: model.HealthStatus(*health),
+		Gender:       model.Gender(*gender),
+		FavoriteFood: model.Food{FoodType: model.FoodType(*foodType), Name: *food},
+	}
+	if *enclosure != "" {
+		if data.EnclosureID, err = parseUUID(*enclosure, "-enclosure"); err != nil {
+			return err
+		}
+	}
+
+	animal, err := client.CreateAnimal(data)
+	if err != nil {
+		return err
+	}
+	return out.print(animal, animalTable([]model.Animal{*animal}))
+}
+
+func deleteAnimal(client backend, out *printer, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("animals delete", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseUUID(positional[0], "id")
+	if err != nil {
+		return err
+	}
+	if err := client.DeleteAnimal(id); err != nil {
+		return err
+	}
+	return out.message("Животное %s удалено", id)
+}
+
+func changeAnimalState(client backend, out *printer, args []string) error {
+	flags := flag.NewFlagSet("animals state", flag.ContinueOnError)
+	state := flags.String("state", "", "incoming, quarantine, on-display, off-display, loaned-out, transferred-out or deceased")
+	reason := flags.String("reason", "", "why the state changes")
+	date := flags.String("date", "", "when it happened, YYYY-MM-DD or RFC 3339; now by default")
+	enclosure := flags.String("enclosure", "", "enclosure ID to settle a returning animal in")
+	positional, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseUUID(positional[0], "id")
+	if err != nil {
+		return err
+	}
+
+	change := services.LifecycleChange{State: model.LifecycleState(*state), Date: time.Now(), Reason: *reason}
+	if *date != "" {
+		if change.Date, err = time.Parse(time.RFC3339, *date); err != nil {
+			if change.Date, err = time.ParseInLocation(dateLayout, *date, time.Local); err != nil {
+				return fmt.Errorf("-date: ожидается дата YYYY-MM-DD или RFC 3339, получено %q", *date)
+			}
+		}
+	}
+	if *enclosure != "" {
+		if change.EnclosureID, err = parseUUID(*enclosure, "-enclosure"); err != nil {
+			return err
+		}
+	}
+
+	animal, err := client.ChangeAnimalState(id, change)
+	if err != nil {
+		return err
+	}
+	return out.print(animal, animalTable([]model.Animal{*animal}))
+}
+
+func listEnclosures(client backend, out *printer, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("enclosures list", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+	enclosures, err := client.Enclosures()
+	if err != nil {
+		return err
+	}
+	return out.print(enclosures, enclosureTable(enclosures))
+}
+
+func enclosureTable(enclosures []model.Enclosure) table {
+	t := table{header: []string{"ID", "NAME", "TYPE", "SIZE", "ANIMALS", "CAPACITY", "VERSION"}}
+	for _, enclosure := range enclosures {
+		t.add(
+			enclosure.ID.String(),
+			enclosure.Name,
+			string(enclosure.Type),
+			fmt.Sprintf("%dx%dx%d", enclosure.Size.Lenght, enclosure.Size.Width, enclosure.Size.Height),
+			strconv.Itoa(enclosure.CurrentCount),
+			strconv.Itoa(enclosure.MaxCapacity),
+			strconv.Itoa(enclosure.Version),
+		)
+	}
+	return t
+}
+
+func createEnclosure(client backend, out *printer, args []string) error {
+	flags := flag.NewFlagSet("enclosures create", flag.ContinueOnError)
+	name := flags.String("name", "", "unique name, optional")
+	enclosureType := flags.String("type", "", "predator, herbivore, omnivore, aquatic or avian")
+	length := flags.Int("length", 0, "length")
+	width := flags.Int("width", 0, "width")
+	height := flags.Int("height", 0, "height")
+	capacity := flags.Int("capacity", 0, "maximum number of animals")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	enclosure, err := client.CreateEnclosure(*name, model.AnimalType(*enclosureType), model.Size{Lenght: *length, Width: *width, Height: *height}, *capacity)
+	if err != nil {
+		return err
+	}
+	return out.print(enclosure, enclosureTable([]model.Enclosure{*enclosure}))
+}
+
+func deleteEnclosure(client backend, out *printer, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("enclosures delete", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseUUID(positional[0], "id")
+	if err != nil {
+		return err
+	}
+	if err := client.DeleteEnclosure(id); err != nil {
+		return err
+	}
+	return out.message("Вольер %s удалён", id)
+}
+
+func transfer(client backend, out *printer, args []string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return usageError("transfer: ожидается <animal-id> <enclosure-id> [reason]")
+	}
+	animalID, err := parseUUID(args[0], "animal-id")
+	if err != nil {
+		return err
+	}
+	toEnclosureID, err := parseUUID(args[1], "enclosure-id")
+	if err != nil {
+		return err
+	}
+	var reason string
+	if len(args) == 3 {
+		reason = args[2]
+	}
+
+	if err := client.Transfer(animalID, toEnclosureID, reason); err != nil {
+		return err
+	}
+	return out.message("Животное %s перемещено в вольер %s", animalID, toEnclosureID)
+}
+
+func listSchedules(client backend, out *printer, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("schedules list", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	animalID, err := parseUUID(positional[0], "animal-id")
+	if err != nil {
+		return err
+	}
+	schedules, err := client.Schedules(animalID)
+	if err != nil {
+		return err
+	}
+	return out.print(schedules, scheduleTable(schedules, time.Now()))
+}
+
+func scheduleTable(schedules []model.FeedingSchedule, now time.Time) table {
+	t := table{header: []string{"ID", "TIME", "FOOD", "STATUS", "VERSION"}}
+	for _, schedule := range schedules {
+		status := "pending"
+		switch {
+		case schedule.DoneAt != nil:
+			status = "done " + schedule.DoneAt.Format(time.RFC3339)
+		case schedule.IsMissed(now):
+			status = "missed"
+		}
+		t.add(
+			schedule.ID.String(),
+			schedule.FeedingTime.Format(time.RFC3339),
+			string(schedule.FoodType),
+			status,
+			strconv.Itoa(schedule.Version),
+		)
+	}
+	return t
+}
+
+func parseScheduleArgs(args []string, name string) (uuid.UUID, time.Time, error) {
+	animalID, err := parseUUID(args[0], "animal-id")
+	if err != nil {
+		return uuid.Nil, time.Time{}, err
+	}
+	feedingTime, err := time.Parse(time.RFC3339, args[1])
+	if err != nil {
+		return uuid.Nil, time.Time{}, fmt.Errorf("%s: ожидается время в формате RFC 3339, получено %q", name, args[1])
+	}
+	return animalID, feedingTime, nil
+}
+
+func addSchedule(client backend, out *printer, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("schedules add", flag.ContinueOnError), args, 3)
+	if err != nil {
+		return err
+	}
+	animalID, feedingTime, err := parseScheduleArgs(positional, "time")
+	if err != nil {
+		return err
+	}
+	if err := client.AddSchedule(animalID, feedingTime, model.FoodType(positional[2])); err != nil {
+		return err
+	}
+	return out.message("Кормление животного %s добавлено на %s", animalID, feedingTime.Format(time.RFC3339))
+}
+
+func removeSchedule(client backend, out *printer, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("schedules remove", flag.ContinueOnError), args, 2)
+	if err != nil {
+		return err
+	}
+	animalID, feedingTime, err := parseScheduleArgs(positional, "time")
+	if err != nil {
+		return err
+	}
+	if err := client.RemoveSchedule(animalID, feedingTime); err != nil {
+		return err
+	}
+	return out.message("Кормление животного %s на %s удалено", animalID, feedingTime.Format(time.RFC3339))
+}
+
+func markDone(client backend, out *printer, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("schedules done", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseUUID(positional[0], "schedule-id")
+	if err != nil {
+		return err
+	}
+	schedule, err := client.MarkDone(id)
+	if err != nil {
+		return err
+	}
+	return out.print(schedule, scheduleTable([]model.FeedingSchedule{*schedule}, time.Now()))
+}
+
+func stats(client backend, out *printer, args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	days := flags.Int("days", 7, fmt.Sprintf("days of transfer history, 1-%d", services.MaxTransferDays))
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	statistics, err := client.Statistics(*days)
+	if err != nil {
+		return err
+	}
+
+	summary := table{header: []string{"ANIMALS", "ENCLOSURES", "UPCOMING FEEDINGS", "MISSED FEEDINGS"}}
+	summary.add(
+		strconv.Itoa(statistics.AnimalCount),
+		strconv.Itoa(statistics.EnclosureCount),
+		strconv.Itoa(statistics.Feedings.Upcoming),
+		strconv.Itoa(statistics.Feedings.Missed),
+	)
+	species := table{header: []string{"SPECIES", "HEALTH", "COUNT"}}
+	for _, count := range statistics.AnimalsBySpecies {
+		species.add(count.Species, string(count.HealthStatus), strconv.Itoa(count.Count))
+	}
+	occupancy := table{header: []string{"ENCLOSURE", "TYPE", "ANIMALS", "CAPACITY", "OCCUPANCY"}}
+	for _, enclosure := range statistics.Occupancy {
+		occupancy.add(
+			enclosure.EnclosureID.String(),
+			string(enclosure.Type),
+			strconv.Itoa(enclosure.CurrentCount),
+			strconv.Itoa(enclosure.MaxCapacity),
+			fmt.Sprintf("%.0f%%", enclosure.Ratio*100),
+		)
+	}
+	transfers := table{header: []string{"DATE", "TRANSFERS"}}
+	for _, day := range statistics.TransfersPerDay {
+		transfers.add(day.Date, strconv.Itoa(day.Count))
+	}
+	return out.print(statistics, summary, species, occupancy, transfers)
+}
+
+func optionalID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return "-"
+	}
+	return id.String()
+}
+
+// loadFixture - файл описания зоопарка или demo для встроенного
+func loadFixture(client backend, out *printer, args []string) error {
+	positional, err := parseFlags(flag.NewFlagSet("fixtures load", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	data, err := fixtures.Read(positional[0])
+	if err != nil {
+		return err
+	}
+	report, err := client.LoadFixture(data)
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"KIND", "KEY", "ID"}}
+	for _, kind := range []struct {
+		name string
+		ids  map[string]uuid.UUID
+	}{{"enclosure", report.Enclosures}, {"animal", report.Animals}} {
+		keys := slices.Sorted(maps.Keys(kind.ids))
+		for _, key := range keys {
+			t.add(kind.name, key, kind.ids[key].String())
+		}
+	}
+	if err := out.print(report, t); err != nil {
+		return err
+	}
+	if out.format == formatTable {
+		return out.message("Загружено: видов %d, вольеров %d, животных %d, кормлений %d",
+			report.Species, len(report.Enclosures), len(report.Animals), report.Feedings)
+	}
+	return nil
+}
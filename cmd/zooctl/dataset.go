@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"kpo-mini-dz2/domain/model"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// dataset - выгрузка зоопарка для переноса между серверами и файлами данных
+type dataset struct {
+	Enclosures       []model.Enclosure       `json:"enclosures"`
+	Animals          []model.Animal          `json:"animals"`
+	FeedingSchedules []model.FeedingSchedule `json:"feedingSchedules"`
+}
+
+// exportData - формат всегда JSON, -o не учитывается. Выбывшие животные не выгружаются.
+func exportData(client backend, out *printer, args []string) error {
+	positional, err := parseFileArg("export", args)
+	if err != nil {
+		return err
+	}
+
+	var data dataset
+	if data.Enclosures, err = client.Enclosures(); err != nil {
+		return err
+	}
+	if data.Animals, err = client.Animals(""); err != nil {
+		return err
+	}
+	data.FeedingSchedules = make([]model.FeedingSchedule, 0)
+	for _, animal := range data.Animals {
+		schedules, err := client.Schedules(animal.ID)
+		if err != nil {
+			return err
+		}
+		data.FeedingSchedules = append(data.FeedingSchedules, schedules...)
+	}
+
+	w := out.w
+	if positional != "" {
+		file, err := os.Create(positional)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+	if positional != "" {
+		fmt.Fprintf(os.Stderr, "Выгружено: вольеров %d, животных %d, кормлений %d\n",
+			len(data.Enclosures), len(data.Animals), len(data.FeedingSchedules))
+	}
+	return nil
+}
+
+// importData - создаёт вольеры, затем животных и их кормления с новыми идентификаторами.
+// Ссылки на вольеры из выгрузки заменяются на созданные, остальные остаются как есть.
+// Выполненные и прошедшие кормления пропускаются. На первой ошибке импорт
+// останавливается; уже созданное не откатывается.
+func importData(client backend, out *printer, args []string) error {
+	positional, err := parseFileArg("import", args)
+	if err != nil {
+		return err
+	}
+	var r io.Reader = os.Stdin
+	if positional != "" {
+		file, err := os.Open(positional)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+	var data dataset
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return fmt.Errorf("некорректная выгрузка: %w", err)
+	}
+
+	enclosureIDs := make(map[uuid.UUID]uuid.UUID, len(data.Enclosures))
+	for _, enclosure := range data.Enclosures {
+		created, err := client.CreateEnclosure(enclosure.Name, enclosure.Type, enclosure.Size, enclosure.MaxCapacity)
+		if err != nil {
+			return fmt.Errorf("вольер %s: %w", enclosure.ID, err)
+		}
+		enclosureIDs[enclosure.ID] = created.ID
+	}
+
+	animalIDs := make(map[uuid.UUID]uuid.UUID, len(data.Animals))
+	for _, animal := range data.Animals {
+		if id, ok := enclosureIDs[animal.EnclosureID]; ok {
+			animal.EnclosureID = id
+		}
+		created, err := client.CreateAnimal(animal)
+		if err != nil {
+			return fmt.Errorf("животное %s: %w", animal.ID, err)
+		}
+		animalIDs[animal.ID] = created.ID
+	}
+
+	now := time.Now()
+	var schedules, skipped int
+	for _, schedule := range data.FeedingSchedules {
+		animalID, ok := animalIDs[schedule.AnimalID]
+		if !ok || schedule.DoneAt != nil || !schedule.FeedingTime.After(now) {
+			skipped++
+			continue
+		}
+		if err := client.AddSchedule(animalID, schedule.FeedingTime, schedule.FoodType); err != nil {
+			return fmt.Errorf("кормление %s: %w", schedule.ID, err)
+		}
+		schedules++
+	}
+
+	return out.message("Импортировано: вольеров %d, животных %d, кормлений %d (пропущено кормлений: %d)",
+		len(enclosureIDs), len(animalIDs), schedules, skipped)
+}
+
+// parseFileArg - необязательный путь к файлу; без него - stdin или stdout
+func parseFileArg(name string, args []string) (string, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return "", errUsage
+	}
+	if flags.NArg() > 1 {
+		return "", usageError("%s: ожидается не больше одного файла", name)
+	}
+	return flags.Arg(0), nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"kpo-mini-dz2/application/dataexchange"
+	"kpo-mini-dz2/application/fixtures"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/infrastructure/config"
+	"kpo-mini-dz2/infrastructure/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// localBackend - те же прикладные сервисы, что и у сервера, поверх файла данных.
+// Каждое изменение сразу записывается в файл.
+type localBackend struct {
+	ctx        context.Context
+	file       *repositories.FileSnapshotStore
+	store      *repositories.InMemoryStore
+	animals    *services.AnimalService
+	enclosures *services.EnclosureService
+	transfers  *services.AnimalTransferService
+	feeding    *services.FeedingService
+	statistics *services.ZooStatisticsService
+	bulk       *services.BulkService
+	fixtures   *services.FixtureService
+}
+
+func openLocal(path string) (*localBackend, error) {
+	// Журнал вебхуков zooctl не пополняет, но должен сохранить его при записи файла
+	store := repositories.NewInMemoryStore(config.Default().Webhooks.LogSize)
+	file := repositories.NewFileSnapshotStore(path, store)
+	if err := file.Load(); err != nil {
+		return nil, err
+	}
+	unitOfWork := repositories.NewInMemoryUnitOfWorkFactory(store)
+
+	// В журнале аудита изменения будут записаны от имени zooctl
+	ctx := requestcontext.WithActor(context.Background(), requestcontext.Actor{
+		ID:   uuid.New(),
+		Name: "zooctl",
+		Role: model.RoleAdmin,
+	})
+	return &localBackend{
+		ctx:        ctx,
+		file:       file,
+		store:      store,
+		animals:    services.NewAnimalService(unitOfWork),
+		enclosures: services.NewEnclosureService(unitOfWork),
+		transfers:  services.NewAnimalTransferService(unitOfWork),
+		feeding:    services.NewFeedingService(unitOfWork, store.FeedingSchedules),
+		statistics: services.NewZooStatisticsService(unitOfWork, store.Animals, store.Enclosures, store.FeedingSchedules, store.Movements),
+		bulk:       services.NewBulkService(unitOfWork, store.Animals, store.Enclosures),
+		fixtures:   services.NewFixtureService(unitOfWork, store.Animals, store.Enclosures),
+	}, nil
+}
+
+func (b *localBackend) Animals(state model.LifecycleState) ([]model.Animal, error) {
+	animals, err := b.store.Animals.FindAll()
+	if err != nil || state == "" {
+		return model.PresentAnimals(animals), err
+	}
+	matched := make([]model.Animal, 0)
+	for _, animal := range animals {
+		if animal.State == state {
+			matched = append(matched, animal)
+		}
+	}
+	return matched, nil
+}
+
+func (b *localBackend) CreateAnimal(data model.Animal) (*model.Animal, error) {
+	animal, err := b.animals.AddAnimal(b.ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return animal, b.file.Flush()
+}
+
+func (b *localBackend) DeleteAnimal(id uuid.UUID) error {
+	if err := b.animals.DeleteAnimal(b.ctx, id, model.AnyVersion); err != nil {
+		return err
+	}
+	return b.file.Flush()
+}
+
+func (b *localBackend) ChangeAnimalState(id uuid.UUID, change services.LifecycleChange) (*model.Animal, error) {
+	animal, err := b.animals.ChangeLifecycleState(b.ctx, id, change, model.AnyVersion)
+	if err != nil {
+		return nil, err
+	}
+	return animal, b.file.Flush()
+}
+
+func (b *localBackend) Enclosures() ([]model.Enclosure, error) {
+	return b.store.Enclosures.FindAll()
+}
+
+func (b *localBackend) CreateEnclosure(name string, enclosureType model.AnimalType, size model.Size, maxCapacity int) (*model.Enclosure, error) {
+	enclosure, err := b.enclosures.AddEnclosure(b.ctx, name, enclosureType, size, maxCapacity)
+	if err != nil {
+		return nil, err
+	}
+	return enclosure, b.file.Flush()
+}
+
+func (b *localBackend) DeleteEnclosure(id uuid.UUID) error {
+	if err := b.enclosures.DeleteEnclosure(b.ctx, id, model.AnyVersion); err != nil {
+		return err
+	}
+	return b.file.Flush()
+}
+
+func (b *localBackend) Transfer(animalID uuid.UUID, toEnclosureID uuid.UUID, reason string) error {
+	if err := b.transfers.TransferAnimal(b.ctx, animalID, toEnclosureID, reason); err != nil {
+		return err
+	}
+	return b.file.Flush()
+}
+
+func (b *localBackend) Schedules(animalID uuid.UUID) ([]model.FeedingSchedule, error) {
+	return b.feeding.GetAnimalSchedules(animalID)
+}
+
+func (b *localBackend) AddSchedule(animalID uuid.UUID, feedingTime time.Time, foodType model.FoodType) error {
+	if _, err := b.feeding.AddFeedingSchedule(b.ctx, animalID, feedingTime, foodType); err != nil {
+		return err
+	}
+	return b.file.Flush()
+}
+
+func (b *localBackend) RemoveSchedule(animalID uuid.UUID, feedingTime time.Time) error {
+	if err := b.feeding.RemoveFeedingSchedule(b.ctx, animalID, feedingTime); err != nil {
+		return err
+	}
+	return b.file.Flush()
+}
+
+func (b *localBackend) MarkDone(id uuid.UUID) (*model.FeedingSchedule, error) {
+	schedule, err := b.feeding.MarkFeedingDone(b.ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, b.file.Flush()
+}
+
+func (b *localBackend) Statistics(transferDays int) (*services.ZooStatistics, error) {
+	return b.statistics.GetStatistics(time.Now(), transferDays)
+}
+
+func (b *localBackend) ImportEnclosures(records []dataexchange.EnclosureRecord, mode services.ImportMode) (*services.ImportReport, error) {
+	return b.flushImport(b.bulk.ImportEnclosures(b.ctx, records, mode))
+}
+
+func (b *localBackend) ImportAnimals(records []dataexchange.AnimalRecord, mode services.ImportMode) (*services.ImportReport, error) {
+	return b.flushImport(b.bulk.ImportAnimals(b.ctx, records, mode))
+}
+
+func (b *localBackend) flushImport(report *services.ImportReport, err error) (*services.ImportReport, error) {
+	if err != nil {
+		return nil, err
+	}
+	if report.Imported > 0 {
+		return report, b.file.Flush()
+	}
+	return report, nil
+}
+
+func (b *localBackend) ExportEnclosures() ([]dataexchange.EnclosureRecord, error) {
+	return b.bulk.ExportEnclosures()
+}
+
+func (b *localBackend) ExportAnimals() ([]dataexchange.AnimalRecord, error) {
+	return b.bulk.ExportAnimals()
+}
+
+func (b *localBackend) LoadFixture(data []byte) (*services.FixtureReport, error) {
+	fixture, err := fixtures.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	report, err := b.fixtures.Load(b.ctx, fixture, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return report, b.file.Flush()
+}
@@ -0,0 +1,101 @@
+// zooctl - администрирование зоопарка из командной строки.
+//
+// По умолчанию команды идут в REST API запущенного сервера:
+//
+//	zooctl -server http://localhost:8080 -user admin -password secret animals list
+//	zooctl -token $TOKEN -o csv enclosures list
+//
+// С -storage команды работают прямо с файлом данных файлового хранилища.
+// Сервер при этом должен быть остановлен: он держит данные в памяти и
+// при следующем сохранении перезапишет файл.
+//
+//	zooctl -storage zoo-data.json stats
+//
+// Код выхода: 0 - успех, 1 - ошибка команды, 2 - неверные аргументы.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// errUsage - неверные аргументы; подсказку уже напечатал разбор флагов
+var errUsage = errors.New("usage")
+
+const usage = `usage: zooctl [flags] <command> [args]
+
+commands:
+  login                                          print a token for -user/-password
+  animals list [-state S]                        animals in the zoo, or those in lifecycle state S
+  animals create -name N -species S -type T -gender G -birth YYYY-MM-DD -food-type F -food N [-health H] [-enclosure ID]
+  animals delete <id>
+  animals state -state S -reason R [-date D] [-enclosure ID] <id>
+  animals import [-mode all-or-nothing|skip-invalid] [-format csv|json] <file>
+  animals export [-format csv|json] [file]       CSV to stdout by default
+  enclosures list
+  enclosures create [-name N] -type T -length L -width W -height H -capacity C
+  enclosures delete <id>
+  enclosures import [-mode all-or-nothing|skip-invalid] [-format csv|json] <file>
+  enclosures export [-format csv|json] [file]
+  transfer <animal-id> <enclosure-id> [reason]
+  schedules list <animal-id>
+  schedules add <animal-id> <time RFC 3339> <food-type>
+  schedules remove <animal-id> <time RFC 3339>
+  schedules done <schedule-id>
+  stats [-days N]
+  fixtures load <file|demo>                      load a YAML/JSON zoo description or the bundled demo zoo
+  export [file]                                  whole dataset as JSON, stdout by default
+  import [file]                                  dataset from export, stdin by default
+`
+
+func main() {
+	global := flag.NewFlagSet("zooctl", flag.ContinueOnError)
+	server := global.String("server", envOr("ZOOCTL_SERVER", "http://localhost:8080"), "REST API address (ZOOCTL_SERVER)")
+	token := global.String("token", os.Getenv("ZOOCTL_TOKEN"), "access token (ZOOCTL_TOKEN)")
+	user := global.String("user", os.Getenv("ZOOCTL_USER"), "username to log in with when there is no token (ZOOCTL_USER)")
+	password := global.String("password", os.Getenv("ZOOCTL_PASSWORD"), "password to log in with (ZOOCTL_PASSWORD)")
+	storage := global.String("storage", os.Getenv("ZOOCTL_STORAGE"), "data file of a stopped server; used instead of the REST API (ZOOCTL_STORAGE)")
+	format := global.String("o", string(formatTable), "output format: table, json or csv")
+	global.Usage = func() {
+		fmt.Fprint(global.Output(), usage+"\nflags:\n")
+		global.PrintDefaults()
+	}
+	if err := global.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	out, err := newPrinter(os.Stdout, outputFormat(*format))
+	if err != nil || global.NArg() == 0 {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Ошибка:", err)
+		}
+		global.Usage()
+		os.Exit(2)
+	}
+
+	var client backend
+	if *storage != "" {
+		client, err = openLocal(*storage)
+	} else {
+		client, err = openREST(strings.TrimRight(*server, "/"), *token, *user, *password)
+	}
+	if err == nil {
+		err = run(client, out, global.Args())
+	}
+	switch {
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "Ошибка:", err)
+		os.Exit(1)
+	}
+}
+
+func envOr(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+type outputFormat string
+
+const (
+	formatTable outputFormat = "table"
+	formatJSON  outputFormat = "json"
+	formatCSV   outputFormat = "csv"
+)
+
+// printer - вывод результата команды в выбранном формате
+type printer struct {
+	w      io.Writer
+	format outputFormat
+}
+
+func newPrinter(w io.Writer, format outputFormat) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return &printer{w: w, format: format}, nil
+	default:
+		return nil, fmt.Errorf("неизвестный формат вывода %q", format)
+	}
+}
+
+// table - строки для таблицы и CSV; в JSON выводится value целиком
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) add(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+// print - несколько таблиц разделяются пустой строкой
+func (p *printer) print(value any, tables ...table) error {
+	if p.format == formatJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+	for i, t := range tables {
+		if i > 0 {
+			fmt.Fprintln(p.w)
+		}
+		if err := p.printTable(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) printTable(t table) error {
+	if p.format == formatCSV {
+		writer := csv.NewWriter(p.w)
+		writer.Write(t.header)
+		writer.WriteAll(t.rows)
+		return writer.Error()
+	}
+	writer := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// message - результат команды без данных; в JSON - {"message": ...}
+func (p *printer) message(format string, args ...any) error {
+	text := fmt.Sprintf(format, args...)
+	if p.format == formatJSON {
+		return p.print(map[string]string{"message": text})
+	}
+	_, err := fmt.Fprintln(p.w, text)
+	return err
+}
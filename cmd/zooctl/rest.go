@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kpo-mini-dz2/application/dataexchange"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const requestTimeout = 30 * time.Second
+
+// restBackend - клиент REST API запущенного сервера
+type restBackend struct {
+	server string
+	token  string
+	client *http.Client
+}
+
+// openREST - без токена, но с именем пользователя сразу входит в систему
+func openREST(server string, token string, user string, password string) (*restBackend, error) {
+	b := &restBackend{server: server, token: token, client: &http.Client{Timeout: requestTimeout}}
+	if token == "" && user != "" {
+		result, err := b.Login(user, password)
+		if err != nil {
+			return nil, err
+		}
+		b.token = result.Token
+	}
+	return b, nil
+}
+
+func (b *restBackend) Login(username string, password string) (*services.LoginResult, error) {
+	var result services.LoginResult
+	err := b.do(http.MethodPost, "/api/auth/login", map[string]string{"username": username, "password": password}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (b *restBackend) Animals(state model.LifecycleState) ([]model.Animal, error) {
+	path := "/api/animals"
+	if state != "" {
+		path += "?state=" + url.QueryEscape(string(state))
+	}
+	var animals []model.Animal
+	err := b.do(http.MethodGet, path, nil, &animals)
+	return animals, err
+}
+
+func (b *restBackend) CreateAnimal(data model.Animal) (*model.Animal, error) {
+	var animal model.Animal
+	if err := b.do(http.MethodPost, "/api/animals", data, &animal); err != nil {
+		return nil, err
+	}
+	return &animal, nil
+}
+
+func (b *restBackend) DeleteAnimal(id uuid.UUID) error {
+	return b.do(http.MethodDelete, "/api/animals/"+id.String(), nil, nil)
+}
+
+func (b *restBackend) ChangeAnimalState(id uuid.UUID, change services.LifecycleChange) (*model.Animal, error) {
+	body := map[string]any{
+		"state":  change.State,
+		"date":   change.Date,
+		"reason": change.Reason,
+	}
+	if change.EnclosureID != uuid.Nil {
+		body["enclosureId"] = change.EnclosureID
+	}
+	var animal model.Animal
+	if err := b.do(http.MethodPost, "/api/animals/"+id.String()+"/state", body, &animal); err != nil {
+		return nil, err
+	}
+	return &animal, nil
+}
+
+func (b *restBackend) Enclosures() ([]model.Enclosure, error) {
+	var enclosures []model.Enclosure
+	err := b.do(http.MethodGet, "/api/enclosures", nil, &enclosures)
+	return enclosures, err
+}
+
+func (b *restBackend) CreateEnclosure(name string, enclosureType model.AnimalType, size model.Size, maxCapacity int) (*model.Enclosure, error) {
+	var enclosure model.Enclosure
+	err := b.do(http.MethodPost, "/api/enclosures", map[string]any{
+		"name":        name,
+		"type":        enclosureType,
+		"size":        size,
+		"maxCapacity": maxCapacity,
+	}, &enclosure)
+	if err != nil {
+		return nil, err
+	}
+	return &enclosure, nil
+}
+
+func (b *restBackend) DeleteEnclosure(id uuid.UUID) error {
+	return b.do(http.MethodDelete, "/api/enclosures/"+id.String(), nil, nil)
+}
+
+func (b *restBackend) Transfer(animalID uuid.UUID, toEnclosureID uuid.UUID, reason string) error {
+	return b.do(http.MethodPost, "/api/transfers", map[string]any{
+		"animalId":      animalID,
+		"toEnclosureId": toEnclosureID,
+		"reason":        reason,
+	}, nil)
+}
+
+func (b *restBackend) Schedules(animalID uuid.UUID) ([]model.FeedingSchedule, error) {
+	var schedules []model.FeedingSchedule
+	err := b.do(http.MethodGet, "/api/schedules/"+animalID.String(), nil, &schedules)
+	return schedules, err
+}
+
+func (b *restBackend) AddSchedule(animalID uuid.UUID, feedingTime time.Time, foodType model.FoodType) error {
+	return b.do(http.MethodPost, "/api/schedules", map[string]any{
+		"animalId":    animalID,
+		"feedingTime": feedingTime,
+		"foodType":    foodType,
+	}, nil)
+}
+
+func (b *restBackend) RemoveSchedule(animalID uuid.UUID, feedingTime time.Time) error {
+	return b.do(http.MethodDelete, "/api/schedules", map[string]any{
+		"animalId":    animalID,
+		"feedingTime": feedingTime,
+	}, nil)
+}
+
+func (b *restBackend) MarkDone(id uuid.UUID) (*model.FeedingSchedule, error) {
+	var schedule model.FeedingSchedule
+	if err := b.do(http.MethodPost, "/api/schedules/"+id.String()+"/done", nil, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (b *restBackend) Statistics(transferDays int) (*services.ZooStatistics, error) {
+	var statistics services.ZooStatistics
+	if err := b.do(http.MethodGet, "/api/statistics?days="+strconv.Itoa(transferDays), nil, &statistics); err != nil {
+		return nil, err
+	}
+	return &statistics, nil
+}
+
+func (b *restBackend) ImportEnclosures(records []dataexchange.EnclosureRecord, mode services.ImportMode) (*services.ImportReport, error) {
+	return b.importRecords("/api/enclosures/import?mode="+url.QueryEscape(string(mode)), records)
+}
+
+func (b *restBackend) ImportAnimals(records []dataexchange.AnimalRecord, mode services.ImportMode) (*services.ImportReport, error) {
+	return b.importRecords("/api/animals/import?mode="+url.QueryEscape(string(mode)), records)
+}
+
+// importRecords - записи уже разобраны из файла, на сервер уходят как JSON.
+// При 409 сервер тоже присылает отчёт - со строками, из-за которых ничего не загружено.
+func (b *restBackend) importRecords(path string, records any) (*services.ImportReport, error) {
+	var report services.ImportReport
+	if err := b.do(http.MethodPost, path, records, &report, http.StatusConflict); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (b *restBackend) ExportEnclosures() ([]dataexchange.EnclosureRecord, error) {
+	var records []dataexchange.EnclosureRecord
+	err := b.do(http.MethodGet, "/api/enclosures/export", nil, &records)
+	return records, err
+}
+
+func (b *restBackend) ExportAnimals() ([]dataexchange.AnimalRecord, error) {
+	var records []dataexchange.AnimalRecord
+	err := b.do(http.MethodGet, "/api/animals/export", nil, &records)
+	return records, err
+}
+
+// LoadFixture - файл уходит как есть: сервер сам разбирает YAML и JSON
+func (b *restBackend) LoadFixture(data []byte) (*services.FixtureReport, error) {
+	var report services.FixtureReport
+	if err := b.send(http.MethodPost, "/api/fixtures", "application/yaml", bytes.NewReader(data), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// do - отправляет body как JSON и разбирает ответ в result, если он нужен.
+// accepted - коды ошибок, ответ с которыми тоже разбирается в result.
+func (b *restBackend) do(method string, path string, body any, result any, accepted ...int) error {
+	if body == nil {
+		return b.send(method, path, "", nil, result, accepted...)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return b.send(method, path, "application/json", bytes.NewReader(data), result, accepted...)
+}
+
+func (b *restBackend) send(method string, path string, contentType string, payload io.Reader, result any, accepted ...int) error {
+	req, err := http.NewRequest(method, b.server+path, payload)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest && !slices.Contains(accepted, resp.StatusCode) {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(message)))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%s %s: некорректный ответ: %w", method, path, err)
+	}
+	return nil
+}
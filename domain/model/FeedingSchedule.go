@@ -8,9 +8,13 @@ import (
 )
 
 type FeedingSchedule struct {
-	AnimalID    uuid.UUID `json:"animalID"`
-	FeedingTime time.Time `json:"feedingTime"`
-	FoodType    FoodType  `json:"foodType"`
+	ID          uuid.UUID  `json:"ID"`
+	AnimalID    uuid.UUID  `json:"animalID"`
+	FeedingTime time.Time  `json:"feedingTime"`
+	FoodType    FoodType   `json:"foodType"`
+	DoneAt      *time.Time `json:"doneAt,omitempty"`
+	Deletion
+	Version int `json:"version"`
 }
 
 func NewFeedingSchedule(
@@ -27,6 +31,7 @@ func NewFeedingSchedule(
 	}
 
 	schedule := &FeedingSchedule{
+		ID:          uuid.New(),
 		AnimalID:    animalID,
 		FeedingTime: feedingTime,
 		FoodType:    foodType,
@@ -35,7 +40,7 @@ func NewFeedingSchedule(
 	return schedule, nil
 }
 
-func (f FeedingSchedule) ChangeSchedule(newTime time.Time) error {
+func (f *FeedingSchedule) ChangeSchedule(newTime time.Time) error {
 	if newTime.Before(time.Now()) {
 		return errors.New("время кормления не может быть в прошлом")
 	}
@@ -43,6 +48,18 @@ func (f FeedingSchedule) ChangeSchedule(newTime time.Time) error {
 	f.FeedingTime = newTime
 	return nil
 }
-func (f FeedingSchedule) PingExecution() {
 
+// PingExecution - отмечает кормление выполненным; повторно отметить нельзя
+func (f *FeedingSchedule) PingExecution() error {
+	if f.DoneAt != nil {
+		return ErrFeedingAlreadyDone
+	}
+	now := time.Now()
+	f.DoneAt = &now
+	return nil
+}
+
+// IsMissed - время кормления прошло, а выполнение не отмечено
+func (f FeedingSchedule) IsMissed(now time.Time) bool {
+	return f.DoneAt == nil && f.FeedingTime.Before(now)
 }
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferStatus string
+
+const (
+	TransferRequested TransferStatus = "requested"
+	TransferApproved  TransferStatus = "approved"
+	TransferRejected  TransferStatus = "rejected"
+	TransferScheduled TransferStatus = "scheduled"
+	TransferExecuted  TransferStatus = "executed"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// TransferRequest - заявка на перемещение животного, требующая подписи куратора
+type TransferRequest struct {
+	ID              uuid.UUID      `json:"ID"`
+	AnimalID        uuid.UUID      `json:"animalID"`
+	FromEnclosureID uuid.UUID      `json:"fromEnclosureID"`
+	ToEnclosureID   uuid.UUID      `json:"toEnclosureID"`
+	PlannedDate     time.Time      `json:"plannedDate"`
+	Reason          string         `json:"reason"`
+	Requester       string         `json:"requester"`
+	Approver        string         `json:"approver"`
+	Comment         string         `json:"comment"`
+	CancelledBy     string         `json:"cancelledBy,omitempty"`
+	Status          TransferStatus `json:"status"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	Version         int            `json:"version"`
+}
+
+func NewTransferRequest(
+	animalID uuid.UUID,
+	fromEnclosureID uuid.UUID,
+	toEnclosureID uuid.UUID,
+	plannedDate time.Time,
+	reason string,
+	requester string,
+) (*TransferRequest, error) {
+	if animalID == uuid.Nil || toEnclosureID == uuid.Nil {
+		return nil, errors.New("животное и вольер назначения обязательны")
+	}
+	if requester == "" {
+		return nil, errors.New("не указан автор заявки")
+	}
+	if plannedDate.IsZero() {
+		return nil, errors.New("не указана планируемая дата перемещения")
+	}
+
+	now := time.Now()
+	return &TransferRequest{
+		ID:              uuid.New(),
+		AnimalID:        animalID,
+		FromEnclosureID: fromEnclosureID,
+		ToEnclosureID:   toEnclosureID,
+		PlannedDate:     plannedDate,
+		Reason:          reason,
+		Requester:       requester,
+		Status:          TransferRequested,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// IsPending - заявка ещё не исполнена и не закрыта
+func (t *TransferRequest) IsPending() bool {
+	return t.Status == TransferRequested || t.Status == TransferApproved || t.Status == TransferScheduled
+}
+
+func (t *TransferRequest) Approve(approver string, role Role) error {
+	if err := t.checkApprover(approver, role); err != nil {
+		return err
+	}
+	if t.Status != TransferRequested {
+		return ErrInvalidTransition
+	}
+	t.Approver = approver
+	t.setStatus(TransferApproved)
+	return nil
+}
+
+func (t *TransferRequest) Reject(approver string, role Role, comment string) error {
+	if err := t.checkApprover(approver, role); err != nil {
+		return err
+	}
+	if t.Status != TransferRequested {
+		return ErrInvalidTransition
+	}
+	t.Approver = approver
+	t.Comment = comment
+	t.setStatus(TransferRejected)
+	return nil
+}
+
+// Schedule - назначает дату перемещения одобренной заявке
+func (t *TransferRequest) Schedule(plannedDate time.Time) error {
+	if t.Status != TransferApproved && t.Status != TransferScheduled {
+		return ErrInvalidTransition
+	}
+	if plannedDate.IsZero() {
+		return errors.New("не указана дата перемещения")
+	}
+	t.PlannedDate = plannedDate
+	t.setStatus(TransferScheduled)
+	return nil
+}
+
+// MarkExecuted - само перемещение выполняет сервис перемещений
+func (t *TransferRequest) MarkExecuted() error {
+	if t.Status != TransferApproved && t.Status != TransferScheduled {
+		return ErrInvalidTransition
+	}
+	t.setStatus(TransferExecuted)
+	return nil
+}
+
+// Cancel - отменить можно и одобренную, и назначенную заявку;
+// reason, если указана, записывается в комментарий
+func (t *TransferRequest) Cancel(actor string, reason string) error {
+	if !t.IsPending() {
+		return ErrInvalidTransition
+	}
+	t.CancelledBy = actor
+	if reason != "" {
+		t.Comment = reason
+	}
+	t.setStatus(TransferCancelled)
+	return nil
+}
+
+func (t *TransferRequest) checkApprover(approver string, role Role) error {
+	if !role.CanApproveTransfers() {
+		return ErrForbidden
+	}
+	if approver == "" || approver == t.Requester {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (t *TransferRequest) setStatus(status TransferStatus) {
+	t.Status = status
+	t.UpdatedAt = time.Now()
+}
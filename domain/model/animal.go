@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,17 @@ type Animal struct {
 	HealthStatus HealthStatus `json:"healthStatus"`
 	Gender       Gender       `json:"gender"`
 	FavoriteFood Food         `json:"favoriteFood"`
+	// ArrivalDate - когда животное поступило в зоопарк; задаётся при создании
+	// и совпадает с датой первого перехода в StateHistory
+	ArrivalDate time.Time `json:"arrivalDate,omitzero"`
+	// State меняется только через ChangeState, каждый переход остаётся в StateHistory
+	State        LifecycleState        `json:"state"`
+	StateHistory []LifecycleTransition `json:"stateHistory,omitempty"`
+	// Sire и Dam - отец и мать, nil - неизвестны; меняются через SetParents
+	Sire *Parent `json:"sire,omitempty"`
+	Dam  *Parent `json:"dam,omitempty"`
+	Deletion
+	Version int `json:"version"`
 }
 
 func NewAnimal(
@@ -27,13 +39,9 @@ func NewAnimal(
 	gender Gender,
 	favoriteFood Food,
 ) (*Animal, error) {
-	if name == "" {
-		return nil, errors.New("имя не может быть пустым")
+	if healthStatus == "" {
+		healthStatus = Healthy
 	}
-	if birthDate.After(time.Now()) {
-		return nil, errors.New("дата рождения не может быть из будущего")
-	}
-
 	animal := &Animal{
 		ID:           uuid.New(),
 		Name:         name,
@@ -44,16 +52,50 @@ func NewAnimal(
 		Gender:       gender,
 		FavoriteFood: favoriteFood,
 	}
+	if err := animal.Validate(); err != nil {
+		return nil, err
+	}
 	return animal, nil
 }
 
+// Validate - проверка описания животного при создании и при изменении
+func (a Animal) Validate() error {
+	if a.Name == "" {
+		return errors.New("имя не может быть пустым")
+	}
+	if a.BirthDate.After(time.Now()) {
+		return errors.New("дата рождения не может быть из будущего")
+	}
+	if a.Species.Name == "" {
+		return errors.New("вид не может быть пустым")
+	}
+	if !a.Species.AnimalType.IsValid() {
+		return fmt.Errorf("неизвестный тип животного %q", a.Species.AnimalType)
+	}
+	if !a.HealthStatus.IsValid() {
+		return fmt.Errorf("неизвестное состояние здоровья %q", a.HealthStatus)
+	}
+	if !a.Gender.IsValid() {
+		return fmt.Errorf("неизвестный пол %q", a.Gender)
+	}
+	if !a.FavoriteFood.FoodType.IsValid() {
+		return fmt.Errorf("неизвестный тип корма %q", a.FavoriteFood.FoodType)
+	}
+	return nil
+}
+
 func (a Animal) Feed() {
 
 }
 func (a *Animal) Heal() {
 	a.HealthStatus = Healthy
 }
-func (a Animal) Replace(e *Enclosure) {
+
+// FallIll - вызывается при открытии медицинского случая
+func (a *Animal) FallIll() {
+	a.HealthStatus = Sick
+}
+func (a *Animal) Replace(e *Enclosure) {
 	a.EnclosureID = e.ID
 }
 
@@ -64,6 +106,10 @@ const (
 	Female Gender = "female"
 )
 
+func (g Gender) IsValid() bool {
+	return g == Male || g == Female
+}
+
 type FoodType string
 
 const (
@@ -74,6 +120,14 @@ const (
 	Vegetable = "vegetable"
 )
 
+func (f FoodType) IsValid() bool {
+	switch f {
+	case Meat, Grass, Fish, Fruit, Vegetable:
+		return true
+	}
+	return false
+}
+
 type HealthStatus string
 
 const (
@@ -81,6 +135,10 @@ const (
 	Sick    = "sick"
 )
 
+func (h HealthStatus) IsValid() bool {
+	return h == Healthy || h == Sick
+}
+
 type AnimalType string
 
 const (
@@ -90,3 +148,11 @@ const (
 	Aquatic   = "aquatic"
 	Avian     = "avian"
 )
+
+func (t AnimalType) IsValid() bool {
+	switch t {
+	case Predator, Herbivore, Omnivore, Aquatic, Avian:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+	// AuditArchive и AuditRestore - мягкое удаление и восстановление, см. Deletion;
+	// AuditDelete у таких сущностей - окончательная очистка
+	AuditArchive AuditAction = "archive"
+	AuditRestore AuditAction = "restore"
+)
+
+// Названия сущностей в журнале аудита
+const (
+	EntityAnimal          = "animal"
+	EntityEnclosure       = "enclosure"
+	EntityFeedingSchedule = "feedingSchedule"
+	EntityMovement        = "movement"
+	EntityTransferRequest = "transferRequest"
+	EntityUser            = "user"
+	EntityWebhook         = "webhook"
+	EntityMedicalCase     = "medicalCase"
+	EntityMedicalEntry    = "medicalEntry"
+	EntityCarePlan        = "carePlan"
+)
+
+// AuditChange - значение поля до и после изменения; у созданной
+// сущности нет Before, у удалённой - After
+type AuditChange struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// AuditEntry - запись журнала: кто, когда и что изменил.
+// Seq, PrevHash и Hash связывают записи в цепочку, см. auditchain.go
+type AuditEntry struct {
+	Seq       int64                  `json:"seq"`
+	ID        uuid.UUID              `json:"id"`
+	At        time.Time              `json:"at"`
+	Actor     string                 `json:"actor,omitempty"`
+	RequestID string                 `json:"requestId,omitempty"`
+	Entity    string                 `json:"entity"`
+	EntityID  uuid.UUID              `json:"entityId"`
+	Action    AuditAction            `json:"action"`
+	Changes   map[string]AuditChange `json:"changes"`
+	PrevHash  string                 `json:"prevHash"`
+	Hash      string                 `json:"hash"`
+}
+
+// EntityChange - зафиксированное изменение сущности в виде самих значений
+// модели: у созданной сущности Before == nil, у удалённой After == nil.
+// По таким изменениям строятся события для подписчиков, см. events.go
+type EntityChange struct {
+	Entity   string
+	EntityID uuid.UUID
+	Action   AuditAction
+	Before   any
+	After    any
+}
+
+// NewAuditEntry - сравнивает состояния сущности по полям JSON.
+// before == nil означает создание, after == nil - удаление,
+// появление или исчезновение deletedAt - мягкое удаление или восстановление.
+// Если ни одно поле не изменилось, возвращает nil.
+func NewAuditEntry(entity string, entityID uuid.UUID, before any, after any) (*AuditEntry, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]AuditChange)
+	for name, value := range beforeFields {
+		if other, ok := afterFields[name]; !ok || !bytes.Equal(value, other) {
+			changes[name] = AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = AuditChange{After: value}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	action := AuditUpdate
+	switch {
+	case beforeFields == nil:
+		action = AuditCreate
+	case afterFields == nil:
+		action = AuditDelete
+	case changes["deletedAt"].Before == nil && changes["deletedAt"].After != nil:
+		action = AuditArchive
+	case changes["deletedAt"].Before != nil && changes["deletedAt"].After == nil:
+		action = AuditRestore
+	}
+
+	return &AuditEntry{
+		ID:       uuid.New(),
+		Entity:   entity,
+		EntityID: entityID,
+		Action:   action,
+		Changes:  changes,
+	}, nil
+}
+
+func jsonFields(value any) (map[string]json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// AuditFilter - условия выборки журнала; пустые поля не ограничивают выборку
+type AuditFilter struct {
+	Entity    string
+	EntityID  uuid.UUID
+	Actor     string
+	Action    AuditAction
+	RequestID string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+func (f AuditFilter) Matches(entry AuditEntry) bool {
+	switch {
+	case f.Entity != "" && entry.Entity != f.Entity,
+		f.EntityID != uuid.Nil && entry.EntityID != f.EntityID,
+		f.Actor != "" && entry.Actor != f.Actor,
+		f.Action != "" && entry.Action != f.Action,
+		f.RequestID != "" && entry.RequestID != f.RequestID,
+		!f.From.IsZero() && entry.At.Before(f.From),
+		!f.To.IsZero() && !entry.At.Before(f.To):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ComputeHash - SHA-256 от канонического JSON записи без поля Hash.
+// В хеш входит PrevHash, поэтому изменение любой записи ломает все последующие.
+func (e AuditEntry) ComputeHash() (string, error) {
+	e.Hash = ""
+	data, err := canonicalJSON(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON - JSON с отсортированными ключами и единым экранированием строк.
+// Значения полей в Changes хранятся как есть (json.RawMessage), поэтому без
+// перекодирования выгрузка, пересохранённая другим инструментом, не прошла бы проверку.
+func canonicalJSON(value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// Link - присоединяет запись к цепочке после prev; prev == nil для первой записи
+func (e *AuditEntry) Link(prev *AuditEntry) error {
+	e.Seq = 1
+	e.PrevHash = ""
+	if prev != nil {
+		e.Seq = prev.Seq + 1
+		e.PrevHash = prev.Hash
+	}
+
+	hash, err := e.ComputeHash()
+	if err != nil {
+		return err
+	}
+	e.Hash = hash
+	return nil
+}
+
+// AuditVerification - итог проверки цепочки; при разрыве BrokenAt - номер первой
+// записи, которой нельзя доверять
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Entries  int    `json:"entries"`
+	HeadHash string `json:"headHash,omitempty"`
+	BrokenAt int64  `json:"brokenAt,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// VerifyAuditChain - проверяет, что журнал начинается с первой записи,
+// номера идут подряд, каждая запись ссылается на хеш предыдущей
+// и хеш каждой записи совпадает с её содержимым
+func VerifyAuditChain(entries []AuditEntry) AuditVerification {
+	result := AuditVerification{Valid: true, Entries: len(entries)}
+	broken := func(seq int64, reason string) AuditVerification {
+		result.Valid = false
+		result.BrokenAt = seq
+		result.Reason = reason
+		result.HeadHash = ""
+		return result
+	}
+
+	var prev *AuditEntry
+	for i := range entries {
+		entry := entries[i]
+		expectedSeq, expectedPrev := int64(1), ""
+		if prev != nil {
+			expectedSeq, expectedPrev = prev.Seq+1, prev.Hash
+		}
+
+		if entry.Seq != expectedSeq {
+			return broken(expectedSeq, fmt.Sprintf("ожидалась запись %d, найдена %d", expectedSeq, entry.Seq))
+		}
+		if entry.PrevHash != expectedPrev {
+			return broken(entry.Seq, "ссылка на предыдущую запись не совпадает")
+		}
+		hash, err := entry.ComputeHash()
+		if err != nil {
+			return broken(entry.Seq, err.Error())
+		}
+		if hash != entry.Hash {
+			return broken(entry.Seq, "содержимое записи изменено")
+		}
+		prev = &entries[i]
+	}
+
+	if prev != nil {
+		result.HeadHash = prev.Hash
+	}
+	return result
+}
+
+// AuditBundle - выгрузка журнала для проверяющих, подписанная ключом Ed25519 зоопарка.
+// Подпись покрывает хеш последней записи, а через цепочку - все записи.
+type AuditBundle struct {
+	ExportedAt time.Time    `json:"exportedAt"`
+	Entries    []AuditEntry `json:"entries"`
+	HeadHash   string       `json:"headHash"`
+	PublicKey  string       `json:"publicKey"`
+	Signature  string       `json:"signature"`
+}
+
+const auditBundleAlgorithm = "zoo-audit-export/ed25519/v1"
+
+// SignedPayload - байты, которые подписываются
+func (b AuditBundle) SignedPayload() []byte {
+	return fmt.Appendf(nil, "%s\n%s\n%d\n%s",
+		auditBundleAlgorithm, b.ExportedAt.UTC().Format(time.RFC3339Nano), len(b.Entries), b.HeadHash)
+}
+
+// NewAuditBundle - проверяет цепочку и подписывает выгрузку
+func NewAuditBundle(entries []AuditEntry, key ed25519.PrivateKey, now time.Time) (*AuditBundle, error) {
+	verification := VerifyAuditChain(entries)
+	if !verification.Valid {
+		return nil, fmt.Errorf("%w: запись %d: %s", ErrAuditChainBroken, verification.BrokenAt, verification.Reason)
+	}
+
+	bundle := &AuditBundle{
+		ExportedAt: now,
+		Entries:    entries,
+		HeadHash:   verification.HeadHash,
+		PublicKey:  base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+	bundle.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, bundle.SignedPayload()))
+	return bundle, nil
+}
+
+// Verify - проверяет цепочку и подпись выгрузки.
+// trusted - известный заранее открытый ключ зоопарка; если nil, подпись проверяется
+// ключом из самой выгрузки, что доказывает только целостность, но не происхождение.
+func (b AuditBundle) Verify(trusted ed25519.PublicKey) (AuditVerification, error) {
+	verification := VerifyAuditChain(b.Entries)
+	if !verification.Valid {
+		return verification, nil
+	}
+	if verification.HeadHash != b.HeadHash {
+		verification.Valid = false
+		verification.HeadHash = ""
+		verification.Reason = "headHash не совпадает с последней записью"
+		return verification, nil
+	}
+
+	embedded, err := base64.StdEncoding.DecodeString(b.PublicKey)
+	if err != nil || len(embedded) != ed25519.PublicKeySize {
+		return verification, errors.New("некорректный открытый ключ в выгрузке")
+	}
+	key := ed25519.PublicKey(embedded)
+	if trusted != nil {
+		if !trusted.Equal(key) {
+			verification.Valid = false
+			verification.HeadHash = ""
+			verification.Reason = "выгрузка подписана другим ключом"
+			return verification, nil
+		}
+		key = trusted
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(b.Signature)
+	if err != nil || !ed25519.Verify(key, b.SignedPayload(), signature) {
+		verification.Valid = false
+		verification.HeadHash = ""
+		verification.Reason = "подпись не совпадает"
+	}
+	return verification, nil
+}
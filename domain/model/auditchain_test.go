@@ -0,0 +1,211 @@
+package model
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// auditChain - count связанных записей об изменении имени животного
+func auditChain(t *testing.T, count int) []AuditEntry {
+	t.Helper()
+	entries := make([]AuditEntry, 0, count)
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := range count {
+		entry := AuditEntry{
+			ID:       uuid.New(),
+			At:       at.Add(time.Duration(i) * time.Minute),
+			Actor:    "keeper1",
+			Entity:   EntityAnimal,
+			EntityID: uuid.New(),
+			Action:   AuditUpdate,
+			Changes: map[string]AuditChange{
+				"name": {Before: json.RawMessage(`"Grey"`), After: json.RawMessage(`"Akela"`)},
+			},
+		}
+		var prev *AuditEntry
+		if i > 0 {
+			prev = &entries[i-1]
+		}
+		if err := entry.Link(prev); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func auditKey(seed byte) ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+}
+
+func TestAuditBundleVerify(t *testing.T) {
+	key := auditKey(1)
+	trusted := key.Public().(ed25519.PublicKey)
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	bundle, err := NewAuditBundle(auditChain(t, 4), key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// resign - выгрузка, заново подписанная key, как сделал бы тот, у кого есть ключ
+	resign := func(b AuditBundle, key ed25519.PrivateKey) AuditBundle {
+		b.PublicKey = base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+		b.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, b.SignedPayload()))
+		return b
+	}
+
+	tests := []struct {
+		name     string
+		tamper   func(b AuditBundle) AuditBundle
+		trusted  ed25519.PublicKey
+		brokenAt int64
+		reason   string
+	}{
+		{name: "valid", tamper: func(b AuditBundle) AuditBundle { return b }, trusted: trusted},
+		{name: "valid without a trusted key", tamper: func(b AuditBundle) AuditBundle { return b }},
+		{
+			name: "tampered middle entry",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries[1].Actor = "mallory"
+				return b
+			},
+			trusted:  trusted,
+			brokenAt: 2,
+			reason:   "содержимое записи изменено",
+		},
+		{
+			name: "tampered middle entry with a recomputed hash",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries[1].Changes = map[string]AuditChange{"name": {After: json.RawMessage(`"Raksha"`)}}
+				b.Entries[1].Hash, _ = b.Entries[1].ComputeHash()
+				return b
+			},
+			trusted:  trusted,
+			brokenAt: 3,
+			reason:   "ссылка на предыдущую запись",
+		},
+		{
+			name: "reordered entries",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries[1], b.Entries[2] = b.Entries[2], b.Entries[1]
+				return b
+			},
+			trusted:  trusted,
+			brokenAt: 2,
+			reason:   "ожидалась запись 2",
+		},
+		{
+			name: "reordered and renumbered entries",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries[1], b.Entries[2] = b.Entries[2], b.Entries[1]
+				b.Entries[1].Seq, b.Entries[2].Seq = 2, 3
+				return b
+			},
+			trusted:  trusted,
+			brokenAt: 2,
+			reason:   "ссылка на предыдущую запись",
+		},
+		{
+			name: "truncated head",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries = b.Entries[1:]
+				return b
+			},
+			trusted:  trusted,
+			brokenAt: 1,
+			reason:   "ожидалась запись 1",
+		},
+		{
+			name: "truncated tail",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries = b.Entries[:2]
+				return b
+			},
+			trusted: trusted,
+			reason:  "headHash не совпадает",
+		},
+		{
+			name: "truncated tail with a matching headHash",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries = b.Entries[:2]
+				b.HeadHash = b.Entries[1].Hash
+				return b
+			},
+			trusted: trusted,
+			reason:  "подпись не совпадает",
+		},
+		{
+			name: "bad signature",
+			tamper: func(b AuditBundle) AuditBundle {
+				signature, _ := base64.StdEncoding.DecodeString(b.Signature)
+				signature[0] ^= 0xff
+				b.Signature = base64.StdEncoding.EncodeToString(signature)
+				return b
+			},
+			trusted: trusted,
+			reason:  "подпись не совпадает",
+		},
+		{
+			name: "bad signature without a trusted key",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte("other payload")))
+				return b
+			},
+			reason: "подпись не совпадает",
+		},
+		{
+			name: "truncated and re-signed with another key",
+			tamper: func(b AuditBundle) AuditBundle {
+				b.Entries = b.Entries[:2]
+				b.HeadHash = b.Entries[1].Hash
+				return resign(b, auditKey(2))
+			},
+			trusted: trusted,
+			reason:  "подписана другим ключом",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copied := *bundle
+			copied.Entries = slices.Clone(bundle.Entries)
+			verification, err := tt.tamper(copied).Verify(tt.trusted)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if tt.reason == "" {
+				if !verification.Valid || verification.HeadHash != bundle.HeadHash {
+					t.Fatalf("Verify() = %+v, want valid with head %s", verification, bundle.HeadHash)
+				}
+				return
+			}
+			if verification.Valid || verification.HeadHash != "" {
+				t.Fatalf("Verify() = %+v, want rejected", verification)
+			}
+			if verification.BrokenAt != tt.brokenAt || !strings.Contains(verification.Reason, tt.reason) {
+				t.Fatalf("Verify() broken at %d: %q, want %d: %q", verification.BrokenAt, verification.Reason, tt.brokenAt, tt.reason)
+			}
+		})
+	}
+
+	malformed := *bundle
+	malformed.PublicKey = "not a key"
+	if _, err := malformed.Verify(nil); err == nil {
+		t.Fatal("Verify() with a malformed public key succeeded")
+	}
+}
+
+func TestNewAuditBundleRejectsBrokenChain(t *testing.T) {
+	entries := auditChain(t, 3)
+	entries[1].Actor = "mallory"
+	if _, err := NewAuditBundle(entries, auditKey(1), time.Now()); !errors.Is(err, ErrAuditChainBroken) {
+		t.Fatalf("NewAuditBundle() error = %v, want %v", err, ErrAuditChainBroken)
+	}
+}
@@ -0,0 +1,315 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CloseKinship - с этого коэффициента родства (полусибсы, дед и внучка,
+// дядя и племянница и ближе) пара не предлагается
+const CloseKinship = 0.125
+
+// Веса факторов в оценке пары
+const (
+	geneticsWeight  = 0.6
+	ageWeight       = 0.25
+	proximityWeight = 0.15
+)
+
+// AgeRange - возраст размножения, полных лет
+type AgeRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (r AgeRange) Validate() error {
+	if r.Min < 0 || r.Max < r.Min {
+		return errors.New("возраст размножения: 0 <= min <= max")
+	}
+	return nil
+}
+
+// breedingAges - возраст размножения по умолчанию по типу животного;
+// своих данных у видов нет, поэтому для вида его можно задать в запросе
+var breedingAges = map[AnimalType]AgeRange{
+	Predator:  {Min: 2, Max: 12},
+	Herbivore: {Min: 3, Max: 20},
+	Omnivore:  {Min: 4, Max: 25},
+	Aquatic:   {Min: 3, Max: 15},
+	Avian:     {Min: 2, Max: 20},
+}
+
+type BreedingFactor string
+
+const (
+	FactorGenetics  BreedingFactor = "genetics"
+	FactorAge       BreedingFactor = "age"
+	FactorProximity BreedingFactor = "proximity"
+)
+
+// BreedingScore - вклад одного фактора: Score от 0 до 1, Reason - почему столько
+type BreedingScore struct {
+	Factor BreedingFactor `json:"factor"`
+	Weight float64        `json:"weight"`
+	Score  float64        `json:"score"`
+	Reason string         `json:"reason"`
+}
+
+// BreedingCandidate - самец или самка в предлагаемой паре; Age - nil, если дата рождения неизвестна
+type BreedingCandidate struct {
+	AnimalID    uuid.UUID `json:"animalId"`
+	Name        string    `json:"name"`
+	Age         *int      `json:"age,omitempty"`
+	EnclosureID uuid.UUID `json:"enclosureId"`
+}
+
+// BreedingPair - предлагаемая пара. Score - взвешенная сумма Factors,
+// OffspringInbreeding - инбридинг потомка, то есть родство пары.
+type BreedingPair struct {
+	Sire                BreedingCandidate `json:"sire"`
+	Dam                 BreedingCandidate `json:"dam"`
+	Score               float64           `json:"score"`
+	OffspringInbreeding float64           `json:"offspringInbreeding"`
+	Factors             []BreedingScore   `json:"factors"`
+}
+
+// BreedingExclusion - животное или пара, которые не предлагаются, и почему
+type BreedingExclusion struct {
+	AnimalIDs []uuid.UUID `json:"animalIds"`
+	Names     []string    `json:"names"`
+	Reason    string      `json:"reason"`
+}
+
+// BreedingRecommendations - пары вида от лучшей к худшей и всё, что отсеяно
+type BreedingRecommendations struct {
+	Species  string              `json:"species"`
+	Ages     AgeRange            `json:"ages"`
+	Pairs    []BreedingPair      `json:"pairs"`
+	Excluded []BreedingExclusion `json:"excluded"`
+}
+
+// RecommendPairs - пары самец-самка внутри каждого вида population.
+// Больные животные, близкие родственники (родство от CloseKinship) и пары,
+// в чьей родословной есть ненайденные или замкнутые в цикл родители, отсеиваются:
+// родство таких пар неизвестно и может оказаться близким. Остальные пары ранжируются по инбридингу потомства, возрасту и тому,
+// живут ли животные в одном вольере. ages перекрывает возраст по умолчанию, если задан.
+func (p *Pedigree) RecommendPairs(population []Animal, ages *AgeRange, now time.Time) []BreedingRecommendations {
+	groups := make(map[string][]Animal)
+	var names []string
+	for _, animal := range population {
+		name := strings.ToLower(animal.Species.Name)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], animal)
+	}
+	sort.Strings(names)
+
+	result := make([]BreedingRecommendations, 0, len(names))
+	for _, name := range names {
+		animals := groups[name]
+		sort.SliceStable(animals, func(i, j int) bool { return animals[i].Name < animals[j].Name })
+		recommendations := BreedingRecommendations{
+			Species:  animals[0].Species.Name,
+			Ages:     breedingAges[animals[0].Species.AnimalType],
+			Pairs:    make([]BreedingPair, 0),
+			Excluded: make([]BreedingExclusion, 0),
+		}
+		if ages != nil {
+			recommendations.Ages = *ages
+		}
+
+		var males, females []Animal
+		for _, animal := range animals {
+			switch {
+			case animal.HealthStatus == Sick:
+				recommendations.exclude("болеет", animal)
+			case animal.Gender == Male:
+				males = append(males, animal)
+			case animal.Gender == Female:
+				females = append(females, animal)
+			}
+		}
+		for _, sire := range males {
+			for _, dam := range females {
+				if unresolved := p.unresolvedAncestry(sire.ID, dam.ID); len(unresolved) > 0 {
+					recommendations.exclude("родство неизвестно: в родословной "+strings.Join(unresolved, "; "), sire, dam)
+					continue
+				}
+				kinship := p.Kinship(sire.ID, dam.ID)
+				if kinship >= CloseKinship {
+					recommendations.exclude(fmt.Sprintf("близкие родственники: родство %.4f, %s", kinship, relationHint(kinship)), sire, dam)
+					continue
+				}
+				recommendations.Pairs = append(recommendations.Pairs, newBreedingPair(sire, dam, kinship, recommendations.Ages, now))
+			}
+		}
+		sort.SliceStable(recommendations.Pairs, func(i, j int) bool {
+			a, b := recommendations.Pairs[i], recommendations.Pairs[j]
+			if a.Score != b.Score {
+				return a.Score > b.Score
+			}
+			return a.OffspringInbreeding < b.OffspringInbreeding
+		})
+		result = append(result, recommendations)
+	}
+	return result
+}
+
+// unresolvedAncestry - предки животных ids (и сами животные), у которых
+// указанный родитель не найден или связь с ним отброшена как цикл
+func (p *Pedigree) unresolvedAncestry(ids ...uuid.UUID) []string {
+	var result []string
+	seen := make(map[string]bool)
+	queue := make([]string, 0, len(ids))
+	for _, id := range ids {
+		queue = append(queue, id.String())
+	}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		node := p.nodes[key]
+		if node == nil || seen[key] {
+			continue
+		}
+		seen[key] = true
+		name := key
+		if node.animal != nil {
+			name = node.animal.Name
+		}
+		if len(node.missing) > 0 {
+			result = append(result, fmt.Sprintf("у %s не найден родитель %s", name, strings.Join(node.missing, ", ")))
+		}
+		if node.circular {
+			result = append(result, fmt.Sprintf("%s указан среди собственных предков", name))
+		}
+		for _, parent := range []string{node.sire, node.dam} {
+			if parent != "" {
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return result
+}
+
+func (r *BreedingRecommendations) exclude(reason string, animals ...Animal) {
+	exclusion := BreedingExclusion{Reason: reason}
+	for _, animal := range animals {
+		exclusion.AnimalIDs = append(exclusion.AnimalIDs, animal.ID)
+		exclusion.Names = append(exclusion.Names, animal.Name)
+	}
+	r.Excluded = append(r.Excluded, exclusion)
+}
+
+func newBreedingPair(sire, dam Animal, kinship float64, ages AgeRange, now time.Time) BreedingPair {
+	pair := BreedingPair{
+		Sire:                breedingCandidate(sire, now),
+		Dam:                 breedingCandidate(dam, now),
+		OffspringInbreeding: kinship,
+		Factors: []BreedingScore{
+			geneticsScore(sire, dam, kinship),
+			ageScore(ages, now, sire, dam),
+			proximityScore(sire, dam),
+		},
+	}
+	for _, factor := range pair.Factors {
+		pair.Score += factor.Weight * factor.Score
+	}
+	return pair
+}
+
+func breedingCandidate(animal Animal, now time.Time) BreedingCandidate {
+	candidate := BreedingCandidate{AnimalID: animal.ID, Name: animal.Name, EnclosureID: animal.EnclosureID}
+	if !animal.BirthDate.IsZero() {
+		age := fullYears(animal.BirthDate, now)
+		candidate.Age = &age
+	}
+	return candidate
+}
+
+func geneticsScore(sire, dam Animal, kinship float64) BreedingScore {
+	score := BreedingScore{Factor: FactorGenetics, Weight: geneticsWeight, Score: 1 - kinship/CloseKinship}
+	if kinship == 0 {
+		score.Reason = "общих известных предков нет, потомство не будет инбредным"
+	} else {
+		score.Reason = fmt.Sprintf("есть общие предки, инбридинг потомства %.4f", kinship)
+	}
+	var unknown []string
+	for _, animal := range []Animal{sire, dam} {
+		if animal.Sire == nil || animal.Dam == nil {
+			unknown = append(unknown, animal.Name)
+		}
+	}
+	if len(unknown) > 0 {
+		score.Reason += "; родословная неполная у " + strings.Join(unknown, " и ") + ", родство может быть выше"
+	}
+	return score
+}
+
+// ageScore - животное в возрасте размножения даёт 1, с неизвестным возрастом - 0.5, иначе 0
+func ageScore(ages AgeRange, now time.Time, animals ...Animal) BreedingScore {
+	score := BreedingScore{Factor: FactorAge, Weight: ageWeight}
+	var reasons []string
+	for _, animal := range animals {
+		if animal.BirthDate.IsZero() {
+			score.Score += 0.5 / float64(len(animals))
+			reasons = append(reasons, animal.Name+": дата рождения неизвестна")
+			continue
+		}
+		age := fullYears(animal.BirthDate, now)
+		switch {
+		case age < ages.Min:
+			reasons = append(reasons, fmt.Sprintf("%s: %d, моложе %d", animal.Name, age, ages.Min))
+		case age > ages.Max:
+			reasons = append(reasons, fmt.Sprintf("%s: %d, старше %d", animal.Name, age, ages.Max))
+		default:
+			score.Score += 1 / float64(len(animals))
+			reasons = append(reasons, fmt.Sprintf("%s: %d", animal.Name, age))
+		}
+	}
+	score.Reason = fmt.Sprintf("возраст размножения %d-%d лет; %s", ages.Min, ages.Max, strings.Join(reasons, "; "))
+	return score
+}
+
+func proximityScore(sire, dam Animal) BreedingScore {
+	score := BreedingScore{Factor: FactorProximity, Weight: proximityWeight}
+	switch {
+	case sire.EnclosureID != uuid.Nil && sire.EnclosureID == dam.EnclosureID:
+		score.Score = 1
+		score.Reason = "живут в одном вольере"
+	case sire.EnclosureID != uuid.Nil && dam.EnclosureID != uuid.Nil:
+		score.Score = 0.5
+		score.Reason = "живут в разных вольерах, одного нужно переместить"
+	default:
+		score.Score = 0.25
+		var homeless []string
+		for _, animal := range []Animal{sire, dam} {
+			if animal.EnclosureID == uuid.Nil {
+				homeless = append(homeless, animal.Name)
+			}
+		}
+		score.Reason = strings.Join(homeless, " и ") + " пока без вольера"
+	}
+	return score
+}
+
+// relationHint - какое родство обычно даёт такой коэффициент
+func relationHint(kinship float64) string {
+	if kinship >= 0.25 {
+		return "как у родителя и потомка или полных сибсов"
+	}
+	return "как у полусибсов, деда и внучки, дяди и племянницы"
+}
+
+func fullYears(birth, now time.Time) int {
+	years := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		years--
+	}
+	return years
+}
@@ -0,0 +1,170 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CarePlanItem - плановая процедура вида: осмотр, прививка, обработка.
+// Первый раз - в возрасте FirstAtMonths, затем каждые EveryMonths
+// после последней записи о ней в медицинской карте.
+type CarePlanItem struct {
+	Name          string           `json:"name"`
+	Kind          MedicalEntryKind `json:"kind"`
+	EveryMonths   int              `json:"everyMonths"`
+	FirstAtMonths int              `json:"firstAtMonths"`
+}
+
+// Matches - засчитывается ли запись карты как выполнение процедуры:
+// вид совпадает, а вакцина, препарат, анализ или описание записи
+// содержат название процедуры без учёта регистра
+func (i CarePlanItem) Matches(entry MedicalEntry) bool {
+	if entry.Kind != i.Kind {
+		return false
+	}
+	subject := entry.Summary
+	switch {
+	case entry.Vaccination != nil:
+		subject = entry.Vaccination.Vaccine
+	case entry.Medication != nil:
+		subject = entry.Medication.Name
+	case entry.LabResult != nil:
+		subject = entry.LabResult.Test
+	}
+	return strings.Contains(strings.ToLower(subject), strings.ToLower(i.Name))
+}
+
+// NextDue - когда процедура нужна в следующий раз. Дата следующей прививки,
+// которую ветеринар указал в записи, важнее интервала плана.
+func (i CarePlanItem) NextDue(birthDate time.Time, last *MedicalEntry) time.Time {
+	if last == nil {
+		return birthDate.AddDate(0, i.FirstAtMonths, 0)
+	}
+	if last.Vaccination != nil && last.Vaccination.NextDueAt != nil {
+		return *last.Vaccination.NextDueAt
+	}
+	return last.Date.AddDate(0, i.EveryMonths, 0)
+}
+
+// CarePlan - план профилактики для вида; вид определяется по названию без учёта регистра
+type CarePlan struct {
+	ID        uuid.UUID      `json:"ID"`
+	Species   string         `json:"species"`
+	Items     []CarePlanItem `json:"items"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	UpdatedBy string         `json:"updatedBy"`
+	Version   int            `json:"version"`
+}
+
+func NewCarePlan(species string, items []CarePlanItem, author string) (*CarePlan, error) {
+	plan := &CarePlan{ID: uuid.New(), Species: strings.TrimSpace(species)}
+	if plan.Species == "" {
+		return nil, errors.New("не указан вид")
+	}
+	if err := plan.SetItems(items, author); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (p *CarePlan) SetItems(items []CarePlanItem, author string) error {
+	names := make(map[string]struct{}, len(items))
+	cleaned := make([]CarePlanItem, 0, len(items))
+	for _, item := range items {
+		item.Name = strings.TrimSpace(item.Name)
+		key := string(item.Kind) + "/" + strings.ToLower(item.Name)
+		switch {
+		case item.Name == "":
+			return errors.New("у процедуры должно быть название")
+		case !item.Kind.IsValid():
+			return fmt.Errorf("процедура %q: неизвестный вид записи %q", item.Name, item.Kind)
+		case item.EveryMonths <= 0:
+			return fmt.Errorf("процедура %q: интервал должен быть больше нуля месяцев", item.Name)
+		case item.FirstAtMonths < 0:
+			return fmt.Errorf("процедура %q: возраст первой процедуры не может быть отрицательным", item.Name)
+		}
+		if _, exists := names[key]; exists {
+			return fmt.Errorf("процедура %q повторяется", item.Name)
+		}
+		names[key] = struct{}{}
+		cleaned = append(cleaned, item)
+	}
+	p.Items = cleaned
+	p.UpdatedAt = time.Now()
+	p.UpdatedBy = author
+	return nil
+}
+
+// AppliesTo - относится ли план к виду животного
+func (p CarePlan) AppliesTo(animal Animal) bool {
+	return strings.EqualFold(p.Species, animal.Species.Name)
+}
+
+type CareTaskStatus string
+
+const (
+	CareDue     CareTaskStatus = "due"
+	CareOverdue CareTaskStatus = "overdue"
+)
+
+// CareTask - процедура, которую нужно выполнить животному
+type CareTask struct {
+	AnimalID   uuid.UUID        `json:"animalID"`
+	AnimalName string           `json:"animalName"`
+	Species    string           `json:"species"`
+	PlanID     uuid.UUID        `json:"planID"`
+	Item       string           `json:"item"`
+	Kind       MedicalEntryKind `json:"kind"`
+	DueAt      time.Time        `json:"dueAt"`
+	LastDoneAt *time.Time       `json:"lastDoneAt,omitempty"`
+	Status     CareTaskStatus   `json:"status"`
+}
+
+// CareTasks - ближайшая процедура по каждому пункту плана для животного;
+// статус - относительно now
+func (p CarePlan) CareTasks(animal Animal, record MedicalRecord, now time.Time) []CareTask {
+	tasks := make([]CareTask, 0, len(p.Items))
+	for _, item := range p.Items {
+		var last *MedicalEntry
+		for i := range record.Entries {
+			entry := &record.Entries[i]
+			if item.Matches(*entry) && (last == nil || entry.Date.After(last.Date)) {
+				last = entry
+			}
+		}
+
+		task := CareTask{
+			AnimalID:   animal.ID,
+			AnimalName: animal.Name,
+			Species:    animal.Species.Name,
+			PlanID:     p.ID,
+			Item:       item.Name,
+			Kind:       item.Kind,
+			DueAt:      item.NextDue(animal.BirthDate, last),
+			Status:     CareDue,
+		}
+		if last != nil {
+			task.LastDoneAt = &last.Date
+		}
+		if task.DueAt.Before(now) {
+			task.Status = CareOverdue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// SortCareTasks - сначала самые давние сроки
+func SortCareTasks(tasks []CareTask) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].DueAt.Equal(tasks[j].DueAt) {
+			return tasks[i].DueAt.Before(tasks[j].DueAt)
+		}
+		return tasks[i].AnimalName < tasks[j].AnimalName
+	})
+}
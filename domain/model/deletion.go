@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Deletion - отметка мягкого удаления. Удалённая запись остаётся в хранилище,
+// но не попадает в поиск и списки, пока её не восстановят или не очистят
+// окончательно по истечении срока хранения.
+type Deletion struct {
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	DeletedBy string     `json:"deletedBy,omitempty"`
+}
+
+func (d Deletion) IsDeleted() bool {
+	return d.DeletedAt != nil
+}
+
+// DeletedBefore - удалена раньше cutoff; такие записи можно очищать
+func (d Deletion) DeletedBefore(cutoff time.Time) bool {
+	return d.DeletedAt != nil && d.DeletedAt.Before(cutoff)
+}
+
+func (d *Deletion) MarkDeleted(at time.Time, actor string) {
+	d.DeletedAt = &at
+	d.DeletedBy = actor
+}
+
+func (d *Deletion) Restore() {
+	d.DeletedAt = nil
+	d.DeletedBy = ""
+}
@@ -2,6 +2,8 @@ package model
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -9,46 +11,93 @@ import (
 type Enclosure struct {
 	AnimalsID    []uuid.UUID `json:"animalsID"`
 	ID           uuid.UUID   `json:"ID"`
+	Name         string      `json:"name,omitempty"`
 	Type         AnimalType  `json:"type"`
 	Size         Size        `json:"size"`
 	CurrentCount int         `json:"currentCount"`
 	MaxCapacity  int         `json:"maxCapacity"`
+	Deletion
+	Version int `json:"version"`
 }
 
+// NewEnclosure - name необязательно; по нему на вольер можно сослаться вместо ID
 func NewEnclosure(
+	name string,
 	enclosureType AnimalType,
 	size Size,
 	maxCapacity int,
 ) (*Enclosure, error) {
-
-	if maxCapacity <= 0 {
-		return nil, errors.New("вместимость должна быть больше нуля")
-	}
-
 	enclosure := &Enclosure{
 		ID:           uuid.New(),
+		Name:         strings.TrimSpace(name),
 		Type:         enclosureType,
 		Size:         size,
 		CurrentCount: 0,
 		MaxCapacity:  maxCapacity,
 		AnimalsID:    []uuid.UUID{},
 	}
-
+	if err := enclosure.Validate(); err != nil {
+		return nil, err
+	}
 	return enclosure, nil
 }
 
-func (e *Enclosure) AddAnimal(a Animal) {
-	e.AnimalsID = append(e.AnimalsID, a.ID)
+// Validate - проверка описания вольера при создании и при изменении
+func (e *Enclosure) Validate() error {
+	if !e.Type.IsValid() {
+		return fmt.Errorf("неизвестный тип вольера %q", e.Type)
+	}
+	if e.Size.Lenght <= 0 || e.Size.Width <= 0 || e.Size.Height <= 0 {
+		return errors.New("размеры вольера должны быть больше нуля")
+	}
+	if e.MaxCapacity <= 0 {
+		return errors.New("вместимость должна быть больше нуля")
+	}
+	return nil
+}
+
+// HasName - названия сравниваются без учёта регистра; вольер без названия не совпадает ни с чем
+func (e *Enclosure) HasName(name string) bool {
+	return e.Name != "" && strings.EqualFold(e.Name, strings.TrimSpace(name))
+}
+
+// CanAccept - проверяет, можно ли поселить животное в вольер
+func (e *Enclosure) CanAccept(a Animal) error {
+	if e.Type != a.Species.AnimalType {
+		return ErrIncompatibleEnclosure
+	}
+	if e.CurrentCount >= e.MaxCapacity {
+		return ErrEnclosureFull
+	}
+	return nil
+}
+
+func (e *Enclosure) AddAnimal(a Animal) error {
+	if err := e.CanAccept(a); err != nil {
+		return err
+	}
+	// Копируем срез, чтобы не задеть копии вольера, хранящиеся в репозитории
+	e.AnimalsID = append(append([]uuid.UUID{}, e.AnimalsID...), a.ID)
+	e.CurrentCount++
+	return nil
 }
 func (e *Enclosure) DeleteAnimal(a Animal) {
-	for i, id := range e.AnimalsID {
-		if id == a.ID {
-			e.AnimalsID = append(e.AnimalsID[:i], e.AnimalsID[i+1:]...)
+	animalsID := make([]uuid.UUID, 0, len(e.AnimalsID))
+	for _, id := range e.AnimalsID {
+		if id != a.ID {
+			animalsID = append(animalsID, id)
 		}
 	}
+	if len(animalsID) != len(e.AnimalsID) {
+		e.CurrentCount--
+	}
+	e.AnimalsID = animalsID
 }
 
-func (en1 Enclosure) ReplaceAnimal(en2 Enclosure, a Animal) {
+func (en1 *Enclosure) ReplaceAnimal(en2 *Enclosure, a Animal) error {
+	if err := en2.AddAnimal(a); err != nil {
+		return err
+	}
 	en1.DeleteAnimal(a)
-	en2.AddAnimal(a)
+	return nil
 }
@@ -0,0 +1,32 @@
+package model
+
+import "errors"
+
+// Доменные ошибки - по ним слой представления выбирает HTTP-код ответа
+var (
+	ErrAnimalNotFound        = errors.New("животное не найдено")
+	ErrEnclosureNotFound     = errors.New("вольер не найден")
+	ErrScheduleNotFound      = errors.New("расписание кормления не найдено")
+	ErrTransferNotFound      = errors.New("заявка на перемещение не найдена")
+	ErrUserNotFound          = errors.New("пользователь не найден")
+	ErrUserExists            = errors.New("пользователь с таким именем уже есть")
+	ErrWebhookNotFound       = errors.New("подписка на вебхуки не найдена")
+	ErrDeliveryNotFound      = errors.New("доставка вебхука не найдена")
+	ErrMedicalCaseNotFound   = errors.New("медицинский случай не найден")
+	ErrCarePlanNotFound      = errors.New("план профилактики не найден")
+	ErrCarePlanExists        = errors.New("план профилактики для этого вида уже есть")
+	ErrEnclosureFull         = errors.New("вольер заполнен")
+	ErrEnclosureNameTaken    = errors.New("вольер с таким названием уже есть")
+	ErrIncompatibleEnclosure = errors.New("тип вольера не подходит животному")
+	ErrInvalidTransition     = errors.New("недопустимая смена статуса")
+	ErrCircularParentage     = errors.New("животное оказалось бы собственным предком")
+	ErrFeedingAlreadyDone    = errors.New("кормление уже отмечено")
+	ErrForbidden             = errors.New("недостаточно прав")
+	ErrUnauthorized          = errors.New("требуется вход в систему")
+	ErrInvalidCredentials    = errors.New("неверное имя пользователя или пароль")
+	ErrValidation            = errors.New("некорректные данные")
+	ErrVersionConflict       = errors.New("запись была изменена другим пользователем")
+	ErrTransactionClosed     = errors.New("транзакция уже завершена")
+	ErrReadOnlyTransaction   = errors.New("транзакция только для чтения")
+	ErrAuditChainBroken      = errors.New("цепочка журнала аудита нарушена")
+)
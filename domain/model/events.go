@@ -0,0 +1,83 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeedingTimeEvent - наступило время кормления по расписанию
+type FeedingTimeEvent struct {
+	ScheduleID  uuid.UUID `json:"scheduleID"`
+	AnimalID    uuid.UUID `json:"animalID"`
+	FeedingTime time.Time `json:"feedingTime"`
+	FoodType    FoodType  `json:"foodType"`
+}
+
+type EventType string
+
+// Типы событий, которые получают подписчики
+const (
+	EventAnimalCreated    EventType = "animal.created"
+	EventAnimalDeleted    EventType = "animal.deleted"
+	EventAnimalRestored   EventType = "animal.restored"
+	EventAnimalMoved      EventType = "animal.moved"
+	EventHealthChanged    EventType = "animal.healthChanged"
+	EventStateChanged     EventType = "animal.stateChanged"
+	EventFeedingDue       EventType = "feeding.due"
+	EventFeedingCompleted EventType = "feeding.completed"
+	EventCareDue          EventType = "care.due"
+)
+
+var eventTypes = map[EventType]struct{}{
+	EventAnimalCreated:    {},
+	EventAnimalDeleted:    {},
+	EventAnimalRestored:   {},
+	EventAnimalMoved:      {},
+	EventHealthChanged:    {},
+	EventStateChanged:     {},
+	EventFeedingDue:       {},
+	EventFeedingCompleted: {},
+	EventCareDue:          {},
+}
+
+func (t EventType) IsValid() bool {
+	_, ok := eventTypes[t]
+	return ok
+}
+
+// ZooEvent - событие в зоопарке для живых подписчиков.
+// ID растёт монотонно и позволяет продолжить поток с места обрыва;
+// EnclosureIDs - вольеры, которых касается событие (при перемещении оба).
+type ZooEvent struct {
+	ID           int64       `json:"id"`
+	Type         EventType   `json:"type"`
+	At           time.Time   `json:"at"`
+	Actor        string      `json:"actor,omitempty"`
+	AnimalID     uuid.UUID   `json:"animalID"`
+	EnclosureIDs []uuid.UUID `json:"enclosureIDs,omitempty"`
+	Data         any         `json:"data"`
+}
+
+// HealthChange - данные события EventHealthChanged
+type HealthChange struct {
+	Before HealthStatus `json:"before"`
+	After  HealthStatus `json:"after"`
+}
+
+// EventFilter - отбор событий подписчика; пустые поля не ограничивают
+type EventFilter struct {
+	Types       []EventType
+	EnclosureID uuid.UUID
+}
+
+func (f EventFilter) Matches(e ZooEvent) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	if f.EnclosureID != uuid.Nil && !slices.Contains(e.EnclosureIDs, f.EnclosureID) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyRecord - первый ответ на запрос с заголовком Idempotency-Key.
+// Пока запрос выполняется, Completed == false и повтор с тем же ключом отклоняется.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	Completed   bool
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LifecycleState - где животное в своём жизненном цикле в зоопарке
+type LifecycleState string
+
+const (
+	StateIncoming       LifecycleState = "incoming"
+	StateQuarantine     LifecycleState = "quarantine"
+	StateOnDisplay      LifecycleState = "on-display"
+	StateOffDisplay     LifecycleState = "off-display"
+	StateLoanedOut      LifecycleState = "loaned-out"
+	StateTransferredOut LifecycleState = "transferred-out"
+	StateDeceased       LifecycleState = "deceased"
+)
+
+// lifecycleTransitions - допустимые переходы. Из transferred-out и deceased
+// переходов нет; животное, вернувшееся из другого зоопарка, - новое поступление.
+var lifecycleTransitions = map[LifecycleState][]LifecycleState{
+	StateIncoming:   {StateQuarantine, StateOnDisplay, StateOffDisplay, StateTransferredOut, StateDeceased},
+	StateQuarantine: {StateOnDisplay, StateOffDisplay, StateTransferredOut, StateDeceased},
+	StateOnDisplay:  {StateOffDisplay, StateQuarantine, StateLoanedOut, StateTransferredOut, StateDeceased},
+	StateOffDisplay: {StateOnDisplay, StateQuarantine, StateLoanedOut, StateTransferredOut, StateDeceased},
+	StateLoanedOut:  {StateQuarantine, StateOnDisplay, StateOffDisplay, StateTransferredOut, StateDeceased},
+}
+
+func (s LifecycleState) IsValid() bool {
+	switch s {
+	case StateIncoming, StateQuarantine, StateOnDisplay, StateOffDisplay,
+		StateLoanedOut, StateTransferredOut, StateDeceased:
+		return true
+	}
+	return false
+}
+
+// IsPresent - животное сейчас в зоопарке. Отданные на время, переданные
+// и павшие животные не попадают в списки и статистику, но остаются в базе.
+func (s LifecycleState) IsPresent() bool {
+	return s != StateLoanedOut && s != StateTransferredOut && s != StateDeceased
+}
+
+// IsFinal - из состояния нет переходов
+func (s LifecycleState) IsFinal() bool {
+	return s == StateTransferredOut || s == StateDeceased
+}
+
+func (s LifecycleState) CanTransitionTo(to LifecycleState) bool {
+	return slices.Contains(lifecycleTransitions[s], to)
+}
+
+// LifecycleTransition - смена состояния: Date - когда произошло,
+// RecordedAt - когда внесено
+type LifecycleTransition struct {
+	From       LifecycleState `json:"from,omitempty"`
+	To         LifecycleState `json:"to"`
+	Date       time.Time      `json:"date"`
+	Reason     string         `json:"reason"`
+	Actor      string         `json:"actor"`
+	RecordedAt time.Time      `json:"recordedAt"`
+}
+
+// EnterLifecycle - первое состояние нового животного; по умолчанию on-display.
+// arrived - дата поступления, нулевая - сейчас. Поступить сразу выбывшим нельзя.
+func (a *Animal) EnterLifecycle(state LifecycleState, arrived time.Time, reason string, actor string) error {
+	if state == "" {
+		state = StateOnDisplay
+	}
+	if !state.IsValid() || !state.IsPresent() {
+		return fmt.Errorf("животное не может поступить в состоянии %q", state)
+	}
+	now := time.Now()
+	if arrived.IsZero() {
+		arrived = now
+	}
+	if arrived.After(now) {
+		return errors.New("дата поступления не может быть в будущем")
+	}
+	if arrived.Before(a.BirthDate) {
+		return errors.New("дата поступления не может быть раньше рождения")
+	}
+	a.State = state
+	a.ArrivalDate = arrived
+	a.StateHistory = []LifecycleTransition{{To: state, Date: arrived, Reason: reason, Actor: actor, RecordedAt: now}}
+	return nil
+}
+
+// ChangeState - переводит животное в состояние to. Недопустимый переход -
+// ErrInvalidTransition, незаполненные дата и причина - обычная ошибка.
+// Дата не может быть в будущем и раньше предыдущего перехода.
+func (a *Animal) ChangeState(to LifecycleState, date time.Time, reason string, actor string) error {
+	reason = strings.TrimSpace(reason)
+	switch {
+	case !to.IsValid():
+		return fmt.Errorf("неизвестное состояние %q", to)
+	case date.IsZero():
+		return errors.New("не указана дата перехода")
+	case reason == "":
+		return errors.New("не указана причина перехода")
+	}
+	if !a.State.CanTransitionTo(to) {
+		return fmt.Errorf("%w: из %q в %q", ErrInvalidTransition, a.State, to)
+	}
+
+	now := time.Now()
+	if date.After(now) {
+		return errors.New("дата перехода не может быть в будущем")
+	}
+	if date.Before(a.BirthDate) {
+		return errors.New("дата перехода не может быть раньше рождения")
+	}
+	if last := a.LastTransition(); last != nil && date.Before(last.Date) {
+		return fmt.Errorf("дата перехода не может быть раньше предыдущего (%s)", last.Date.Format(time.DateOnly))
+	}
+
+	a.StateHistory = append(a.StateHistory, LifecycleTransition{
+		From:       a.State,
+		To:         to,
+		Date:       date,
+		Reason:     reason,
+		Actor:      actor,
+		RecordedAt: now,
+	})
+	a.State = to
+	return nil
+}
+
+func (a Animal) LastTransition() *LifecycleTransition {
+	if len(a.StateHistory) == 0 {
+		return nil
+	}
+	return &a.StateHistory[len(a.StateHistory)-1]
+}
+
+func (a Animal) IsPresent() bool {
+	return a.State.IsPresent()
+}
+
+// PresentAnimals - только животные, которые сейчас в зоопарке
+func PresentAnimals(animals []Animal) []Animal {
+	present := make([]Animal, 0, len(animals))
+	for _, animal := range animals {
+		if animal.IsPresent() {
+			present = append(present, animal)
+		}
+	}
+	return present
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Parent - отец или мать животного: животное зоопарка (AnimalID)
+// или животное другого учреждения, известное по племенной книге (External)
+type Parent struct {
+	AnimalID *uuid.UUID      `json:"animalId,omitempty"`
+	External *ExternalAnimal `json:"external,omitempty"`
+}
+
+// ExternalAnimal - животное другого учреждения. Его предки зоопарку
+// неизвестны, в расчётах родства оно считается основателем.
+type ExternalAnimal struct {
+	Institution string `json:"institution"`
+	StudbookID  string `json:"studbookId"`
+	Name        string `json:"name,omitempty"`
+}
+
+func (p *Parent) normalize() error {
+	switch {
+	case p.AnimalID != nil && p.External != nil:
+		return errors.New("родитель - либо животное зоопарка, либо животное другого учреждения")
+	case p.AnimalID != nil:
+		if *p.AnimalID == uuid.Nil {
+			return errors.New("не указан id родителя")
+		}
+	case p.External != nil:
+		p.External.Institution = strings.TrimSpace(p.External.Institution)
+		p.External.StudbookID = strings.TrimSpace(p.External.StudbookID)
+		p.External.Name = strings.TrimSpace(p.External.Name)
+		if p.External.Institution == "" || p.External.StudbookID == "" {
+			return errors.New("у животного другого учреждения нужны учреждение и номер в племенной книге")
+		}
+	default:
+		return errors.New("родитель не указан")
+	}
+	return nil
+}
+
+// Key - по нему один и тот же родитель узнаётся у разных животных
+func (p Parent) Key() string {
+	if p.AnimalID != nil {
+		return p.AnimalID.String()
+	}
+	return "studbook:" + strings.ToLower(p.External.Institution) + "/" + strings.ToLower(p.External.StudbookID)
+}
+
+// SetParents - nil означает, что родитель неизвестен. Пол, вид и возраст
+// родителей из зоопарка проверяет CheckParent, отсутствие циклов - LineageService.
+func (a *Animal) SetParents(sire, dam *Parent) error {
+	for _, parent := range []*Parent{sire, dam} {
+		if parent == nil {
+			continue
+		}
+		if err := parent.normalize(); err != nil {
+			return err
+		}
+		if parent.AnimalID != nil && *parent.AnimalID == a.ID {
+			return errors.New("животное не может быть собственным родителем")
+		}
+	}
+	if sire != nil && dam != nil && sire.Key() == dam.Key() {
+		return errors.New("отец и мать не могут быть одним животным")
+	}
+	a.Sire = sire
+	a.Dam = dam
+	return nil
+}
+
+// CheckParent - может ли parent быть отцом (gender = Male) или матерью животного
+func (a Animal) CheckParent(parent Animal, gender Gender) error {
+	if parent.Gender != gender {
+		if gender == Male {
+			return fmt.Errorf("отцом указана самка %s", parent.Name)
+		}
+		return fmt.Errorf("матерью указан самец %s", parent.Name)
+	}
+	if !strings.EqualFold(parent.Species.Name, a.Species.Name) {
+		return fmt.Errorf("%s другого вида: %s", parent.Name, parent.Species.Name)
+	}
+	if !parent.BirthDate.IsZero() && !a.BirthDate.IsZero() && !parent.BirthDate.Before(a.BirthDate) {
+		return fmt.Errorf("%s родился не раньше потомка", parent.Name)
+	}
+	return nil
+}
+
+// LineageNode - животное в родословной. У корня заполнены и предки (Sire, Dam),
+// и потомки (Offspring), ниже - только в одну сторону.
+type LineageNode struct {
+	AnimalID   *uuid.UUID      `json:"animalId,omitempty"`
+	External   *ExternalAnimal `json:"external,omitempty"`
+	Name       string          `json:"name"`
+	Gender     Gender          `json:"gender,omitempty"`
+	State      LifecycleState  `json:"state,omitempty"`
+	Inbreeding float64         `json:"inbreeding"`
+	Sire       *LineageNode    `json:"sire,omitempty"`
+	Dam        *LineageNode    `json:"dam,omitempty"`
+	Offspring  []LineageNode   `json:"offspring,omitempty"`
+}
+
+// AnimalKinship - генетическая ценность животного: чем ниже средняя
+// степень родства с остальными животными вида, тем ценнее его потомство
+type AnimalKinship struct {
+	AnimalID    uuid.UUID `json:"animalId"`
+	Name        string    `json:"name"`
+	Gender      Gender    `json:"gender"`
+	Inbreeding  float64   `json:"inbreeding"`
+	MeanKinship float64   `json:"meanKinship"`
+}
+
+// SpeciesKinship - родство внутри вида; животные от самых ценных к наименее ценным
+type SpeciesKinship struct {
+	Species        string          `json:"species"`
+	MeanKinship    float64         `json:"meanKinship"`
+	MeanInbreeding float64         `json:"meanInbreeding"`
+	Animals        []AnimalKinship `json:"animals"`
+}
+
+type LineageIssueKind string
+
+const (
+	IssueUnknownSire        LineageIssueKind = "unknown-sire"
+	IssueUnknownDam         LineageIssueKind = "unknown-dam"
+	IssueMissingParent      LineageIssueKind = "missing-parent"
+	IssueInconsistentParent LineageIssueKind = "inconsistent-parent"
+	IssueCircularParentage  LineageIssueKind = "circular-parentage"
+)
+
+// LineageIssue - пробел или ошибка в родословной животного
+type LineageIssue struct {
+	AnimalID uuid.UUID        `json:"animalId"`
+	Name     string           `json:"name"`
+	Species  string           `json:"species"`
+	Kind     LineageIssueKind `json:"kind"`
+	Detail   string           `json:"detail,omitempty"`
+}
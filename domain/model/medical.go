@@ -0,0 +1,214 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CaseStatus string
+
+const (
+	CaseOpen   CaseStatus = "open"
+	CaseClosed CaseStatus = "closed"
+)
+
+// MedicalCase - случай болезни или травмы. Пока открыт хотя бы один
+// случай, животное считается больным; закрытие последнего вылечивает его.
+type MedicalCase struct {
+	ID         uuid.UUID  `json:"ID"`
+	AnimalID   uuid.UUID  `json:"animalID"`
+	Title      string     `json:"title"`
+	Status     CaseStatus `json:"status"`
+	OpenedAt   time.Time  `json:"openedAt"`
+	OpenedBy   string     `json:"openedBy"`
+	ClosedAt   *time.Time `json:"closedAt,omitempty"`
+	ClosedBy   string     `json:"closedBy,omitempty"`
+	Resolution string     `json:"resolution,omitempty"`
+	Version    int        `json:"version"`
+}
+
+func NewMedicalCase(animalID uuid.UUID, title string, openedBy string) (*MedicalCase, error) {
+	title = strings.TrimSpace(title)
+	if animalID == uuid.Nil {
+		return nil, errors.New("не указано животное")
+	}
+	if title == "" {
+		return nil, errors.New("не указана причина обращения")
+	}
+	if openedBy == "" {
+		return nil, errors.New("не указан ветеринар")
+	}
+
+	return &MedicalCase{
+		ID:       uuid.New(),
+		AnimalID: animalID,
+		Title:    title,
+		Status:   CaseOpen,
+		OpenedAt: time.Now(),
+		OpenedBy: openedBy,
+	}, nil
+}
+
+func (c *MedicalCase) IsOpen() bool {
+	return c.Status == CaseOpen
+}
+
+func (c *MedicalCase) Close(closedBy string, resolution string) error {
+	if !c.IsOpen() {
+		return ErrInvalidTransition
+	}
+	now := time.Now()
+	c.Status = CaseClosed
+	c.ClosedAt = &now
+	c.ClosedBy = closedBy
+	c.Resolution = strings.TrimSpace(resolution)
+	return nil
+}
+
+// MedicalEntryKind - вид записи медицинской карты
+type MedicalEntryKind string
+
+const (
+	EntryExamination MedicalEntryKind = "examination"
+	EntryDiagnosis   MedicalEntryKind = "diagnosis"
+	EntryTreatment   MedicalEntryKind = "treatment"
+	EntryMedication  MedicalEntryKind = "medication"
+	EntryVaccination MedicalEntryKind = "vaccination"
+	EntryLabResult   MedicalEntryKind = "labResult"
+)
+
+func (k MedicalEntryKind) IsValid() bool {
+	switch k {
+	case EntryExamination, EntryDiagnosis, EntryTreatment, EntryMedication, EntryVaccination, EntryLabResult:
+		return true
+	}
+	return false
+}
+
+type Medication struct {
+	Name      string `json:"name"`
+	Dosage    string `json:"dosage"`
+	Frequency string `json:"frequency,omitempty"`
+	// Until - последний день приёма, если курс ограничен
+	Until *time.Time `json:"until,omitempty"`
+}
+
+type Vaccination struct {
+	Vaccine string `json:"vaccine"`
+	Batch   string `json:"batch,omitempty"`
+	// NextDueAt - когда нужна следующая прививка
+	NextDueAt *time.Time `json:"nextDueAt,omitempty"`
+}
+
+type LabResult struct {
+	Test           string `json:"test"`
+	Value          string `json:"value"`
+	Unit           string `json:"unit,omitempty"`
+	ReferenceRange string `json:"referenceRange,omitempty"`
+	Abnormal       bool   `json:"abnormal"`
+}
+
+// MedicalEntry - запись медицинской карты. Записи только добавляются:
+// ошибочную запись исправляют новой записью, а не правкой старой.
+// Date - когда выполнено, RecordedAt - когда внесено в карту.
+type MedicalEntry struct {
+	ID          uuid.UUID        `json:"ID"`
+	AnimalID    uuid.UUID        `json:"animalID"`
+	CaseID      *uuid.UUID       `json:"caseID,omitempty"`
+	Kind        MedicalEntryKind `json:"kind"`
+	Date        time.Time        `json:"date"`
+	Author      string           `json:"author"`
+	Summary     string           `json:"summary,omitempty"`
+	Notes       string           `json:"notes,omitempty"`
+	Medication  *Medication      `json:"medication,omitempty"`
+	Vaccination *Vaccination     `json:"vaccination,omitempty"`
+	LabResult   *LabResult       `json:"labResult,omitempty"`
+	RecordedAt  time.Time        `json:"recordedAt"`
+}
+
+// NewMedicalEntry - проверяет, что у записи заполнены поля её вида:
+// препарат и дозировка, вакцина, анализ и результат или описание для остальных
+func NewMedicalEntry(data MedicalEntry, author string) (*MedicalEntry, error) {
+	now := time.Now()
+	switch {
+	case data.AnimalID == uuid.Nil:
+		return nil, errors.New("не указано животное")
+	case !data.Kind.IsValid():
+		return nil, fmt.Errorf("неизвестный вид записи %q", data.Kind)
+	case author == "":
+		return nil, errors.New("не указан автор записи")
+	case data.Date.IsZero():
+		return nil, errors.New("не указана дата")
+	case data.Date.After(now):
+		return nil, errors.New("дата записи не может быть в будущем")
+	}
+
+	entry := MedicalEntry{
+		ID:         uuid.New(),
+		AnimalID:   data.AnimalID,
+		CaseID:     data.CaseID,
+		Kind:       data.Kind,
+		Date:       data.Date,
+		Author:     author,
+		Summary:    strings.TrimSpace(data.Summary),
+		Notes:      strings.TrimSpace(data.Notes),
+		RecordedAt: now,
+	}
+	switch data.Kind {
+	case EntryMedication:
+		if data.Medication == nil || data.Medication.Name == "" || data.Medication.Dosage == "" {
+			return nil, errors.New("для назначения нужны препарат и дозировка")
+		}
+		if until := data.Medication.Until; until != nil && until.Before(data.Date) {
+			return nil, errors.New("курс не может закончиться раньше назначения")
+		}
+		entry.Medication = data.Medication
+	case EntryVaccination:
+		if data.Vaccination == nil || data.Vaccination.Vaccine == "" {
+			return nil, errors.New("для прививки нужна вакцина")
+		}
+		if next := data.Vaccination.NextDueAt; next != nil && !next.After(data.Date) {
+			return nil, errors.New("следующая прививка должна быть позже этой")
+		}
+		entry.Vaccination = data.Vaccination
+	case EntryLabResult:
+		if data.LabResult == nil || data.LabResult.Test == "" || data.LabResult.Value == "" {
+			return nil, errors.New("для анализа нужны название и результат")
+		}
+		entry.LabResult = data.LabResult
+	default:
+		if entry.Summary == "" {
+			return nil, errors.New("не указано описание")
+		}
+	}
+	return &entry, nil
+}
+
+// MedicalRecord - медицинская карта животного: случаи и записи по дате
+type MedicalRecord struct {
+	AnimalID uuid.UUID      `json:"animalID"`
+	Cases    []MedicalCase  `json:"cases"`
+	Entries  []MedicalEntry `json:"entries"`
+}
+
+func (r *MedicalRecord) OpenCases() []MedicalCase {
+	open := make([]MedicalCase, 0)
+	for _, c := range r.Cases {
+		if c.IsOpen() {
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+// HealthStatus - состояние здоровья, которое следует из открытых случаев
+func (r *MedicalRecord) HealthStatus() HealthStatus {
+	if len(r.OpenCases()) > 0 {
+		return Sick
+	}
+	return Healthy
+}
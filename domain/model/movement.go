@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Movement - запись о перемещении животного.
+// uuid.Nil в From означает поступление в зоопарк, в To - выбытие.
+type Movement struct {
+	ID              uuid.UUID `json:"ID"`
+	AnimalID        uuid.UUID `json:"animalID"`
+	FromEnclosureID uuid.UUID `json:"fromEnclosureID"`
+	ToEnclosureID   uuid.UUID `json:"toEnclosureID"`
+	MovedAt         time.Time `json:"movedAt"`
+	Reason          string    `json:"reason"`
+	Actor           string    `json:"actor"`
+}
+
+func NewMovement(animalID uuid.UUID, from uuid.UUID, to uuid.UUID, reason string, actor string) *Movement {
+	return &Movement{
+		ID:              uuid.New(),
+		AnimalID:        animalID,
+		FromEnclosureID: from,
+		ToEnclosureID:   to,
+		MovedAt:         time.Now(),
+		Reason:          reason,
+		Actor:           actor,
+	}
+}
+
+// Occupancy - животное, находившееся в вольере в заданный момент
+type Occupancy struct {
+	AnimalID uuid.UUID `json:"animalID"`
+	Since    time.Time `json:"since"`
+}
+
+// Стандартные причины перемещений
+const (
+	ReasonArrival          = "поступление"
+	ReasonTransfer         = "перемещение"
+	ReasonRemoval          = "выбытие"
+	ReasonEnclosureRemoved = "вольер удалён"
+)
@@ -0,0 +1,338 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Pedigree - родословная животных зоопарка и упомянутых ими родителей
+// из других учреждений. Строится по снимку животных. Степени родства
+// запоминаются, поэтому расчёт по целому виду не растёт экспоненциально
+// с числом поколений.
+type Pedigree struct {
+	nodes   map[string]*pedigreeNode
+	kinship map[[2]string]float64
+}
+
+type pedigreeNode struct {
+	animal   *Animal
+	external *ExternalAnimal
+	// sire и dam - ключи родителей; "" - родитель неизвестен или связь отброшена
+	sire, dam string
+	offspring []string
+	// depth - длина самой длинной цепочки предков; предок всегда "мельче" потомка
+	depth int
+	// missing - указанные родители из зоопарка, которых среди животных нет
+	missing []string
+	// circular - связь с родителем замыкала родословную в цикл и не учитывается
+	circular bool
+}
+
+func NewPedigree(animals []Animal) *Pedigree {
+	animals = append([]Animal(nil), animals...)
+	p := &Pedigree{
+		nodes:   make(map[string]*pedigreeNode, len(animals)),
+		kinship: make(map[[2]string]float64),
+	}
+	for i := range animals {
+		p.nodes[animals[i].ID.String()] = &pedigreeNode{animal: &animals[i], depth: -1}
+	}
+	for _, animal := range animals {
+		node := p.nodes[animal.ID.String()]
+		node.sire = p.link(node, animal.Sire)
+		node.dam = p.link(node, animal.Dam)
+	}
+
+	keys := make([]string, 0, len(p.nodes))
+	for key := range p.nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		p.resolveDepth(key, make(map[string]bool))
+	}
+	for _, key := range keys {
+		node := p.nodes[key]
+		for _, parent := range []string{node.sire, node.dam} {
+			if parent != "" {
+				p.nodes[parent].offspring = append(p.nodes[parent].offspring, key)
+			}
+		}
+	}
+	for _, node := range p.nodes {
+		sort.SliceStable(node.offspring, func(i, j int) bool {
+			return p.nodes[node.offspring[i]].animal.BirthDate.Before(p.nodes[node.offspring[j]].animal.BirthDate)
+		})
+	}
+	return p
+}
+
+// link - ключ родителя; родитель из другого учреждения добавляется при первом упоминании
+func (p *Pedigree) link(child *pedigreeNode, parent *Parent) string {
+	if parent == nil || (parent.AnimalID == nil && parent.External == nil) {
+		return ""
+	}
+	key := parent.Key()
+	if _, ok := p.nodes[key]; ok {
+		return key
+	}
+	if parent.External == nil {
+		child.missing = append(child.missing, key)
+		return ""
+	}
+	p.nodes[key] = &pedigreeNode{external: parent.External, depth: -1}
+	return key
+}
+
+// resolveDepth - глубина узла; связь с предком, который уже обходится, замкнула бы цикл
+func (p *Pedigree) resolveDepth(key string, visiting map[string]bool) int {
+	node := p.nodes[key]
+	if node.depth >= 0 {
+		return node.depth
+	}
+	visiting[key] = true
+	depth := 0
+	for _, parent := range []*string{&node.sire, &node.dam} {
+		if *parent == "" {
+			continue
+		}
+		if visiting[*parent] {
+			node.circular = true
+			*parent = ""
+			continue
+		}
+		if d := p.resolveDepth(*parent, visiting) + 1; d > depth {
+			depth = d
+		}
+	}
+	delete(visiting, key)
+	node.depth = depth
+	return depth
+}
+
+// Kinship - коэффициент родства: вероятность, что случайно взятые у a и b
+// аллели одного гена идентичны по происхождению. Животные с неизвестными
+// родителями считаются неродственными основателями.
+func (p *Pedigree) Kinship(a, b uuid.UUID) float64 {
+	if p.nodes[a.String()] == nil || p.nodes[b.String()] == nil {
+		return 0
+	}
+	return p.kinshipOf(a.String(), b.String())
+}
+
+// Inbreeding - коэффициент инбридинга: родство отца и матери животного
+func (p *Pedigree) Inbreeding(id uuid.UUID) float64 {
+	if p.nodes[id.String()] == nil {
+		return 0
+	}
+	return p.inbreeding(id.String())
+}
+
+// IsAncestor - ancestor среди предков животного id
+func (p *Pedigree) IsAncestor(ancestor, id uuid.UUID) bool {
+	target := ancestor.String()
+	queue := []string{id.String()}
+	seen := make(map[string]bool)
+	for len(queue) > 0 {
+		node := p.nodes[queue[0]]
+		queue = queue[1:]
+		if node == nil {
+			continue
+		}
+		for _, parent := range []string{node.sire, node.dam} {
+			if parent == target {
+				return true
+			}
+			if parent != "" && !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return false
+}
+
+func (p *Pedigree) inbreeding(key string) float64 {
+	node := p.nodes[key]
+	return p.kinshipOf(node.sire, node.dam)
+}
+
+func (p *Pedigree) kinshipOf(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 0.5 * (1 + p.inbreeding(a))
+	}
+	if a > b {
+		a, b = b, a
+	}
+	pair := [2]string{a, b}
+	if f, ok := p.kinship[pair]; ok {
+		return f
+	}
+	// Раскрываем того, кто дальше от основателей: он не может быть предком другого
+	younger, other := p.nodes[b], a
+	if p.nodes[a].depth > younger.depth {
+		younger, other = p.nodes[a], b
+	}
+	f := 0.5*p.kinshipOf(other, younger.sire) + 0.5*p.kinshipOf(other, younger.dam)
+	p.kinship[pair] = f
+	return f
+}
+
+// Lineage - предки и потомки животного на generations поколений; nil, если животного нет
+func (p *Pedigree) Lineage(id uuid.UUID, generations int) *LineageNode {
+	key := id.String()
+	node := p.nodes[key]
+	if node == nil {
+		return nil
+	}
+	root := p.lineageNode(key)
+	root.Sire = p.ancestors(node.sire, generations)
+	root.Dam = p.ancestors(node.dam, generations)
+	root.Offspring = p.descendants(key, generations)
+	return &root
+}
+
+func (p *Pedigree) ancestors(key string, generations int) *LineageNode {
+	if key == "" || generations <= 0 {
+		return nil
+	}
+	result := p.lineageNode(key)
+	result.Sire = p.ancestors(p.nodes[key].sire, generations-1)
+	result.Dam = p.ancestors(p.nodes[key].dam, generations-1)
+	return &result
+}
+
+func (p *Pedigree) descendants(key string, generations int) []LineageNode {
+	if generations <= 0 {
+		return nil
+	}
+	var result []LineageNode
+	for _, child := range p.nodes[key].offspring {
+		node := p.lineageNode(child)
+		node.Offspring = p.descendants(child, generations-1)
+		result = append(result, node)
+	}
+	return result
+}
+
+func (p *Pedigree) lineageNode(key string) LineageNode {
+	node := p.nodes[key]
+	result := LineageNode{Inbreeding: p.inbreeding(key)}
+	if node.animal == nil {
+		result.External = node.external
+		result.Name = node.external.Name
+		if result.Name == "" {
+			result.Name = node.external.StudbookID
+		}
+		return result
+	}
+	id := node.animal.ID
+	result.AnimalID = &id
+	result.Name = node.animal.Name
+	result.Gender = node.animal.Gender
+	result.State = node.animal.State
+	return result
+}
+
+// SpeciesKinship - среднее родство и инбридинг по видам среди population.
+// Среднее родство животного считается со всеми животными вида, включая его самого.
+func (p *Pedigree) SpeciesKinship(population []Animal) []SpeciesKinship {
+	groups := make(map[string][]Animal)
+	var names []string
+	for _, animal := range population {
+		if p.nodes[animal.ID.String()] == nil {
+			continue
+		}
+		name := strings.ToLower(animal.Species.Name)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], animal)
+	}
+	sort.Strings(names)
+
+	result := make([]SpeciesKinship, 0, len(names))
+	for _, name := range names {
+		animals := groups[name]
+		n := float64(len(animals))
+		species := SpeciesKinship{Species: animals[0].Species.Name, Animals: make([]AnimalKinship, 0, len(animals))}
+		for _, a := range animals {
+			var sum float64
+			for _, b := range animals {
+				sum += p.kinshipOf(a.ID.String(), b.ID.String())
+			}
+			kinship := AnimalKinship{
+				AnimalID:    a.ID,
+				Name:        a.Name,
+				Gender:      a.Gender,
+				Inbreeding:  p.inbreeding(a.ID.String()),
+				MeanKinship: sum / n,
+			}
+			species.MeanKinship += kinship.MeanKinship / n
+			species.MeanInbreeding += kinship.Inbreeding / n
+			species.Animals = append(species.Animals, kinship)
+		}
+		sort.SliceStable(species.Animals, func(i, j int) bool {
+			if species.Animals[i].MeanKinship != species.Animals[j].MeanKinship {
+				return species.Animals[i].MeanKinship < species.Animals[j].MeanKinship
+			}
+			return species.Animals[i].Name < species.Animals[j].Name
+		})
+		result = append(result, species)
+	}
+	return result
+}
+
+// Issues - неизвестные, ненайденные, несовместимые и замкнутые в цикл родители animals
+func (p *Pedigree) Issues(animals []Animal) []LineageIssue {
+	issues := make([]LineageIssue, 0)
+	for _, animal := range animals {
+		node := p.nodes[animal.ID.String()]
+		if node == nil {
+			continue
+		}
+		add := func(kind LineageIssueKind, detail string) {
+			issues = append(issues, LineageIssue{
+				AnimalID: animal.ID,
+				Name:     animal.Name,
+				Species:  animal.Species.Name,
+				Kind:     kind,
+				Detail:   detail,
+			})
+		}
+
+		if animal.Sire == nil {
+			add(IssueUnknownSire, "")
+		}
+		if animal.Dam == nil {
+			add(IssueUnknownDam, "")
+		}
+		for _, key := range node.missing {
+			add(IssueMissingParent, fmt.Sprintf("родитель %s не найден среди животных зоопарка", key))
+		}
+		if node.circular {
+			add(IssueCircularParentage, "животное указано среди собственных предков")
+		}
+		for _, parent := range []struct {
+			key    string
+			gender Gender
+		}{{node.sire, Male}, {node.dam, Female}} {
+			if parent.key == "" || p.nodes[parent.key].animal == nil {
+				continue
+			}
+			if err := animal.CheckParent(*p.nodes[parent.key].animal, parent.gender); err != nil {
+				add(IssueInconsistentParent, err.Error())
+			}
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return strings.ToLower(issues[i].Name) < strings.ToLower(issues[j].Name)
+	})
+	return issues
+}
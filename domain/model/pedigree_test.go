@@ -0,0 +1,164 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// pedigreeAnimal - здоровый волк 2018 года рождения с родителями sire и dam
+func pedigreeAnimal(name string, gender Gender, sire, dam *Parent) Animal {
+	return Animal{
+		ID:           uuid.New(),
+		Name:         name,
+		Species:      Species{AnimalType: Predator, Name: "Grey wolf"},
+		BirthDate:    time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		HealthStatus: Healthy,
+		Gender:       gender,
+		Sire:         sire,
+		Dam:          dam,
+	}
+}
+
+func zooParent(animal Animal) *Parent {
+	id := animal.ID
+	return &Parent{AnimalID: &id}
+}
+
+func studbookParent(studbookID string) *Parent {
+	return &Parent{External: &ExternalAnimal{Institution: "Tierpark Berlin", StudbookID: studbookID}}
+}
+
+func TestPedigreeKinshipAndInbreeding(t *testing.T) {
+	sire := pedigreeAnimal("Sire", Male, nil, nil)
+	dam := pedigreeAnimal("Dam", Female, nil, nil)
+	otherDam := pedigreeAnimal("Other dam", Female, nil, nil)
+	brother := pedigreeAnimal("Brother", Male, zooParent(sire), zooParent(dam))
+	sister := pedigreeAnimal("Sister", Female, zooParent(sire), zooParent(dam))
+	halfSister := pedigreeAnimal("Half-sister", Female, zooParent(sire), zooParent(otherDam))
+	// Отец из другого учреждения узнаётся по номеру в племенной книге без учёта регистра
+	imported := pedigreeAnimal("Imported", Male, studbookParent("AL-0815"), nil)
+	importedHalf := pedigreeAnimal("Imported half", Female, studbookParent("al-0815"), zooParent(otherDam))
+	unknown := pedigreeAnimal("Unknown", Male, nil, nil)
+	siblingsCub := pedigreeAnimal("Siblings cub", Male, zooParent(brother), zooParent(sister))
+	halfSiblingsCub := pedigreeAnimal("Half-siblings cub", Female, zooParent(brother), zooParent(halfSister))
+	backcross := pedigreeAnimal("Backcross", Female, zooParent(sire), zooParent(sister))
+
+	pedigree := NewPedigree([]Animal{
+		sire, dam, otherDam, brother, sister, halfSister, imported, importedHalf, unknown,
+		siblingsCub, halfSiblingsCub, backcross,
+	})
+
+	kinship := []struct {
+		name string
+		a, b Animal
+		want float64
+	}{
+		{name: "full siblings", a: brother, b: sister, want: 0.25},
+		{name: "half siblings", a: brother, b: halfSister, want: 0.125},
+		{name: "half siblings through an external sire", a: imported, b: importedHalf, want: 0.125},
+		{name: "parent and offspring", a: sire, b: sister, want: 0.25},
+		{name: "grandparent and grandchild", a: dam, b: siblingsCub, want: 0.25},
+		{name: "unrelated founders", a: sire, b: dam, want: 0},
+		{name: "unknown ancestors", a: unknown, b: brother, want: 0},
+		{name: "self, founder", a: sire, b: sire, want: 0.5},
+		{name: "self, inbred", a: siblingsCub, b: siblingsCub, want: 0.625},
+	}
+	for _, tt := range kinship {
+		t.Run("kinship/"+tt.name, func(t *testing.T) {
+			if got := pedigree.Kinship(tt.a.ID, tt.b.ID); math.Abs(got-tt.want) > 1e-12 {
+				t.Fatalf("Kinship(%s, %s) = %v, want %v", tt.a.Name, tt.b.Name, got, tt.want)
+			}
+			if got := pedigree.Kinship(tt.b.ID, tt.a.ID); math.Abs(got-tt.want) > 1e-12 {
+				t.Fatalf("Kinship(%s, %s) = %v, want %v", tt.b.Name, tt.a.Name, got, tt.want)
+			}
+		})
+	}
+
+	inbreeding := []struct {
+		name   string
+		animal Animal
+		want   float64
+	}{
+		{name: "offspring of full siblings", animal: siblingsCub, want: 0.25},
+		{name: "offspring of half siblings", animal: halfSiblingsCub, want: 0.125},
+		{name: "offspring of parent and offspring", animal: backcross, want: 0.25},
+		{name: "offspring of unrelated parents", animal: brother, want: 0},
+		{name: "unknown parents", animal: unknown, want: 0},
+		{name: "one unknown parent", animal: imported, want: 0},
+	}
+	for _, tt := range inbreeding {
+		t.Run("inbreeding/"+tt.name, func(t *testing.T) {
+			if got := pedigree.Inbreeding(tt.animal.ID); math.Abs(got-tt.want) > 1e-12 {
+				t.Fatalf("Inbreeding(%s) = %v, want %v", tt.animal.Name, got, tt.want)
+			}
+		})
+	}
+
+	if got := pedigree.Kinship(uuid.New(), sire.ID); got != 0 {
+		t.Fatalf("Kinship() with an animal outside the pedigree = %v, want 0", got)
+	}
+}
+
+func TestRecommendPairsExcludesUnresolvedAncestry(t *testing.T) {
+	// Родитель указан, но среди животных его нет
+	missing := pedigreeAnimal("Orphan", Male, zooParent(pedigreeAnimal("Gone", Male, nil, nil)), nil)
+	// Два животных указаны родителями друг друга; их сын наследует цикл
+	first := pedigreeAnimal("First", Male, nil, nil)
+	second := pedigreeAnimal("Second", Female, zooParent(first), nil)
+	first.Dam = zooParent(second)
+	cycleSon := pedigreeAnimal("Cycle son", Male, nil, zooParent(second))
+	brother := pedigreeAnimal("Brother", Male, nil, nil)
+	mate := pedigreeAnimal("Mate", Female, nil, nil)
+	sister := pedigreeAnimal("Sister", Female, zooParent(brother), nil)
+
+	animals := []Animal{missing, first, second, cycleSon, brother, mate, sister}
+	pedigree := NewPedigree(animals)
+	recommendations := pedigree.RecommendPairs(animals, nil, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(recommendations) != 1 {
+		t.Fatalf("recommendations = %+v, want one species", recommendations)
+	}
+	wolves := recommendations[0]
+
+	excluded := make(map[string]string)
+	for _, exclusion := range wolves.Excluded {
+		excluded[strings.Join(exclusion.Names, " + ")] = exclusion.Reason
+	}
+	tests := []struct {
+		pair   string
+		reason string
+	}{
+		{pair: "Orphan + Mate", reason: "не найден родитель"},
+		{pair: "Orphan + Sister", reason: "не найден родитель"},
+		{pair: "Cycle son + Mate", reason: "указан среди собственных предков"},
+		{pair: "First + Mate", reason: "указан среди собственных предков"},
+		{pair: "Brother + Second", reason: "указан среди собственных предков"},
+		// Отец и дочь - родство 0.25
+		{pair: "Brother + Sister", reason: "близкие родственники"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pair, func(t *testing.T) {
+			if reason, ok := excluded[tt.pair]; !ok || !strings.Contains(reason, tt.reason) {
+				t.Fatalf("exclusion of %s = %q, want a reason with %q; excluded: %v", tt.pair, reason, tt.reason, excluded)
+			}
+		})
+	}
+
+	if len(wolves.Pairs) != 1 || wolves.Pairs[0].Sire.Name != "Brother" || wolves.Pairs[0].Dam.Name != "Mate" {
+		t.Fatalf("pairs = %+v, want only Brother + Mate", wolves.Pairs)
+	}
+
+	kinds := make(map[LineageIssueKind][]string)
+	for _, issue := range pedigree.Issues(animals) {
+		kinds[issue.Kind] = append(kinds[issue.Kind], issue.Name)
+	}
+	if names := kinds[IssueMissingParent]; len(names) != 1 || names[0] != "Orphan" {
+		t.Fatalf("missing parent issues = %v, want Orphan", names)
+	}
+	if names := kinds[IssueCircularParentage]; len(names) != 1 {
+		t.Fatalf("circular parentage issues = %v, want one of First and Second", names)
+	}
+}
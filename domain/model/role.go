@@ -0,0 +1,73 @@
+package model
+
+// Role - роль сотрудника зоопарка
+type Role string
+
+const (
+	RoleKeeper  Role = "keeper"
+	RoleVet     Role = "vet"
+	RoleCurator Role = "curator"
+	RoleAdmin   Role = "admin"
+	RolePublic  Role = "public"
+)
+
+// Permission - действие, которое проверяется перед выполнением запроса
+type Permission string
+
+const (
+	PermRead             Permission = "read"
+	PermAdmitAnimals     Permission = "animals.admit"
+	PermManageAnimals    Permission = "animals.manage"
+	PermDeleteAnimals    Permission = "animals.delete"
+	PermChangeLifecycle  Permission = "animals.lifecycle"
+	PermReadMedical      Permission = "medical.read"
+	PermManageMedical    Permission = "medical.manage"
+	PermManageEnclosures Permission = "enclosures.manage"
+	PermDeleteEnclosures Permission = "enclosures.delete"
+	PermMoveAnimals      Permission = "transfers.move"
+	PermRequestTransfers Permission = "transfers.request"
+	PermApproveTransfers Permission = "transfers.approve"
+	PermManageFeeding    Permission = "feeding.manage"
+	PermReadAudit        Permission = "audit.read"
+	PermManageUsers      Permission = "users.manage"
+	PermManageWebhooks   Permission = "webhooks.manage"
+	PermLoadFixtures     Permission = "fixtures.load"
+)
+
+// rolePermissions - права ролей; администратору разрешено всё
+var rolePermissions = map[Role][]Permission{
+	RolePublic: {PermRead},
+	RoleKeeper: {PermRead, PermAdmitAnimals, PermManageAnimals, PermRequestTransfers, PermManageFeeding, PermReadMedical},
+	RoleVet:    {PermRead, PermAdmitAnimals, PermReadMedical, PermManageMedical, PermRequestTransfers, PermChangeLifecycle},
+	RoleCurator: {
+		PermRead, PermAdmitAnimals, PermManageAnimals, PermDeleteAnimals, PermChangeLifecycle,
+		PermManageEnclosures, PermDeleteEnclosures,
+		PermMoveAnimals, PermRequestTransfers, PermApproveTransfers,
+		PermReadAudit, PermReadMedical,
+	},
+}
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleKeeper, RoleVet, RoleCurator, RoleAdmin, RolePublic:
+		return true
+	}
+	return false
+}
+
+func (r Role) Can(permission Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanApproveTransfers - подписывать заявки на перемещение могут кураторы и администраторы
+func (r Role) CanApproveTransfers() bool {
+	return r.Can(PermApproveTransfers)
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User - сотрудник, который входит в систему.
+// Хеш пароля не попадает ни в ответы API, ни в журнал аудита.
+type User struct {
+	ID           uuid.UUID `json:"ID"`
+	Username     string    `json:"username"`
+	Role         Role      `json:"role"`
+	Disabled     bool      `json:"disabled"`
+	CreatedAt    time.Time `json:"createdAt"`
+	PasswordHash string    `json:"-"`
+	// TokenGeneration - поколение токенов: токены, выданные до смены пароля,
+	// несут прежнее значение и больше не принимаются
+	TokenGeneration int `json:"-"`
+	Version         int `json:"version"`
+}
+
+const MinPasswordLength = 8
+
+func NewUser(username string, role Role, passwordHash string) (*User, error) {
+	if username == "" {
+		return nil, errors.New("имя пользователя не может быть пустым")
+	}
+	if !role.IsValid() {
+		return nil, errors.New("неизвестная роль")
+	}
+
+	return &User{
+		ID:           uuid.New(),
+		Username:     username,
+		Role:         role,
+		CreatedAt:    time.Now(),
+		PasswordHash: passwordHash,
+	}, nil
+}
+
+// SetPassword - новый хеш пароля; все выданные раньше токены перестают действовать
+func (u *User) SetPassword(passwordHash string) {
+	u.PasswordHash = passwordHash
+	u.TokenGeneration++
+}
+
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return errors.New("пароль должен быть не короче 8 символов")
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package model
+
+// AnyVersion - версия не проверяется (клиент не прислал If-Match)
+const AnyVersion = 0
+
+// CheckVersion - сравнивает ожидаемую версию записи с текущей
+func CheckVersion(expected int, current int) error {
+	if expected != AnyVersion && expected != current {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Заголовки исходящих вебхуков
+const (
+	WebhookEventHeader     = "X-Zoo-Event"
+	WebhookDeliveryHeader  = "X-Zoo-Delivery"
+	WebhookSignatureHeader = "X-Zoo-Signature"
+)
+
+// WebhookSubscription - внешняя система, которой отправляются события.
+// Пустой EventTypes означает все события. Секрет не попадает ни в ответы API,
+// ни в журнал аудита: его показывают один раз при создании.
+type WebhookSubscription struct {
+	ID         uuid.UUID   `json:"ID"`
+	URL        string      `json:"url"`
+	EventTypes []EventType `json:"eventTypes"`
+	Active     bool        `json:"active"`
+	CreatedAt  time.Time   `json:"createdAt"`
+	CreatedBy  string      `json:"createdBy"`
+	// SecretRotatedAt - когда секрет последний раз меняли; сам секрет в журнал не пишется
+	SecretRotatedAt *time.Time `json:"secretRotatedAt,omitempty"`
+	Secret          string     `json:"-"`
+	Version         int        `json:"version"`
+}
+
+const MinWebhookSecretLength = 16
+
+func NewWebhookSubscription(target string, eventTypes []EventType, secret string, createdBy string) (*WebhookSubscription, error) {
+	subscription := &WebhookSubscription{
+		ID:        uuid.New(),
+		Active:    true,
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+		Secret:    secret,
+	}
+	if err := subscription.SetURL(target); err != nil {
+		return nil, err
+	}
+	if err := subscription.SetEventTypes(eventTypes); err != nil {
+		return nil, err
+	}
+	if len(secret) < MinWebhookSecretLength {
+		return nil, fmt.Errorf("секрет должен быть не короче %d символов", MinWebhookSecretLength)
+	}
+	return subscription, nil
+}
+
+// SetURL - принимает только абсолютные http(s) адреса
+func (s *WebhookSubscription) SetURL(target string) error {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("адрес вебхука должен быть абсолютным http(s) URL")
+	}
+	s.URL = target
+	return nil
+}
+
+func (s *WebhookSubscription) SetEventTypes(eventTypes []EventType) error {
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return fmt.Errorf("неизвестный тип события %q", eventType)
+		}
+	}
+	s.EventTypes = append([]EventType{}, eventTypes...)
+	return nil
+}
+
+func (s *WebhookSubscription) RotateSecret(secret string) error {
+	if len(secret) < MinWebhookSecretLength {
+		return fmt.Errorf("секрет должен быть не короче %d символов", MinWebhookSecretLength)
+	}
+	now := time.Now()
+	s.Secret = secret
+	s.SecretRotatedAt = &now
+	return nil
+}
+
+// Wants - нужно ли отправлять подписчику событие такого типа
+func (s WebhookSubscription) Wants(eventType EventType) bool {
+	return s.Active && (len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, eventType))
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending    DeliveryStatus = "pending"
+	DeliveryInProgress DeliveryStatus = "delivering"
+	DeliveryDelivered  DeliveryStatus = "delivered"
+	// DeliveryDead - попытки исчерпаны, доставка лежит в списке недоставленных
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// maxAttemptLog - сколько последних попыток хранится у доставки
+const maxAttemptLog = 20
+
+// WebhookAttempt - одна попытка отправки
+type WebhookAttempt struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// WebhookDelivery - отправка одного события одному подписчику.
+// AttemptCount считает попытки с последней постановки в очередь,
+// Attempts - журнал всех попыток.
+type WebhookDelivery struct {
+	ID             uuid.UUID        `json:"ID"`
+	SubscriptionID uuid.UUID        `json:"subscriptionID"`
+	EventID        int64            `json:"eventID"`
+	EventType      EventType        `json:"eventType"`
+	Status         DeliveryStatus   `json:"status"`
+	AttemptCount   int              `json:"attemptCount"`
+	NextAttemptAt  *time.Time       `json:"nextAttemptAt,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	Attempts       []WebhookAttempt `json:"attempts"`
+	Payload        json.RawMessage  `json:"payload"`
+}
+
+func NewWebhookDelivery(subscriptionID uuid.UUID, event ZooEvent, payload json.RawMessage) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Status:         DeliveryPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+		Payload:        payload,
+	}
+}
+
+// Record - учитывает попытку; nextAttempt == nil означает, что повторов не будет
+func (d *WebhookDelivery) Record(attempt WebhookAttempt, delivered bool, nextAttempt *time.Time) {
+	d.AttemptCount++
+	d.Attempts = append(d.Attempts, attempt)
+	if len(d.Attempts) > maxAttemptLog {
+		d.Attempts = d.Attempts[len(d.Attempts)-maxAttemptLog:]
+	}
+	d.NextAttemptAt = nextAttempt
+	switch {
+	case delivered:
+		d.Status = DeliveryDelivered
+	case nextAttempt == nil:
+		d.Status = DeliveryDead
+	default:
+		d.Status = DeliveryPending
+	}
+}
+
+// Interrupt - отправку прервала остановка сервера: доставка возвращается
+// в очередь, а попытка не учитывается
+func (d *WebhookDelivery) Interrupt() {
+	now := time.Now()
+	d.Status = DeliveryPending
+	d.NextAttemptAt = &now
+}
+
+// Requeue - возвращает недоставленное событие в очередь с новым счётчиком попыток
+func (d *WebhookDelivery) Requeue() error {
+	if d.Status != DeliveryDead {
+		return ErrInvalidTransition
+	}
+	now := time.Now()
+	d.Status = DeliveryPending
+	d.AttemptCount = 0
+	d.NextAttemptAt = &now
+	return nil
+}
+
+// SignWebhook - значение заголовка X-Zoo-Signature: "t=<unix>,v1=<hex>",
+// где v1 - HMAC-SHA256 секрета от "<unix>.<тело>"
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + unix + ",v1=" + webhookMAC(secret, unix, body)
+}
+
+// VerifyWebhookSignature - проверка подписи на стороне получателя.
+// tolerance ограничивает возраст подписи, чтобы перехваченный запрос нельзя было повторить позже.
+func VerifyWebhookSignature(secret string, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var unix, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signature = value
+		}
+	}
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || signature == "" {
+		return errors.New("некорректный заголовок подписи")
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return errors.New("подпись устарела")
+	}
+	expected := webhookMAC(secret, unix, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("подпись не совпадает")
+	}
+	return nil
+}
+
+func webhookMAC(secret string, unix string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
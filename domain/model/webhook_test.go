@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	body := []byte(`{"id":1,"type":"animal.created"}`)
+	signedAt := time.Unix(1_700_000_000, 0)
+	header := SignWebhook(secret, signedAt, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "valid", secret: secret, header: header, body: body, now: signedAt.Add(time.Minute)},
+		{name: "tampered body", secret: secret, header: header, body: []byte(`{"id":2,"type":"animal.created"}`), now: signedAt, wantErr: true},
+		{name: "other secret", secret: "whsec_fedcba9876543210", header: header, body: body, now: signedAt, wantErr: true},
+		{name: "expired", secret: secret, header: header, body: body, now: signedAt.Add(6 * time.Minute), wantErr: true},
+		{name: "from the future", secret: secret, header: header, body: body, now: signedAt.Add(-6 * time.Minute), wantErr: true},
+		{name: "malformed header", secret: secret, header: "v1=abc", body: body, now: signedAt, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(tt.secret, tt.header, tt.body, 5*time.Minute, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyWebhookSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookDeliveryRequeue(t *testing.T) {
+	delivery := NewWebhookDelivery(uuid.New(), ZooEvent{ID: 1, Type: EventAnimalCreated}, []byte(`{}`))
+	if err := delivery.Requeue(); err != ErrInvalidTransition {
+		t.Fatalf("Requeue() of a pending delivery error = %v, want %v", err, ErrInvalidTransition)
+	}
+
+	delivery.Record(WebhookAttempt{At: time.Now(), StatusCode: 500}, false, nil)
+	if delivery.Status != DeliveryDead {
+		t.Fatalf("status after the last failed attempt = %q, want %q", delivery.Status, DeliveryDead)
+	}
+	if err := delivery.Requeue(); err != nil {
+		t.Fatalf("Requeue() error = %v", err)
+	}
+	if delivery.Status != DeliveryPending || delivery.AttemptCount != 0 || delivery.NextAttemptAt == nil {
+		t.Fatalf("after Requeue() status = %q, attempts = %d, next = %v", delivery.Status, delivery.AttemptCount, delivery.NextAttemptAt)
+	}
+	if len(delivery.Attempts) != 1 {
+		t.Fatalf("Requeue() must keep the attempt log, got %d attempts", len(delivery.Attempts))
+	}
+}
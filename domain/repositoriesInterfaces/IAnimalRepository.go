@@ -8,8 +8,14 @@ import (
 
 type IAnimalRepository interface {
 	Save(animal model.Animal) error
+	// FindByID и FindAll не видят удалённых животных (model.Deletion)
 	FindByID(id uuid.UUID) (*model.Animal, error)
 	FindAll() ([]model.Animal, error)
+	// FindDeletedByID и FindDeleted - только удалённые, для восстановления и очистки
+	FindDeletedByID(id uuid.UUID) (*model.Animal, error)
+	FindDeleted() ([]model.Animal, error)
+	// Delete - удаляет окончательно; обычное удаление - Save с отметкой Deletion
 	Delete(id uuid.UUID) error
+	// AnimalCount - сколько животных сейчас в зоопарке, без выбывших
 	AnimalCount() int
 }
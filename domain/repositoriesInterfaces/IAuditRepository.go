@@ -0,0 +1,13 @@
+package repositoriesinterfaces
+
+import "kpo-mini-dz2/domain/model"
+
+// IAuditRepository - журнал изменений, записи только добавляются.
+// Add сам связывает новые записи в цепочку хешей.
+type IAuditRepository interface {
+	Add(entries ...model.AuditEntry) error
+	// Find - записи, подходящие под фильтр, от новых к старым
+	Find(filter model.AuditFilter) ([]model.AuditEntry, error)
+	// All - весь журнал по порядку, для проверки цепочки и выгрузки
+	All() ([]model.AuditEntry, error)
+}
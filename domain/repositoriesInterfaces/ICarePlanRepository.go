@@ -0,0 +1,17 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// ICarePlanRepository - планы профилактики по видам
+type ICarePlanRepository interface {
+	Save(plan model.CarePlan) error
+	FindByID(id uuid.UUID) (*model.CarePlan, error)
+	// FindBySpecies - название вида без учёта регистра
+	FindBySpecies(species string) (*model.CarePlan, error)
+	FindAll() ([]model.CarePlan, error)
+	Delete(id uuid.UUID) error
+}
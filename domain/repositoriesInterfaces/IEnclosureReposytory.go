@@ -6,12 +6,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// IEnclosureRepository - поиск не видит удалённых вольеров (model.Deletion),
+// кроме FindDeletedByID и FindDeleted; название удалённого вольера свободно
 type IEnclosureRepository interface {
 	Save(enclosure model.Enclosure) error
 	FindByID(id uuid.UUID) (*model.Enclosure, error)
+	// FindByName - model.ErrEnclosureNotFound, если вольера с таким названием нет
+	FindByName(name string) (*model.Enclosure, error)
 	FindAll() ([]model.Enclosure, error)
 	FindByType(animalType model.AnimalType) ([]model.Enclosure, error)
 	FindWithAvailableSpace(minSpace int) ([]model.Enclosure, error)
+	FindDeletedByID(id uuid.UUID) (*model.Enclosure, error)
+	FindDeleted() ([]model.Enclosure, error)
 	Update(enclosure model.Enclosure) error
+	// Delete - удаляет окончательно
 	Delete(id uuid.UUID) error
 }
@@ -7,10 +7,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// IFeedingScheduleRepository - Get* не видят удалённых кормлений (model.Deletion),
+// кроме GetDeletedScheduleByID и GetDeletedSchedules.
+// UpdateSchedule сохраняет и удалённые: так их удаляют и восстанавливают.
 type IFeedingScheduleRepository interface {
 	AddSchedule(schedule model.FeedingSchedule) error
+	GetScheduleByID(id uuid.UUID) (*model.FeedingSchedule, error)
 	GetSchedulesByAnimalID(animalID uuid.UUID) ([]model.FeedingSchedule, error)
 	GetAllSchedules() (map[uuid.UUID][]model.FeedingSchedule, error)
+	GetDeletedScheduleByID(id uuid.UUID) (*model.FeedingSchedule, error)
+	GetDeletedSchedules() ([]model.FeedingSchedule, error)
+	UpdateSchedule(schedule model.FeedingSchedule) error
+	// RemoveSchedule, ClearSchedules и DeleteSchedule удаляют окончательно
 	RemoveSchedule(animalID uuid.UUID, feedingTime time.Time) error
 	ClearSchedules(animalID uuid.UUID) error
+	DeleteSchedule(id uuid.UUID) error
 }
@@ -0,0 +1,17 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"time"
+)
+
+// IIdempotencyRepository - ключи идемпотентности с сохранёнными ответами
+type IIdempotencyRepository interface {
+	// Reserve - занимает ключ под новый запрос. Если ключ уже занят и не истёк,
+	// ничего не меняет и возвращает существующую запись.
+	Reserve(record model.IdempotencyRecord, now time.Time) (*model.IdempotencyRecord, error)
+	// Complete - сохраняет ответ для занятого ключа
+	Complete(record model.IdempotencyRecord) error
+	// Release - освобождает ключ, например если запрос завершился ошибкой сервера
+	Release(key string) error
+}
@@ -0,0 +1,20 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// IMedicalRecordRepository - медицинские карты: случаи меняются с проверкой
+// версии, записи только добавляются
+type IMedicalRecordRepository interface {
+	SaveCase(medicalCase model.MedicalCase) error
+	FindCaseByID(id uuid.UUID) (*model.MedicalCase, error)
+	AddEntry(entry model.MedicalEntry) error
+	// FindByAnimalID - карта животного; у животного без записей она пустая
+	FindByAnimalID(animalID uuid.UUID) (*model.MedicalRecord, error)
+	FindOpenCases() ([]model.MedicalCase, error)
+	// DeleteByAnimalID - окончательно удаляет карту животного вместе с животным
+	DeleteByAnimalID(animalID uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// IMovementRepository - история перемещений, записи только добавляются;
+// удаляются лишь вместе с самим животным
+type IMovementRepository interface {
+	Add(movement model.Movement) error
+	FindByAnimalID(animalID uuid.UUID) ([]model.Movement, error)
+	FindAll() ([]model.Movement, error)
+	DeleteByAnimalID(animalID uuid.UUID) error
+}
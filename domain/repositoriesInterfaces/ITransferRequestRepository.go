@@ -0,0 +1,17 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type ITransferRequestRepository interface {
+	Save(request model.TransferRequest) error
+	FindByID(id uuid.UUID) (*model.TransferRequest, error)
+	FindAll() ([]model.TransferRequest, error)
+	// FindPendingByEnclosure - незакрытые заявки, где вольер - источник или назначение
+	FindPendingByEnclosure(enclosureID uuid.UUID) ([]model.TransferRequest, error)
+	// DeleteByAnimalID - окончательно удаляет заявки животного вместе с животным
+	DeleteByAnimalID(animalID uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package repositoriesinterfaces
+
+import "context"
+
+// IUnitOfWork - набор репозиториев, изменения в которых применяются вместе.
+// Пока не вызван Commit, изменения видны только внутри транзакции.
+type IUnitOfWork interface {
+	Animals() IAnimalRepository
+	Enclosures() IEnclosureRepository
+	FeedingSchedules() IFeedingScheduleRepository
+	Movements() IMovementRepository
+	TransferRequests() ITransferRequestRepository
+	Users() IUserRepository
+	Webhooks() IWebhookRepository
+	MedicalRecords() IMedicalRecordRepository
+	CarePlans() ICarePlanRepository
+	Commit() error
+	// Rollback отменяет изменения; после Commit ничего не делает,
+	// поэтому его удобно вызывать через defer
+	Rollback() error
+}
+
+// IUnitOfWorkFactory - открывает транзакции; ctx несёт автора изменений
+// и ID запроса для журнала аудита и логов
+type IUnitOfWorkFactory interface {
+	Begin(ctx context.Context) (IUnitOfWork, error)
+	// BeginRead - транзакция только для чтения: все её чтения видят одно
+	// и то же зафиксированное состояние. Commit возвращает ErrReadOnlyTransaction,
+	// завершается она через Rollback. Открывать в ней другие транзакции нельзя.
+	BeginRead(ctx context.Context) (IUnitOfWork, error)
+}
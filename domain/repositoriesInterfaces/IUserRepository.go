@@ -0,0 +1,16 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// IUserRepository - сотрудники; имя пользователя уникально.
+// Пользователей не удаляют, а отключают, чтобы записи аудита ссылались на существующих.
+type IUserRepository interface {
+	Save(user model.User) error
+	FindByID(id uuid.UUID) (*model.User, error)
+	FindByUsername(username string) (*model.User, error)
+	FindAll() ([]model.User, error)
+}
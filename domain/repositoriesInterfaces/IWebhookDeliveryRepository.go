@@ -0,0 +1,22 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IWebhookDeliveryRepository - очередь и журнал доставок вебхуков
+type IWebhookDeliveryRepository interface {
+	Add(delivery model.WebhookDelivery) error
+	Save(delivery model.WebhookDelivery) error
+	FindByID(id uuid.UUID) (*model.WebhookDelivery, error)
+	// FindBySubscription - доставки подписки, от новых к старым
+	FindBySubscription(subscriptionID uuid.UUID, limit int) ([]model.WebhookDelivery, error)
+	// FindDead - доставки, исчерпавшие попытки
+	FindDead() ([]model.WebhookDelivery, error)
+	// ClaimDue - забирает до limit доставок, время которых наступило,
+	// и помечает их отправляемыми, чтобы их не взяли повторно
+	ClaimDue(now time.Time, limit int) ([]model.WebhookDelivery, error)
+}
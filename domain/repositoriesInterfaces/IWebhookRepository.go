@@ -0,0 +1,15 @@
+package repositoriesinterfaces
+
+import (
+	"kpo-mini-dz2/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// IWebhookRepository - подписки внешних систем на события
+type IWebhookRepository interface {
+	Save(subscription model.WebhookSubscription) error
+	FindByID(id uuid.UUID) (*model.WebhookSubscription, error)
+	FindAll() ([]model.WebhookSubscription, error)
+	Delete(id uuid.UUID) error
+}
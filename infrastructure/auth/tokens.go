@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var errInvalidToken = errors.New("недействительный токен")
+
+// HMACTokenIssuer - токены вида base64(данные).base64(HMAC-SHA256).
+// В токене только ID пользователя, поколение токенов и срок действия: роль,
+// блокировка и поколение проверяются по репозиторию на каждом запросе.
+type HMACTokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+type tokenClaims struct {
+	Subject    uuid.UUID `json:"sub"`
+	Generation int       `json:"gen"`
+	IssuedAt   int64     `json:"iat"`
+	ExpiresAt  int64     `json:"exp"`
+}
+
+func NewHMACTokenIssuer(secret []byte, ttl time.Duration) *HMACTokenIssuer {
+	return &HMACTokenIssuer{secret: secret, ttl: ttl}
+}
+
+// GenerateSecret - случайный секрет; токены, подписанные им, не переживут перезапуск
+func GenerateSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	return secret, err
+}
+
+func (i *HMACTokenIssuer) Issue(userID uuid.UUID, generation int, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(i.ttl)
+	payload, err := json.Marshal(tokenClaims{Subject: userID, Generation: generation, IssuedAt: now.Unix(), ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + i.sign(encoded), expiresAt, nil
+}
+
+func (i *HMACTokenIssuer) Parse(token string, now time.Time) (uuid.UUID, int, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(i.sign(encoded))) {
+		return uuid.Nil, 0, errInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return uuid.Nil, 0, errInvalidToken
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return uuid.Nil, 0, errInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return uuid.Nil, 0, errors.New("срок действия токена истёк")
+	}
+	return claims.Subject, claims.Generation, nil
+}
+
+func (i *HMACTokenIssuer) sign(encoded string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestHMACTokenIssuer(t *testing.T) {
+	issuer := NewHMACTokenIssuer([]byte("secret"), time.Hour)
+	now := time.Now()
+	userID := uuid.New()
+	token, expiresAt, err := issuer.Issue(userID, 3, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expiresAt = %v, want %v", expiresAt, now.Add(time.Hour))
+	}
+
+	gotID, generation, err := issuer.Parse(token, now.Add(time.Minute))
+	if err != nil || gotID != userID || generation != 3 {
+		t.Fatalf("Parse() = %s, %d, %v, want %s, 3", gotID, generation, err, userID)
+	}
+
+	tests := map[string]struct {
+		issuer *HMACTokenIssuer
+		token  string
+		at     time.Time
+	}{
+		"expired":      {issuer: issuer, token: token, at: now.Add(time.Hour)},
+		"other secret": {issuer: NewHMACTokenIssuer([]byte("other"), time.Hour), token: token, at: now},
+		"tampered":     {issuer: issuer, token: "e30" + token[3:], at: now},
+		"no signature": {issuer: issuer, token: "e30", at: now},
+	}
+	for name, tt := range tests {
+		if _, _, err := tt.issuer.Parse(tt.token, tt.at); err == nil {
+			t.Errorf("%s: Parse() accepted the token", name)
+		}
+	}
+}
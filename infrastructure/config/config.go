@@ -0,0 +1,477 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Config - настройки приложения.
+// Источники применяются по порядку, каждый следующий перекрывает предыдущий:
+// значения по умолчанию, файл (YAML или JSON), переменные окружения ZOO_*, флаги.
+type Config struct {
+	ListenAddr      string            `yaml:"listenAddr"`
+	ShutdownTimeout time.Duration     `yaml:"shutdownTimeout"`
+	Storage         StorageConfig     `yaml:"storage"`
+	Timezone        string            `yaml:"timezone"`
+	LogLevel        string            `yaml:"logLevel"`
+	LogFormat       string            `yaml:"logFormat"`
+	CORS            CORSConfig        `yaml:"cors"`
+	Scheduler       SchedulerConfig   `yaml:"scheduler"`
+	Features        FeaturesConfig    `yaml:"features"`
+	Audit           AuditConfig       `yaml:"audit"`
+	Auth            AuthConfig        `yaml:"auth"`
+	Idempotency     IdempotencyConfig `yaml:"idempotency"`
+	Archive         ArchiveConfig     `yaml:"archive"`
+	Events          EventsConfig      `yaml:"events"`
+	Webhooks        WebhooksConfig    `yaml:"webhooks"`
+	GRPC            GRPCConfig        `yaml:"grpc"`
+	// Fixtures - описание зоопарка (YAML/JSON) или demo для встроенного;
+	// загружается при запуске, только если в зоопарке нет ни животных, ни вольеров
+	Fixtures string `yaml:"fixtures"`
+}
+
+type StorageConfig struct {
+	// Backend - memory или file
+	Backend       string        `yaml:"backend"`
+	Path          string        `yaml:"path"`
+	FlushInterval time.Duration `yaml:"flushInterval"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+	AllowedMethods []string `yaml:"allowedMethods"`
+	AllowedHeaders []string `yaml:"allowedHeaders"`
+}
+
+type SchedulerConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+type AuditConfig struct {
+	// SigningKeyPath - ключ Ed25519 для подписи выгрузок журнала; создаётся, если файла нет.
+	// Пустой путь - новый ключ при каждом запуске.
+	SigningKeyPath string `yaml:"signingKeyPath"`
+}
+
+type AuthConfig struct {
+	// TokenSecret - ключ подписи токенов; пустой - случайный при каждом запуске,
+	// и после перезапуска всем придётся войти заново
+	TokenSecret string        `yaml:"tokenSecret"`
+	TokenTTL    time.Duration `yaml:"tokenTTL"`
+	// Администратор, который создаётся при первом запуске, пока пользователей нет.
+	// Без пароля он будет сгенерирован и один раз выведен в stderr, минуя лог.
+	AdminUsername string `yaml:"adminUsername"`
+	AdminPassword string `yaml:"adminPassword"`
+}
+
+const minTokenSecretLength = 32
+
+type IdempotencyConfig struct {
+	// Retention - сколько хранится ответ для ключа Idempotency-Key
+	Retention time.Duration `yaml:"retention"`
+}
+
+// ArchiveConfig - мягко удалённые животные, вольеры и кормления
+// можно восстановить в течение Retention, потом они удаляются окончательно
+type ArchiveConfig struct {
+	Retention time.Duration `yaml:"retention"`
+	// PurgeInterval - как часто ищутся записи с истёкшим сроком
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+}
+
+type EventsConfig struct {
+	// ReplaySize - сколько последних событий хранится для продолжения по Last-Event-ID
+	ReplaySize int `yaml:"replaySize"`
+	// Heartbeat - как часто слать комментарий в пустой поток, чтобы прокси не закрыли соединение
+	Heartbeat time.Duration `yaml:"heartbeat"`
+}
+
+type WebhooksConfig struct {
+	// MaxAttempts - попыток до перевода доставки в недоставленные
+	MaxAttempts int `yaml:"maxAttempts"`
+	// InitialBackoff удваивается после каждой неудачи, но не больше MaxBackoff
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+	Timeout        time.Duration `yaml:"timeout"`
+	// Workers - одновременных отправок
+	Workers int `yaml:"workers"`
+	// LogSize - сколько доставок хранится в журнале
+	LogSize int `yaml:"logSize"`
+}
+
+type GRPCConfig struct {
+	Enabled    bool   `yaml:"enabled"`
+	ListenAddr string `yaml:"listenAddr"`
+}
+
+type FeaturesConfig struct {
+	Swagger          bool `yaml:"swagger"`
+	TransferRequests bool `yaml:"transferRequests"`
+	BatchTransfers   bool `yaml:"batchTransfers"`
+}
+
+const (
+	BackendMemory = "memory"
+	BackendFile   = "file"
+)
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+func Default() Config {
+	return Config{
+		ListenAddr:      ":8080",
+		ShutdownTimeout: 15 * time.Second,
+		Storage: StorageConfig{
+			Backend:       BackendMemory,
+			Path:          "zoo-data.json",
+			FlushInterval: 30 * time.Second,
+		},
+		Timezone:  "Local",
+		LogLevel:  "info",
+		LogFormat: LogFormatJSON,
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "If-Match", "Idempotency-Key", "X-Request-ID"},
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:  true,
+			Interval: time.Minute,
+		},
+		Auth: AuthConfig{
+			TokenTTL:      12 * time.Hour,
+			AdminUsername: "admin",
+		},
+		Idempotency: IdempotencyConfig{
+			Retention: 24 * time.Hour,
+		},
+		Archive: ArchiveConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Events: EventsConfig{
+			ReplaySize: 1000,
+			Heartbeat:  15 * time.Second,
+		},
+		Webhooks: WebhooksConfig{
+			MaxAttempts:    8,
+			InitialBackoff: 10 * time.Second,
+			MaxBackoff:     time.Hour,
+			Timeout:        10 * time.Second,
+			Workers:        4,
+			LogSize:        10000,
+		},
+		GRPC: GRPCConfig{
+			Enabled:    true,
+			ListenAddr: ":9090",
+		},
+		Features: FeaturesConfig{
+			Swagger:          true,
+			TransferRequests: true,
+			BatchTransfers:   true,
+		},
+	}
+}
+
+// Load - собирает конфигурацию из всех источников и проверяет её.
+// args - аргументы командной строки без имени программы.
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("zoo", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("ZOO_CONFIG"), "path to YAML/JSON config file (env ZOO_CONFIG)")
+	listenAddr := fs.String("listen", "", "listen address, e.g. :8080")
+	grpcListenAddr := fs.String("grpc-listen", "", "gRPC listen address, e.g. :9090")
+	backend := fs.String("storage", "", "storage backend: memory or file")
+	storagePath := fs.String("storage-path", "", "data file for the file backend")
+	timezone := fs.String("timezone", "", "IANA timezone, e.g. Europe/Moscow")
+	logLevel := fs.String("log-level", "", "debug, info, warn or error")
+	logFormat := fs.String("log-format", "", "json or text")
+	corsOrigins := fs.String("cors-origins", "", "comma-separated allowed CORS origins")
+	auditSigningKey := fs.String("audit-signing-key", "", "Ed25519 key file for signing audit exports")
+	schedulerInterval := fs.Duration("scheduler-interval", 0, "how often feeding times and preventive care due dates are checked")
+	fixtures := fs.String("fixtures", "", "fixture file to load into an empty zoo at startup, or demo")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configPath != "" {
+		if err := cfg.loadFile(*configPath); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	// Флаги перекрывают остальное, только если заданы явно
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen":
+			cfg.ListenAddr = *listenAddr
+		case "grpc-listen":
+			cfg.GRPC.ListenAddr = *grpcListenAddr
+		case "storage":
+			cfg.Storage.Backend = *backend
+		case "storage-path":
+			cfg.Storage.Path = *storagePath
+		case "timezone":
+			cfg.Timezone = *timezone
+		case "log-level":
+			cfg.LogLevel = *logLevel
+		case "log-format":
+			cfg.LogFormat = *logFormat
+		case "cors-origins":
+			cfg.CORS.AllowedOrigins = splitList(*corsOrigins)
+		case "audit-signing-key":
+			cfg.Audit.SigningKeyPath = *auditSigningKey
+		case "scheduler-interval":
+			cfg.Scheduler.Interval = *schedulerInterval
+		case "fixtures":
+			cfg.Fixtures = *fixtures
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("не удалось прочитать конфигурацию: %w", err)
+	}
+	// JSON - подмножество YAML, поэтому подходят оба формата
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("ошибка в файле конфигурации %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	var errs []error
+	str := func(name string, target *string) {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = value
+		}
+	}
+	list := func(name string, target *[]string) {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = splitList(value)
+		}
+	}
+	boolean := func(name string, target *bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			*target = parsed
+		}
+	}
+	integer := func(name string, target *int) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			*target = parsed
+		}
+	}
+	duration := func(name string, target *time.Duration) {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			*target = parsed
+		}
+	}
+
+	str("ZOO_LISTEN_ADDR", &c.ListenAddr)
+	duration("ZOO_SHUTDOWN_TIMEOUT", &c.ShutdownTimeout)
+	str("ZOO_STORAGE_BACKEND", &c.Storage.Backend)
+	str("ZOO_STORAGE_PATH", &c.Storage.Path)
+	duration("ZOO_STORAGE_FLUSH_INTERVAL", &c.Storage.FlushInterval)
+	str("ZOO_TIMEZONE", &c.Timezone)
+	str("ZOO_LOG_LEVEL", &c.LogLevel)
+	str("ZOO_LOG_FORMAT", &c.LogFormat)
+	list("ZOO_CORS_ALLOWED_ORIGINS", &c.CORS.AllowedOrigins)
+	list("ZOO_CORS_ALLOWED_METHODS", &c.CORS.AllowedMethods)
+	list("ZOO_CORS_ALLOWED_HEADERS", &c.CORS.AllowedHeaders)
+	boolean("ZOO_SCHEDULER_ENABLED", &c.Scheduler.Enabled)
+	duration("ZOO_SCHEDULER_INTERVAL", &c.Scheduler.Interval)
+	boolean("ZOO_FEATURE_SWAGGER", &c.Features.Swagger)
+	boolean("ZOO_FEATURE_TRANSFER_REQUESTS", &c.Features.TransferRequests)
+	boolean("ZOO_FEATURE_BATCH_TRANSFERS", &c.Features.BatchTransfers)
+	str("ZOO_AUDIT_SIGNING_KEY", &c.Audit.SigningKeyPath)
+	str("ZOO_AUTH_TOKEN_SECRET", &c.Auth.TokenSecret)
+	duration("ZOO_AUTH_TOKEN_TTL", &c.Auth.TokenTTL)
+	str("ZOO_AUTH_ADMIN_USERNAME", &c.Auth.AdminUsername)
+	str("ZOO_AUTH_ADMIN_PASSWORD", &c.Auth.AdminPassword)
+	duration("ZOO_IDEMPOTENCY_RETENTION", &c.Idempotency.Retention)
+	duration("ZOO_ARCHIVE_RETENTION", &c.Archive.Retention)
+	duration("ZOO_ARCHIVE_PURGE_INTERVAL", &c.Archive.PurgeInterval)
+	integer("ZOO_EVENTS_REPLAY_SIZE", &c.Events.ReplaySize)
+	duration("ZOO_EVENTS_HEARTBEAT", &c.Events.Heartbeat)
+	integer("ZOO_WEBHOOKS_MAX_ATTEMPTS", &c.Webhooks.MaxAttempts)
+	duration("ZOO_WEBHOOKS_INITIAL_BACKOFF", &c.Webhooks.InitialBackoff)
+	duration("ZOO_WEBHOOKS_MAX_BACKOFF", &c.Webhooks.MaxBackoff)
+	duration("ZOO_WEBHOOKS_TIMEOUT", &c.Webhooks.Timeout)
+	integer("ZOO_WEBHOOKS_WORKERS", &c.Webhooks.Workers)
+	integer("ZOO_WEBHOOKS_LOG_SIZE", &c.Webhooks.LogSize)
+	boolean("ZOO_GRPC_ENABLED", &c.GRPC.Enabled)
+	str("ZOO_GRPC_LISTEN_ADDR", &c.GRPC.ListenAddr)
+	str("ZOO_FIXTURES", &c.Fixtures)
+
+	return errors.Join(errs...)
+}
+
+// Validate - проверяет конфигурацию целиком и возвращает все ошибки сразу
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		errs = append(errs, fmt.Errorf("listenAddr: %w", err))
+	}
+	if c.GRPC.Enabled {
+		if _, _, err := net.SplitHostPort(c.GRPC.ListenAddr); err != nil {
+			errs = append(errs, fmt.Errorf("grpc.listenAddr: %w", err))
+		} else if c.GRPC.ListenAddr == c.ListenAddr {
+			errs = append(errs, errors.New("grpc.listenAddr: должен отличаться от listenAddr"))
+		}
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdownTimeout: должен быть больше нуля"))
+	}
+	switch c.Storage.Backend {
+	case BackendMemory:
+	case BackendFile:
+		if c.Storage.Path == "" {
+			errs = append(errs, errors.New("storage.path: обязателен для файлового хранилища"))
+		}
+		if c.Storage.FlushInterval <= 0 {
+			errs = append(errs, errors.New("storage.flushInterval: должен быть больше нуля"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("storage.backend: неизвестное хранилище %q", c.Storage.Backend))
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		errs = append(errs, fmt.Errorf("timezone: %w", err))
+	}
+	if _, err := c.SlogLevel(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.LogFormat != LogFormatJSON && c.LogFormat != LogFormatText {
+		errs = append(errs, fmt.Errorf("logFormat: неизвестный формат %q", c.LogFormat))
+	}
+	if c.Auth.TokenSecret != "" && len(c.Auth.TokenSecret) < minTokenSecretLength {
+		errs = append(errs, fmt.Errorf("auth.tokenSecret: должен быть не короче %d символов", minTokenSecretLength))
+	}
+	if c.Auth.TokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.tokenTTL: должен быть больше нуля"))
+	}
+	if c.Auth.AdminUsername == "" {
+		errs = append(errs, errors.New("auth.adminUsername: не может быть пустым"))
+	}
+	if c.Idempotency.Retention <= 0 {
+		errs = append(errs, errors.New("idempotency.retention: должен быть больше нуля"))
+	}
+	if c.Archive.Retention <= 0 {
+		errs = append(errs, errors.New("archive.retention: должен быть больше нуля"))
+	}
+	if c.Archive.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("archive.purgeInterval: должен быть больше нуля"))
+	}
+	if c.Events.ReplaySize < 0 {
+		errs = append(errs, errors.New("events.replaySize: не может быть отрицательным"))
+	}
+	if c.Events.Heartbeat <= 0 {
+		errs = append(errs, errors.New("events.heartbeat: должен быть больше нуля"))
+	}
+	if c.Webhooks.MaxAttempts <= 0 {
+		errs = append(errs, errors.New("webhooks.maxAttempts: должен быть больше нуля"))
+	}
+	if c.Webhooks.InitialBackoff <= 0 || c.Webhooks.MaxBackoff < c.Webhooks.InitialBackoff {
+		errs = append(errs, errors.New("webhooks: нужно 0 < initialBackoff <= maxBackoff"))
+	}
+	if c.Webhooks.Timeout <= 0 {
+		errs = append(errs, errors.New("webhooks.timeout: должен быть больше нуля"))
+	}
+	if c.Webhooks.Workers <= 0 {
+		errs = append(errs, errors.New("webhooks.workers: должен быть больше нуля"))
+	}
+	if c.Webhooks.LogSize <= 0 {
+		errs = append(errs, errors.New("webhooks.logSize: должен быть больше нуля"))
+	}
+	if c.Scheduler.Enabled && c.Scheduler.Interval <= 0 {
+		errs = append(errs, errors.New("scheduler.interval: должен быть больше нуля"))
+	}
+	if c.Fixtures != "" && c.Fixtures != "demo" {
+		if _, err := os.Stat(c.Fixtures); err != nil {
+			errs = append(errs, fmt.Errorf("fixtures: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Location - часовой пояс из настроек; конфигурация уже проверена
+func (c *Config) Location() *time.Location {
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+func (c *Config) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return 0, fmt.Errorf("logLevel: неизвестный уровень %q", c.LogLevel)
+	}
+	return level, nil
+}
+
+// String - итоговая конфигурация в YAML, печатается при старте; секреты скрыты
+func (c *Config) String() string {
+	masked := *c
+	if masked.Auth.TokenSecret != "" {
+		masked.Auth.TokenSecret = "***"
+	}
+	if masked.Auth.AdminPassword != "" {
+		masked.Auth.AdminPassword = "***"
+	}
+	data, err := yaml.Marshal(masked)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+func splitList(value string) []string {
+	result := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+// Package logging - настройка slog: формат вывода и данные запроса из context
+package logging
+
+import (
+	"context"
+	"io"
+	"kpo-mini-dz2/application/requestcontext"
+	"log/slog"
+)
+
+// NewHandler - обработчик slog: JSON или, если text, человекочитаемый текст.
+// К каждой записи, сделанной через *Context-методы, добавляются
+// request_id и actor, если они есть в ctx.
+func NewHandler(w io.Writer, text bool, level slog.Level) slog.Handler {
+	options := &slog.HandlerOptions{Level: level}
+	if text {
+		return &contextHandler{Handler: slog.NewTextHandler(w, options)}
+	}
+	return &contextHandler{Handler: slog.NewJSONHandler(w, options)}
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := requestcontext.RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if actor := requestcontext.ActorFrom(ctx); actor.Name != "" {
+		record.AddAttrs(slog.String("actor", actor.Name))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
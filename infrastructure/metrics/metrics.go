@@ -0,0 +1,256 @@
+// Package metrics - минимальная реализация метрик в текстовом формате Prometheus
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Collector - источник метрик для /metrics
+type Collector interface {
+	Collect(w *Writer)
+}
+
+type Registry struct {
+	mu         sync.Mutex
+	collectors []Collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) MustRegister(collectors ...Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collectors...)
+}
+
+// Handler - отдаёт все метрики в формате text/plain; version=0.0.4
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		collectors := append([]Collector{}, r.collectors...)
+		r.mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writer := &Writer{w: bufio.NewWriter(w)}
+		for _, collector := range collectors {
+			collector.Collect(writer)
+		}
+		writer.w.Flush()
+	})
+}
+
+// Label - пара имя-значение; порядок меток сохраняется
+type Label struct {
+	Name  string
+	Value string
+}
+
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+type Writer struct {
+	w *bufio.Writer
+}
+
+func (w *Writer) header(name string, help string, kind string) {
+	fmt.Fprintf(w.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (w *Writer) sample(name string, labels []Label, value float64) {
+	w.w.WriteString(name)
+	if len(labels) > 0 {
+		w.w.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.w.WriteByte(',')
+			}
+			fmt.Fprintf(w.w, "%s=\"%s\"", label.Name, escape(label.Value))
+		}
+		w.w.WriteByte('}')
+	}
+	w.w.WriteByte(' ')
+	w.w.WriteString(formatFloat(value))
+	w.w.WriteByte('\n')
+}
+
+// CounterVec - счётчик с метками
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	labels []Label
+	value  float64
+}
+
+func NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	return &CounterVec{name: name, help: help, labels: labels, values: make(map[string]*counterValue)}
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	if !ok {
+		v = &counterValue{labels: makeLabels(c.labels, labelValues)}
+		c.values[key] = v
+	}
+	v.value += delta
+}
+
+func (c *CounterVec) Collect(w *Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w.header(c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.values) {
+		v := c.values[key]
+		w.sample(c.name, v.labels, v.value)
+	}
+}
+
+// HistogramVec - гистограмма с метками
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	values map[string]*histogramValue
+}
+
+type histogramValue struct {
+	labels []Label
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// DefBuckets - границы корзин для длительности HTTP-запросов в секундах
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+func NewHistogramVec(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{name: name, help: help, labels: labels, buckets: buckets, values: make(map[string]*histogramValue)}
+}
+
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := strings.Join(labelValues, "\xff")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{labels: makeLabels(h.labels, labelValues), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = v
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			v.counts[i]++
+		}
+	}
+	v.count++
+	v.sum += value
+}
+
+func (h *HistogramVec) Collect(w *Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	w.header(h.name, h.help, "histogram")
+	for _, key := range sortedKeys(h.values) {
+		v := h.values[key]
+		for i, bound := range h.buckets {
+			w.sample(h.name+"_bucket", withLabel(v.labels, "le", formatFloat(bound)), float64(v.counts[i]))
+		}
+		w.sample(h.name+"_bucket", withLabel(v.labels, "le", "+Inf"), float64(v.count))
+		w.sample(h.name+"_sum", v.labels, v.sum)
+		w.sample(h.name+"_count", v.labels, float64(v.count))
+	}
+}
+
+// GaugeFunc - метрика, значения которой вычисляются при каждом запросе /metrics
+type GaugeFunc struct {
+	name    string
+	help    string
+	collect func() ([]Sample, error)
+}
+
+func NewGaugeFunc(name string, help string, collect func() ([]Sample, error)) *GaugeFunc {
+	return &GaugeFunc{name: name, help: help, collect: collect}
+}
+
+func (g *GaugeFunc) Collect(w *Writer) {
+	samples, err := g.collect()
+	if err != nil {
+		slog.Error("не удалось вычислить метрику", "metric", g.name, "error", err)
+		return
+	}
+
+	w.header(g.name, g.help, "gauge")
+	for _, s := range samples {
+		w.sample(g.name, s.Labels, s.Value)
+	}
+}
+
+func makeLabels(names []string, values []string) []Label {
+	labels := make([]Label, len(names))
+	for i, name := range names {
+		if i < len(values) {
+			labels[i] = Label{Name: name, Value: values[i]}
+		} else {
+			labels[i] = Label{Name: name}
+		}
+	}
+	return labels
+}
+
+func withLabel(labels []Label, name string, value string) []Label {
+	return append(append([]Label{}, labels...), Label{Name: name, Value: value})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"kpo-mini-dz2/application/services"
+	"time"
+)
+
+// HTTPMetrics - счётчики запросов и гистограмма длительности по маршрутам chi
+type HTTPMetrics struct {
+	Requests *CounterVec
+	Duration *HistogramVec
+}
+
+func NewHTTPMetrics(reg *Registry) *HTTPMetrics {
+	m := &HTTPMetrics{
+		Requests: NewCounterVec("zoo_http_requests_total", "HTTP requests by method, route pattern and status.", "method", "route", "status"),
+		Duration: NewHistogramVec("zoo_http_request_duration_seconds", "HTTP request latency by method and route pattern.", DefBuckets, "method", "route"),
+	}
+	reg.MustRegister(m.Requests, m.Duration)
+	return m
+}
+
+// RegisterZooMetrics - доменные показатели, вычисляемые при каждом опросе
+func RegisterZooMetrics(reg *Registry, stats *services.ZooStatisticsService) {
+	reg.MustRegister(
+		NewGaugeFunc("zoo_animals", "Animals by species and health status.", func() ([]Sample, error) {
+			counts, err := stats.CountAnimalsBySpeciesAndHealth()
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]Sample, 0, len(counts))
+			for _, c := range counts {
+				samples = append(samples, Sample{
+					Labels: []Label{{Name: "species", Value: c.Species}, {Name: "health_status", Value: string(c.HealthStatus)}},
+					Value:  float64(c.Count),
+				})
+			}
+			return samples, nil
+		}),
+		NewGaugeFunc("zoo_enclosure_occupancy_ratio", "Current animal count divided by capacity, per enclosure.", func() ([]Sample, error) {
+			occupancy, err := stats.GetEnclosureOccupancy()
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]Sample, 0, len(occupancy))
+			for _, o := range occupancy {
+				samples = append(samples, Sample{
+					Labels: []Label{{Name: "enclosure_id", Value: o.EnclosureID.String()}, {Name: "type", Value: string(o.Type)}},
+					Value:  o.Ratio,
+				})
+			}
+			return samples, nil
+		}),
+		NewGaugeFunc("zoo_feedings", "Feedings that are upcoming or missed (time passed, not marked done).", func() ([]Sample, error) {
+			counts, err := stats.CountFeedings(time.Now())
+			if err != nil {
+				return nil, err
+			}
+			return []Sample{
+				{Labels: []Label{{Name: "state", Value: "upcoming"}}, Value: float64(counts.Upcoming)},
+				{Labels: []Label{{Name: "state", Value: "missed"}}, Value: float64(counts.Missed)},
+			}, nil
+		}),
+		NewGaugeFunc("zoo_transfers_per_day", "Transfers between enclosures per day over the last week.", func() ([]Sample, error) {
+			days, err := stats.CountTransfersPerDay(time.Now(), 7)
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]Sample, 0, len(days))
+			for _, d := range days {
+				samples = append(samples, Sample{Labels: []Label{{Name: "date", Value: d.Date}}, Value: float64(d.Count)})
+			}
+			return samples, nil
+		}),
+	)
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"kpo-mini-dz2/domain/model"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileSnapshotStore - файловое хранилище поверх in-memory репозиториев:
+// при старте данные читаются из JSON-файла, затем периодически сохраняются целиком.
+type FileSnapshotStore struct {
+	path  string
+	store *InMemoryStore
+
+	mu        sync.Mutex
+	lastError error
+}
+
+type snapshot struct {
+	Animals          []model.Animal                        `json:"animals"`
+	Enclosures       []model.Enclosure                     `json:"enclosures"`
+	FeedingSchedules map[uuid.UUID][]model.FeedingSchedule `json:"feedingSchedules"`
+	Movements        []model.Movement                      `json:"movements"`
+	TransferRequests []model.TransferRequest               `json:"transferRequests"`
+	Users            []userRecord                          `json:"users"`
+	Webhooks         []webhookRecord                       `json:"webhooks"`
+	// WebhookDeliveries - в порядке постановки в очередь
+	WebhookDeliveries []model.WebhookDelivery `json:"webhookDeliveries"`
+	MedicalCases      []model.MedicalCase     `json:"medicalCases"`
+	MedicalEntries    []model.MedicalEntry    `json:"medicalEntries"`
+	CarePlans         []model.CarePlan        `json:"carePlans"`
+	Audit             []model.AuditEntry      `json:"audit"`
+}
+
+// userRecord - пользователь вместе с хешем пароля и поколением токенов,
+// которые в model.User не сериализуются
+type userRecord struct {
+	model.User
+	PasswordHash    string `json:"passwordHash"`
+	TokenGeneration int    `json:"tokenGeneration,omitempty"`
+}
+
+// webhookRecord - подписка вместе с секретом, который в model.WebhookSubscription не сериализуется
+type webhookRecord struct {
+	model.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+func NewFileSnapshotStore(path string, store *InMemoryStore) *FileSnapshotStore {
+	return &FileSnapshotStore{path: path, store: store}
+}
+
+// Load - заполняет репозитории из файла; отсутствие файла - не ошибка
+func (s *FileSnapshotStore) Load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+
+	s.store.lock()
+	defer s.store.unlock()
+
+	for _, animal := range snap.Animals {
+		// В снимках до появления жизненного цикла все животные были в экспозиции
+		if animal.State == "" {
+			animal.State = model.StateOnDisplay
+		}
+		s.store.Animals.animals[animal.ID] = animal
+	}
+	for _, enclosure := range snap.Enclosures {
+		s.store.Enclosures.enclosures[enclosure.ID] = enclosure
+	}
+	for animalID, schedules := range snap.FeedingSchedules {
+		s.store.FeedingSchedules.schedules[animalID] = schedules
+	}
+	s.store.Movements.movements = append(s.store.Movements.movements, snap.Movements...)
+	for _, request := range snap.TransferRequests {
+		s.store.TransferRequests.requests[request.ID] = request
+	}
+	for _, record := range snap.Users {
+		user := record.User
+		user.PasswordHash = record.PasswordHash
+		user.TokenGeneration = record.TokenGeneration
+		s.store.Users.users[user.ID] = user
+	}
+	for _, record := range snap.Webhooks {
+		subscription := record.WebhookSubscription
+		subscription.Secret = record.Secret
+		s.store.Webhooks.subscriptions[subscription.ID] = subscription
+	}
+	for _, delivery := range snap.WebhookDeliveries {
+		// Отправка, прерванная остановкой, повторяется после запуска
+		if delivery.Status == model.DeliveryInProgress {
+			delivery.Status = model.DeliveryPending
+		}
+		s.store.WebhookDeliveries.deliveries[delivery.ID] = delivery
+		s.store.WebhookDeliveries.order = append(s.store.WebhookDeliveries.order, delivery.ID)
+	}
+	for _, medicalCase := range snap.MedicalCases {
+		s.store.MedicalRecords.cases[medicalCase.ID] = medicalCase
+	}
+	s.store.MedicalRecords.entries = append(s.store.MedicalRecords.entries, snap.MedicalEntries...)
+	for _, plan := range snap.CarePlans {
+		s.store.CarePlans.plans[plan.ID] = plan
+	}
+	s.store.Audit.entries = append(s.store.Audit.entries, snap.Audit...)
+	return nil
+}
+
+// Flush - атомарно записывает снимок всех репозиториев в файл
+func (s *FileSnapshotStore) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastError = s.write()
+	return s.lastError
+}
+
+// Check - доступно ли хранилище: каталог существует и последняя запись удалась
+func (s *FileSnapshotStore) Check() error {
+	if _, err := os.Stat(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastError
+}
+
+func (s *FileSnapshotStore) write() error {
+	data, err := json.MarshalIndent(s.takeSnapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// Run - сохраняет снимок каждые interval, пока не отменён ctx
+func (s *FileSnapshotStore) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				slog.Error("не удалось сохранить данные", "path", s.path, "error", err)
+			}
+		}
+	}
+}
+
+func (s *FileSnapshotStore) takeSnapshot() snapshot {
+	s.store.rlock()
+	defer s.store.runlock()
+
+	snap := snapshot{
+		Animals:           make([]model.Animal, 0, len(s.store.Animals.animals)),
+		Enclosures:        make([]model.Enclosure, 0, len(s.store.Enclosures.enclosures)),
+		FeedingSchedules:  make(map[uuid.UUID][]model.FeedingSchedule, len(s.store.FeedingSchedules.schedules)),
+		Movements:         append([]model.Movement{}, s.store.Movements.movements...),
+		TransferRequests:  make([]model.TransferRequest, 0, len(s.store.TransferRequests.requests)),
+		Users:             make([]userRecord, 0, len(s.store.Users.users)),
+		Webhooks:          make([]webhookRecord, 0, len(s.store.Webhooks.subscriptions)),
+		WebhookDeliveries: make([]model.WebhookDelivery, 0, len(s.store.WebhookDeliveries.order)),
+		MedicalCases:      make([]model.MedicalCase, 0, len(s.store.MedicalRecords.cases)),
+		MedicalEntries:    append([]model.MedicalEntry{}, s.store.MedicalRecords.entries...),
+		CarePlans:         make([]model.CarePlan, 0, len(s.store.CarePlans.plans)),
+		Audit:             append([]model.AuditEntry{}, s.store.Audit.entries...),
+	}
+	for _, animal := range s.store.Animals.animals {
+		snap.Animals = append(snap.Animals, animal)
+	}
+	for _, enclosure := range s.store.Enclosures.enclosures {
+		snap.Enclosures = append(snap.Enclosures, enclosure)
+	}
+	for animalID, schedules := range s.store.FeedingSchedules.schedules {
+		snap.FeedingSchedules[animalID] = append([]model.FeedingSchedule{}, schedules...)
+	}
+	for _, request := range s.store.TransferRequests.requests {
+		snap.TransferRequests = append(snap.TransferRequests, request)
+	}
+	for _, user := range s.store.Users.users {
+		snap.Users = append(snap.Users, userRecord{User: user, PasswordHash: user.PasswordHash, TokenGeneration: user.TokenGeneration})
+	}
+	for _, subscription := range s.store.Webhooks.subscriptions {
+		snap.Webhooks = append(snap.Webhooks, webhookRecord{WebhookSubscription: subscription, Secret: subscription.Secret})
+	}
+	for _, id := range s.store.WebhookDeliveries.order {
+		snap.WebhookDeliveries = append(snap.WebhookDeliveries, s.store.WebhookDeliveries.deliveries[id])
+	}
+	for _, medicalCase := range s.store.MedicalRecords.cases {
+		snap.MedicalCases = append(snap.MedicalCases, medicalCase)
+	}
+	for _, plan := range s.store.CarePlans.plans {
+		snap.CarePlans = append(snap.CarePlans, plan)
+	}
+	return snap
+}
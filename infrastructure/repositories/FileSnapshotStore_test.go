@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFileSnapshotStoreKeepsWebhookDeliveries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "zoo.json")
+	store := NewInMemoryStore(100)
+
+	subscriptionID := uuid.New()
+	var ids []uuid.UUID
+	for i := range 3 {
+		delivery := model.NewWebhookDelivery(subscriptionID, model.ZooEvent{ID: int64(i + 1), Type: model.EventAnimalCreated}, []byte(`{}`))
+		if err := store.WebhookDeliveries.Add(*delivery); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, delivery.ID)
+	}
+	dead, _ := store.WebhookDeliveries.FindByID(ids[0])
+	dead.Record(model.WebhookAttempt{At: time.Now(), StatusCode: 500, Error: "получатель ответил 500"}, false, nil)
+	if err := store.WebhookDeliveries.Save(*dead); err != nil {
+		t.Fatal(err)
+	}
+	// Вторая доставка взята в отправку, когда сервер остановился
+	if claimed, _ := store.WebhookDeliveries.ClaimDue(time.Now(), 1); len(claimed) != 1 || claimed[0].ID != ids[1] {
+		t.Fatalf("ClaimDue() = %+v, want delivery %s", claimed, ids[1])
+	}
+	if err := NewFileSnapshotStore(path, store).Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewInMemoryStore(100)
+	if err := NewFileSnapshotStore(path, loaded).Load(); err != nil {
+		t.Fatal(err)
+	}
+	letters, _ := loaded.WebhookDeliveries.FindDead()
+	if len(letters) != 1 || letters[0].ID != ids[0] || len(letters[0].Attempts) != 1 || letters[0].Attempts[0].StatusCode != 500 {
+		t.Fatalf("dead letters after load = %+v", letters)
+	}
+	interrupted, err := loaded.WebhookDeliveries.FindByID(ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if interrupted.Status != model.DeliveryPending {
+		t.Fatalf("interrupted delivery status after load = %q, want %q", interrupted.Status, model.DeliveryPending)
+	}
+
+	// Порядок очереди сохраняется: журнал подписки - от новых к старым
+	deliveries, _ := loaded.WebhookDeliveries.FindBySubscription(subscriptionID, 10)
+	if len(deliveries) != 3 || deliveries[0].ID != ids[2] || deliveries[2].ID != ids[0] {
+		t.Fatalf("deliveries after load = %+v", deliveries)
+	}
+	due, _ := loaded.WebhookDeliveries.ClaimDue(time.Now(), 10)
+	if len(due) != 2 || due[0].ID != ids[1] || due[1].ID != ids[2] {
+		t.Fatalf("ClaimDue() after load = %+v, want deliveries %s and %s", due, ids[1], ids[2])
+	}
+}
@@ -1,7 +1,6 @@
 package repositories
 
 import (
-	"errors"
 	"kpo-mini-dz2/domain/model"
 	"sync"
 
@@ -23,31 +22,60 @@ func (r *InMemoryAnimalRepository) Save(animal model.Animal) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if current, exists := r.animals[animal.ID]; exists && current.Version != animal.Version {
+		return model.ErrVersionConflict
+	}
+
+	animal.Version++
 	r.animals[animal.ID] = animal
 	return nil
 }
 
 func (r *InMemoryAnimalRepository) FindByID(id uuid.UUID) (*model.Animal, error) {
+	animal, exists := r.find(id)
+	if !exists || animal.IsDeleted() {
+		return nil, model.ErrAnimalNotFound
+	}
+	return &animal, nil
+}
+
+func (r *InMemoryAnimalRepository) FindAll() ([]model.Animal, error) {
+	return r.list(false), nil
+}
+
+func (r *InMemoryAnimalRepository) FindDeletedByID(id uuid.UUID) (*model.Animal, error) {
+	animal, exists := r.find(id)
+	if !exists || !animal.IsDeleted() {
+		return nil, model.ErrAnimalNotFound
+	}
+	return &animal, nil
+}
+
+func (r *InMemoryAnimalRepository) FindDeleted() ([]model.Animal, error) {
+	return r.list(true), nil
+}
+
+// find - животное по ID, в том числе удалённое
+func (r *InMemoryAnimalRepository) find(id uuid.UUID) (model.Animal, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	animal, exists := r.animals[id]
-	if !exists {
-		return nil, errors.New("животное не найдено")
-	}
-
-	return &animal, nil
+	return animal, exists
 }
 
-func (r *InMemoryAnimalRepository) FindAll() ([]model.Animal, error) {
+// list - удалённые животные или все остальные
+func (r *InMemoryAnimalRepository) list(deleted bool) []model.Animal {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	animals := make([]model.Animal, 0, len(r.animals))
 	for _, animal := range r.animals {
-		animals = append(animals, animal)
+		if animal.IsDeleted() == deleted {
+			animals = append(animals, animal)
+		}
 	}
-	return animals, nil
+	return animals
 }
 
 func (r *InMemoryAnimalRepository) Delete(id uuid.UUID) error {
@@ -59,8 +87,13 @@ func (r *InMemoryAnimalRepository) Delete(id uuid.UUID) error {
 }
 
 func (r *InMemoryAnimalRepository) AnimalCount() int {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	count := len(r.animals)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	count := 0
+	for _, animal := range r.animals {
+		if animal.IsPresent() && !animal.IsDeleted() {
+			count++
+		}
+	}
 	return count
 }
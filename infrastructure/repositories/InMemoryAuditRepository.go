@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"sync"
+)
+
+type InMemoryAuditRepository struct {
+	mu      sync.RWMutex
+	entries []model.AuditEntry
+}
+
+func NewInMemoryAuditRepository() *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{
+		entries: make([]model.AuditEntry, 0),
+	}
+}
+
+func (r *InMemoryAuditRepository) Add(entries ...model.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chained, err := r.chain(entries)
+	if err != nil {
+		return err
+	}
+	r.entries = append(r.entries, chained...)
+	return nil
+}
+
+// chain - связывает новые записи с концом журнала, сам журнал не меняет;
+// вызывается под блокировкой
+func (r *InMemoryAuditRepository) chain(entries []model.AuditEntry) ([]model.AuditEntry, error) {
+	var prev *model.AuditEntry
+	if len(r.entries) > 0 {
+		prev = &r.entries[len(r.entries)-1]
+	}
+
+	chained := append([]model.AuditEntry{}, entries...)
+	for i := range chained {
+		if err := chained[i].Link(prev); err != nil {
+			return nil, err
+		}
+		prev = &chained[i]
+	}
+	return chained, nil
+}
+
+func (r *InMemoryAuditRepository) All() ([]model.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]model.AuditEntry{}, r.entries...), nil
+}
+
+func (r *InMemoryAuditRepository) Find(filter model.AuditFilter) ([]model.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.AuditEntry, 0)
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+		if filter.Matches(r.entries[i]) {
+			result = append(result, r.entries[i])
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryCarePlanRepository struct {
+	mu    sync.RWMutex
+	plans map[uuid.UUID]model.CarePlan
+}
+
+func NewInMemoryCarePlanRepository() *InMemoryCarePlanRepository {
+	return &InMemoryCarePlanRepository{
+		plans: make(map[uuid.UUID]model.CarePlan),
+	}
+}
+
+func (r *InMemoryCarePlanRepository) Save(plan model.CarePlan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.plans[plan.ID]; exists && current.Version != plan.Version {
+		return model.ErrVersionConflict
+	}
+
+	plan.Version++
+	r.plans[plan.ID] = plan
+	return nil
+}
+
+func (r *InMemoryCarePlanRepository) FindByID(id uuid.UUID) (*model.CarePlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plan, exists := r.plans[id]
+	if !exists {
+		return nil, model.ErrCarePlanNotFound
+	}
+	return &plan, nil
+}
+
+func (r *InMemoryCarePlanRepository) FindBySpecies(species string) (*model.CarePlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, plan := range r.plans {
+		if strings.EqualFold(plan.Species, species) {
+			return &plan, nil
+		}
+	}
+	return nil, model.ErrCarePlanNotFound
+}
+
+func (r *InMemoryCarePlanRepository) FindAll() ([]model.CarePlan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	plans := make([]model.CarePlan, 0, len(r.plans))
+	for _, plan := range r.plans {
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func (r *InMemoryCarePlanRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.plans[id]; !exists {
+		return model.ErrCarePlanNotFound
+	}
+	delete(r.plans, id)
+	return nil
+}
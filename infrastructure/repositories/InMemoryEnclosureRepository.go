@@ -1,7 +1,6 @@
 package repositories
 
 import (
-	"errors"
 	"kpo-mini-dz2/domain/model"
 	"sync"
 
@@ -23,34 +22,87 @@ func (r *InMemoryEnclosureRepository) Save(enclosure model.Enclosure) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if current, exists := r.enclosures[enclosure.ID]; exists && current.Version != enclosure.Version {
+		return model.ErrVersionConflict
+	}
+	if other, exists := r.findByName(enclosure.Name); exists && other.ID != enclosure.ID {
+		return model.ErrEnclosureNameTaken
+	}
+
+	enclosure.Version++
 	r.enclosures[enclosure.ID] = enclosure
 	return nil
 }
 
 func (r *InMemoryEnclosureRepository) FindByID(id uuid.UUID) (*model.Enclosure, error) {
+	enclosure, exists := r.find(id)
+	if !exists || enclosure.IsDeleted() {
+		return nil, model.ErrEnclosureNotFound
+	}
+
+	// Возвращаем копию
+	return &enclosure, nil
+}
+
+func (r *InMemoryEnclosureRepository) FindDeletedByID(id uuid.UUID) (*model.Enclosure, error) {
+	enclosure, exists := r.find(id)
+	if !exists || !enclosure.IsDeleted() {
+		return nil, model.ErrEnclosureNotFound
+	}
+	return &enclosure, nil
+}
+
+// find - вольер по ID, в том числе удалённый
+func (r *InMemoryEnclosureRepository) find(id uuid.UUID) (model.Enclosure, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	enclosure, exists := r.enclosures[id]
+	return enclosure, exists
+}
+
+func (r *InMemoryEnclosureRepository) FindByName(name string) (*model.Enclosure, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	enclosure, exists := r.findByName(name)
 	if !exists {
-		return nil, errors.New("вольер не найден")
+		return nil, model.ErrEnclosureNotFound
 	}
-
-	// Возвращаем копию
 	return &enclosure, nil
 }
 
-// FindAll - возвращает все вольеры
+// findByName вызывается под блокировкой; удалённые вольеры название не занимают
+func (r *InMemoryEnclosureRepository) findByName(name string) (model.Enclosure, bool) {
+	for _, enclosure := range r.enclosures {
+		if !enclosure.IsDeleted() && enclosure.HasName(name) {
+			return enclosure, true
+		}
+	}
+	return model.Enclosure{}, false
+}
+
+// FindAll - возвращает все вольеры, кроме удалённых
 func (r *InMemoryEnclosureRepository) FindAll() ([]model.Enclosure, error) {
+	return r.list(false), nil
+}
+
+func (r *InMemoryEnclosureRepository) FindDeleted() ([]model.Enclosure, error) {
+	return r.list(true), nil
+}
+
+// list - удалённые вольеры или все остальные
+func (r *InMemoryEnclosureRepository) list(deleted bool) []model.Enclosure {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	enclosures := make([]model.Enclosure, 0, len(r.enclosures))
 	for _, enclosure := range r.enclosures {
-		enclosures = append(enclosures, enclosure)
+		if enclosure.IsDeleted() == deleted {
+			enclosures = append(enclosures, enclosure)
+		}
 	}
-
-	return enclosures, nil
+	return enclosures
 }
 
 // FindByType - ищет вольеры по типу
@@ -60,7 +112,7 @@ func (r *InMemoryEnclosureRepository) FindByType(animalType model.AnimalType) ([
 
 	var result []model.Enclosure
 	for _, enclosure := range r.enclosures {
-		if enclosure.Type == animalType {
+		if enclosure.Type == animalType && !enclosure.IsDeleted() {
 			result = append(result, enclosure)
 		}
 	}
@@ -76,7 +128,7 @@ func (r *InMemoryEnclosureRepository) FindWithAvailableSpace(minSpace int) ([]mo
 	var result []model.Enclosure
 	for _, enclosure := range r.enclosures {
 		available := enclosure.MaxCapacity - enclosure.CurrentCount
-		if available >= minSpace {
+		if available >= minSpace && !enclosure.IsDeleted() {
 			result = append(result, enclosure)
 		}
 	}
@@ -84,85 +136,40 @@ func (r *InMemoryEnclosureRepository) FindWithAvailableSpace(minSpace int) ([]mo
 	return result, nil
 }
 
-// Update - обновляет вольер
+// Update - обновляет вольер; версия должна совпадать с сохранённой
 func (r *InMemoryEnclosureRepository) Update(enclosure model.Enclosure) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.enclosures[enclosure.ID]; !exists {
-		return errors.New("вольер не найден")
+	current, exists := r.enclosures[enclosure.ID]
+	if !exists {
+		return model.ErrEnclosureNotFound
+	}
+	if current.Version != enclosure.Version {
+		return model.ErrVersionConflict
+	}
+	if other, exists := r.findByName(enclosure.Name); exists && other.ID != enclosure.ID {
+		return model.ErrEnclosureNameTaken
 	}
 
+	enclosure.Version++
 	r.enclosures[enclosure.ID] = enclosure
 	return nil
 }
 
-// Delete - удаляет вольер
+// Delete - удаляет вольер окончательно
 func (r *InMemoryEnclosureRepository) Delete(id uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, exists := r.enclosures[id]; !exists {
-		return errors.New("вольер не найден")
+		return model.ErrEnclosureNotFound
 	}
 
 	delete(r.enclosures, id)
 	return nil
 }
 
-/*
-// SeedWithSampleData - заполняет репозиторий тестовыми данными
-func (r *InMemoryEnclosureRepository) SeedWithSampleData() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	// Создаем тестовые вольеры
-	sampleEnclosures := []entities.Enclosure{
-		{
-			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
-			Type:         enums.Predator,
-			Size:         enums.Large,
-			CurrentCount: 2,
-			MaxCapacity:  5,
-		},
-		{
-			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000002"),
-			Type:         enums.Herbivore,
-			Size:         enums.Large,
-			CurrentCount: 10,
-			MaxCapacity:  15,
-		},
-		{
-			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000003"),
-			Type:         enums.Bird,
-			Size:         enums.Medium,
-			CurrentCount: 5,
-			MaxCapacity:  8,
-		},
-		{
-			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000004"),
-			Type:         enums.Aquarium,
-			Size:         enums.Small,
-			CurrentCount: 3,
-			MaxCapacity:  5,
-		},
-		{
-			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000005"),
-			Type:         model.Quarantine,
-			Size:         model.Small,
-			CurrentCount: 1,
-			MaxCapacity:  3,
-		},
-	}
-
-	for _, enclosure := range sampleEnclosures {
-		r.enclosures[enclosure.ID] = enclosure
-	}
-
-	return nil
-}
-*/
-
 // Clear - очищает репозиторий (для тестов)
 func (r *InMemoryEnclosureRepository) Clear() error {
 	r.mu.Lock()
@@ -23,18 +23,117 @@ func (r *InMemoryFeedingScheduleRepository) AddSchedule(schedule model.FeedingSc
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if schedule.ID == uuid.Nil {
+		schedule.ID = uuid.New()
+	}
+	schedule.Version++
 	r.schedules[schedule.AnimalID] = append(r.schedules[schedule.AnimalID], schedule)
 	return nil
 }
 
-func (r *InMemoryFeedingScheduleRepository) GetSchedulesByAnimalID(animalID uuid.UUID) ([]model.FeedingSchedule, error) {
+func (r *InMemoryFeedingScheduleRepository) GetScheduleByID(id uuid.UUID) (*model.FeedingSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, exists := r.findSchedule(id)
+	if !exists || schedule.IsDeleted() {
+		return nil, model.ErrScheduleNotFound
+	}
+	return &schedule, nil
+}
+
+func (r *InMemoryFeedingScheduleRepository) GetDeletedScheduleByID(id uuid.UUID) (*model.FeedingSchedule, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	return r.schedules[animalID], nil
+	schedule, exists := r.findSchedule(id)
+	if !exists || !schedule.IsDeleted() {
+		return nil, model.ErrScheduleNotFound
+	}
+	return &schedule, nil
+}
+
+// UpdateSchedule - заменяет расписание с тем же ID; версия должна совпадать с сохранённой
+func (r *InMemoryFeedingScheduleRepository) UpdateSchedule(schedule model.FeedingSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, exists := r.findSchedule(schedule.ID)
+	if !exists {
+		return model.ErrScheduleNotFound
+	}
+	if current.Version != schedule.Version {
+		return model.ErrVersionConflict
+	}
+
+	schedule.Version++
+	r.replaceSchedule(current, schedule)
+	return nil
+}
+
+// findSchedule - ищет расписание по ID, вызывается под блокировкой
+func (r *InMemoryFeedingScheduleRepository) findSchedule(id uuid.UUID) (model.FeedingSchedule, bool) {
+	for _, schedules := range r.schedules {
+		for _, schedule := range schedules {
+			if schedule.ID == id {
+				return schedule, true
+			}
+		}
+	}
+	return model.FeedingSchedule{}, false
+}
+
+// replaceSchedule - заменяет старую запись новой (животное могло смениться),
+// вызывается под блокировкой
+func (r *InMemoryFeedingScheduleRepository) replaceSchedule(old model.FeedingSchedule, schedule model.FeedingSchedule) {
+	schedules := make([]model.FeedingSchedule, 0, len(r.schedules[old.AnimalID]))
+	for _, s := range r.schedules[old.AnimalID] {
+		if s.ID != old.ID {
+			schedules = append(schedules, s)
+		}
+	}
+	r.schedules[old.AnimalID] = schedules
+	r.schedules[schedule.AnimalID] = append(r.schedules[schedule.AnimalID], schedule)
+}
+
+func (r *InMemoryFeedingScheduleRepository) GetSchedulesByAnimalID(animalID uuid.UUID) ([]model.FeedingSchedule, error) {
+	return activeSchedules(r.schedulesOf(animalID)), nil
 }
 
 func (r *InMemoryFeedingScheduleRepository) GetAllSchedules() (map[uuid.UUID][]model.FeedingSchedule, error) {
+	result := r.all()
+	for animalID, schedules := range result {
+		if active := activeSchedules(schedules); len(active) > 0 {
+			result[animalID] = active
+		} else {
+			delete(result, animalID)
+		}
+	}
+	return result, nil
+}
+
+func (r *InMemoryFeedingScheduleRepository) GetDeletedSchedules() ([]model.FeedingSchedule, error) {
+	deleted := make([]model.FeedingSchedule, 0)
+	for _, schedules := range r.all() {
+		for _, schedule := range schedules {
+			if schedule.IsDeleted() {
+				deleted = append(deleted, schedule)
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// schedulesOf - копия расписаний животного, в том числе удалённых
+func (r *InMemoryFeedingScheduleRepository) schedulesOf(animalID uuid.UUID) []model.FeedingSchedule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]model.FeedingSchedule{}, r.schedules[animalID]...)
+}
+
+// all - копия всех расписаний, в том числе удалённых
+func (r *InMemoryFeedingScheduleRepository) all() map[uuid.UUID][]model.FeedingSchedule {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -43,8 +142,18 @@ func (r *InMemoryFeedingScheduleRepository) GetAllSchedules() (map[uuid.UUID][]m
 	for animalID, schedules := range r.schedules {
 		result[animalID] = append([]model.FeedingSchedule{}, schedules...)
 	}
+	return result
+}
 
-	return result, nil
+// activeSchedules - расписания без удалённых
+func activeSchedules(schedules []model.FeedingSchedule) []model.FeedingSchedule {
+	active := make([]model.FeedingSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if !schedule.IsDeleted() {
+			active = append(active, schedule)
+		}
+	}
+	return active
 }
 
 func (r *InMemoryFeedingScheduleRepository) RemoveSchedule(animalID uuid.UUID, feedingTime time.Time) error {
@@ -65,6 +174,7 @@ func (r *InMemoryFeedingScheduleRepository) RemoveSchedule(animalID uuid.UUID, f
 	r.schedules[animalID] = newSchedules
 	return nil
 }
+
 func (r *InMemoryFeedingScheduleRepository) ClearSchedules(animalID uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -73,6 +183,24 @@ func (r *InMemoryFeedingScheduleRepository) ClearSchedules(animalID uuid.UUID) e
 	return nil
 }
 
+func (r *InMemoryFeedingScheduleRepository) DeleteSchedule(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schedule, exists := r.findSchedule(id)
+	if !exists {
+		return model.ErrScheduleNotFound
+	}
+	schedules := make([]model.FeedingSchedule, 0, len(r.schedules[schedule.AnimalID]))
+	for _, s := range r.schedules[schedule.AnimalID] {
+		if s.ID != id {
+			schedules = append(schedules, s)
+		}
+	}
+	r.schedules[schedule.AnimalID] = schedules
+	return nil
+}
+
 // ClearAll очищает все расписания
 func (r *InMemoryFeedingScheduleRepository) ClearAll() error {
 	r.mu.Lock()
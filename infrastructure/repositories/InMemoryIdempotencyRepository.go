@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"sync"
+	"time"
+)
+
+// idempotencySweepInterval - как часто удаляются истёкшие ключи
+const idempotencySweepInterval = time.Minute
+
+// InMemoryIdempotencyRepository - ключи не сохраняются в файл:
+// после перезапуска повтор выполнится заново
+type InMemoryIdempotencyRepository struct {
+	mu        sync.Mutex
+	records   map[string]model.IdempotencyRecord
+	lastSweep time.Time
+}
+
+func NewInMemoryIdempotencyRepository() *InMemoryIdempotencyRepository {
+	return &InMemoryIdempotencyRepository{
+		records: make(map[string]model.IdempotencyRecord),
+	}
+}
+
+func (r *InMemoryIdempotencyRepository) Reserve(record model.IdempotencyRecord, now time.Time) (*model.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(now)
+	if existing, ok := r.records[record.Key]; ok && now.Before(existing.ExpiresAt) {
+		return &existing, nil
+	}
+
+	record.Completed = false
+	r.records[record.Key] = record
+	return nil, nil
+}
+
+func (r *InMemoryIdempotencyRepository) Complete(record model.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record.Completed = true
+	r.records[record.Key] = record
+	return nil
+}
+
+func (r *InMemoryIdempotencyRepository) Release(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, key)
+	return nil
+}
+
+// sweep вызывается под блокировкой
+func (r *InMemoryIdempotencyRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	r.lastSweep = now
+	for key, record := range r.records {
+		if !now.Before(record.ExpiresAt) {
+			delete(r.records, key)
+		}
+	}
+}
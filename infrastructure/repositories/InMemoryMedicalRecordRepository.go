@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryMedicalRecordRepository struct {
+	mu      sync.RWMutex
+	cases   map[uuid.UUID]model.MedicalCase
+	entries []model.MedicalEntry
+}
+
+func NewInMemoryMedicalRecordRepository() *InMemoryMedicalRecordRepository {
+	return &InMemoryMedicalRecordRepository{
+		cases:   make(map[uuid.UUID]model.MedicalCase),
+		entries: make([]model.MedicalEntry, 0),
+	}
+}
+
+func (r *InMemoryMedicalRecordRepository) SaveCase(medicalCase model.MedicalCase) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.cases[medicalCase.ID]; exists && current.Version != medicalCase.Version {
+		return model.ErrVersionConflict
+	}
+
+	medicalCase.Version++
+	r.cases[medicalCase.ID] = medicalCase
+	return nil
+}
+
+func (r *InMemoryMedicalRecordRepository) FindCaseByID(id uuid.UUID) (*model.MedicalCase, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	medicalCase, exists := r.cases[id]
+	if !exists {
+		return nil, model.ErrMedicalCaseNotFound
+	}
+	return &medicalCase, nil
+}
+
+func (r *InMemoryMedicalRecordRepository) AddEntry(entry model.MedicalEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *InMemoryMedicalRecordRepository) FindByAnimalID(animalID uuid.UUID) (*model.MedicalRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return buildMedicalRecord(animalID, r.allCases(), r.entries), nil
+}
+
+func (r *InMemoryMedicalRecordRepository) FindOpenCases() ([]model.MedicalCase, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return filterOpenCases(r.allCases()), nil
+}
+
+func (r *InMemoryMedicalRecordRepository) DeleteByAnimalID(animalID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteByAnimalID(animalID)
+	return nil
+}
+
+// deleteByAnimalID вызывается под блокировкой
+func (r *InMemoryMedicalRecordRepository) deleteByAnimalID(animalID uuid.UUID) {
+	for id, medicalCase := range r.cases {
+		if medicalCase.AnimalID == animalID {
+			delete(r.cases, id)
+		}
+	}
+	r.entries = slices.DeleteFunc(r.entries, func(entry model.MedicalEntry) bool {
+		return entry.AnimalID == animalID
+	})
+}
+
+// allCases вызывается под блокировкой
+func (r *InMemoryMedicalRecordRepository) allCases() []model.MedicalCase {
+	cases := make([]model.MedicalCase, 0, len(r.cases))
+	for _, medicalCase := range r.cases {
+		cases = append(cases, medicalCase)
+	}
+	return cases
+}
+
+// buildMedicalRecord - случаи и записи животного по дате, от старых к новым
+func buildMedicalRecord(animalID uuid.UUID, cases []model.MedicalCase, entries []model.MedicalEntry) *model.MedicalRecord {
+	record := &model.MedicalRecord{
+		AnimalID: animalID,
+		Cases:    make([]model.MedicalCase, 0),
+		Entries:  make([]model.MedicalEntry, 0),
+	}
+	for _, medicalCase := range cases {
+		if medicalCase.AnimalID == animalID {
+			record.Cases = append(record.Cases, medicalCase)
+		}
+	}
+	for _, entry := range entries {
+		if entry.AnimalID == animalID {
+			record.Entries = append(record.Entries, entry)
+		}
+	}
+	sort.Slice(record.Cases, func(i, j int) bool {
+		return record.Cases[i].OpenedAt.Before(record.Cases[j].OpenedAt)
+	})
+	sort.SliceStable(record.Entries, func(i, j int) bool {
+		return record.Entries[i].Date.Before(record.Entries[j].Date)
+	})
+	return record
+}
+
+func filterOpenCases(cases []model.MedicalCase) []model.MedicalCase {
+	open := make([]model.MedicalCase, 0)
+	for _, medicalCase := range cases {
+		if medicalCase.IsOpen() {
+			open = append(open, medicalCase)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].OpenedAt.Before(open[j].OpenedAt)
+	})
+	return open
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryMovementRepository struct {
+	mu        sync.RWMutex
+	movements []model.Movement
+}
+
+func NewInMemoryMovementRepository() *InMemoryMovementRepository {
+	return &InMemoryMovementRepository{
+		movements: make([]model.Movement, 0),
+	}
+}
+
+func (r *InMemoryMovementRepository) Add(movement model.Movement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.movements = append(r.movements, movement)
+	return nil
+}
+
+func (r *InMemoryMovementRepository) FindByAnimalID(animalID uuid.UUID) ([]model.Movement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]model.Movement, 0)
+	for _, movement := range r.movements {
+		if movement.AnimalID == animalID {
+			result = append(result, movement)
+		}
+	}
+	return result, nil
+}
+
+func (r *InMemoryMovementRepository) FindAll() ([]model.Movement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]model.Movement{}, r.movements...), nil
+}
+
+func (r *InMemoryMovementRepository) DeleteByAnimalID(animalID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteByAnimalID(animalID)
+	return nil
+}
+
+// deleteByAnimalID вызывается под блокировкой
+func (r *InMemoryMovementRepository) deleteByAnimalID(animalID uuid.UUID) {
+	r.movements = slices.DeleteFunc(r.movements, func(movement model.Movement) bool {
+		return movement.AnimalID == animalID
+	})
+}
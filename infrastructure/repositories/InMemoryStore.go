@@ -0,0 +1,103 @@
+package repositories
+
+import "sync"
+
+// InMemoryStore - все in-memory репозитории приложения.
+// Через него репозитории получают транзакции и файловое хранилище.
+type InMemoryStore struct {
+	// mu - фиксация транзакции берёт его на запись, транзакция только
+	// для чтения и снимок - на чтение, поэтому они не видят фиксацию наполовину
+	mu sync.RWMutex
+
+	Animals          *InMemoryAnimalRepository
+	Enclosures       *InMemoryEnclosureRepository
+	FeedingSchedules *InMemoryFeedingScheduleRepository
+	Movements        *InMemoryMovementRepository
+	TransferRequests *InMemoryTransferRequestRepository
+	Users            *InMemoryUserRepository
+	Webhooks         *InMemoryWebhookRepository
+	// WebhookDeliveries не участвует в транзакциях, но сохраняется в снимке вместе с остальными
+	WebhookDeliveries *InMemoryWebhookDeliveryRepository
+	MedicalRecords    *InMemoryMedicalRecordRepository
+	CarePlans         *InMemoryCarePlanRepository
+	Audit             *InMemoryAuditRepository
+}
+
+// webhookLogSize - сколько доставок вебхуков хранится в журнале
+func NewInMemoryStore(webhookLogSize int) *InMemoryStore {
+	return &InMemoryStore{
+		Animals:           NewAnimalRepository(),
+		Enclosures:        NewInMemoryEnclosureRepository(),
+		FeedingSchedules:  NewInMemoryFeedingScheduleRepository(),
+		Movements:         NewInMemoryMovementRepository(),
+		TransferRequests:  NewInMemoryTransferRequestRepository(),
+		Users:             NewInMemoryUserRepository(),
+		Webhooks:          NewInMemoryWebhookRepository(),
+		WebhookDeliveries: NewInMemoryWebhookDeliveryRepository(webhookLogSize),
+		MedicalRecords:    NewInMemoryMedicalRecordRepository(),
+		CarePlans:         NewInMemoryCarePlanRepository(),
+		Audit:             NewInMemoryAuditRepository(),
+	}
+}
+
+// lock и rlock берут блокировки всех репозиториев в одном и том же порядке,
+// чтобы транзакции и снимки хранилища не блокировали друг друга
+func (s *InMemoryStore) lock() {
+	s.mu.Lock()
+	s.Animals.mu.Lock()
+	s.Enclosures.mu.Lock()
+	s.FeedingSchedules.mu.Lock()
+	s.Movements.mu.Lock()
+	s.TransferRequests.mu.Lock()
+	s.Users.mu.Lock()
+	s.Webhooks.mu.Lock()
+	s.WebhookDeliveries.mu.Lock()
+	s.MedicalRecords.mu.Lock()
+	s.CarePlans.mu.Lock()
+	s.Audit.mu.Lock()
+}
+
+func (s *InMemoryStore) unlock() {
+	s.Audit.mu.Unlock()
+	s.CarePlans.mu.Unlock()
+	s.MedicalRecords.mu.Unlock()
+	s.WebhookDeliveries.mu.Unlock()
+	s.Webhooks.mu.Unlock()
+	s.Users.mu.Unlock()
+	s.TransferRequests.mu.Unlock()
+	s.Movements.mu.Unlock()
+	s.FeedingSchedules.mu.Unlock()
+	s.Enclosures.mu.Unlock()
+	s.Animals.mu.Unlock()
+	s.mu.Unlock()
+}
+
+func (s *InMemoryStore) rlock() {
+	s.mu.RLock()
+	s.Animals.mu.RLock()
+	s.Enclosures.mu.RLock()
+	s.FeedingSchedules.mu.RLock()
+	s.Movements.mu.RLock()
+	s.TransferRequests.mu.RLock()
+	s.Users.mu.RLock()
+	s.Webhooks.mu.RLock()
+	s.WebhookDeliveries.mu.RLock()
+	s.MedicalRecords.mu.RLock()
+	s.CarePlans.mu.RLock()
+	s.Audit.mu.RLock()
+}
+
+func (s *InMemoryStore) runlock() {
+	s.Audit.mu.RUnlock()
+	s.CarePlans.mu.RUnlock()
+	s.MedicalRecords.mu.RUnlock()
+	s.WebhookDeliveries.mu.RUnlock()
+	s.Webhooks.mu.RUnlock()
+	s.Users.mu.RUnlock()
+	s.TransferRequests.mu.RUnlock()
+	s.Movements.mu.RUnlock()
+	s.FeedingSchedules.mu.RUnlock()
+	s.Enclosures.mu.RUnlock()
+	s.Animals.mu.RUnlock()
+	s.mu.RUnlock()
+}
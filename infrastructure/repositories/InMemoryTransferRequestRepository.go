@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryTransferRequestRepository struct {
+	mu       sync.RWMutex
+	requests map[uuid.UUID]model.TransferRequest
+}
+
+func NewInMemoryTransferRequestRepository() *InMemoryTransferRequestRepository {
+	return &InMemoryTransferRequestRepository{
+		requests: make(map[uuid.UUID]model.TransferRequest),
+	}
+}
+
+func (r *InMemoryTransferRequestRepository) Save(request model.TransferRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.requests[request.ID]; exists && current.Version != request.Version {
+		return model.ErrVersionConflict
+	}
+
+	request.Version++
+	r.requests[request.ID] = request
+	return nil
+}
+
+func (r *InMemoryTransferRequestRepository) FindByID(id uuid.UUID) (*model.TransferRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	request, exists := r.requests[id]
+	if !exists {
+		return nil, model.ErrTransferNotFound
+	}
+	return &request, nil
+}
+
+func (r *InMemoryTransferRequestRepository) FindAll() ([]model.TransferRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	requests := make([]model.TransferRequest, 0, len(r.requests))
+	for _, request := range r.requests {
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+func (r *InMemoryTransferRequestRepository) FindPendingByEnclosure(enclosureID uuid.UUID) ([]model.TransferRequest, error) {
+	requests, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return filterPendingByEnclosure(requests, enclosureID), nil
+}
+
+func (r *InMemoryTransferRequestRepository) DeleteByAnimalID(animalID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteByAnimalID(animalID)
+	return nil
+}
+
+// deleteByAnimalID вызывается под блокировкой
+func (r *InMemoryTransferRequestRepository) deleteByAnimalID(animalID uuid.UUID) {
+	for id, request := range r.requests {
+		if request.AnimalID == animalID {
+			delete(r.requests, id)
+		}
+	}
+}
+
+func filterPendingByEnclosure(requests []model.TransferRequest, enclosureID uuid.UUID) []model.TransferRequest {
+	result := make([]model.TransferRequest, 0)
+	for _, request := range requests {
+		if !request.IsPending() {
+			continue
+		}
+		if request.FromEnclosureID == enclosureID || request.ToEnclosureID == enclosureID {
+			result = append(result, request)
+		}
+	}
+	return result
+}
@@ -0,0 +1,1593 @@
+package repositories
+
+import (
+	"context"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryUnitOfWorkFactory - открывает транзакции поверх in-memory репозиториев.
+// Транзакции на запись выполняются по одной: Begin ждёт, пока завершится
+// предыдущая, так что решение, принятое по прочитанному, не устареет до фиксации.
+// Транзакции только для чтения выполняются параллельно с ними и друг с другом.
+// При фиксации каждое изменение записывается в журнал аудита
+// с автором и ID запроса из ctx.
+type InMemoryUnitOfWorkFactory struct {
+	writer sync.Mutex
+	// hooksMu берётся до того, как освобождается writer, поэтому хуки
+	// вызываются в порядке фиксаций, но следующей транзакции не мешают
+	hooksMu sync.Mutex
+	store   *InMemoryStore
+	hooks   []CommitHook
+}
+
+// CommitHook - получает изменения каждой успешно зафиксированной транзакции.
+// Вызывается после снятия всех блокировок, в порядке фиксаций; пока хук
+// работает, другие транзакции выполняются, ждут только их хуки.
+// Хук не должен открывать транзакции, которые ждут его самого.
+type CommitHook func(ctx context.Context, changes []model.EntityChange)
+
+func NewInMemoryUnitOfWorkFactory(store *InMemoryStore) *InMemoryUnitOfWorkFactory {
+	return &InMemoryUnitOfWorkFactory{store: store}
+}
+
+// OnCommit - добавляет хук; вызывать до начала обработки запросов
+func (f *InMemoryUnitOfWorkFactory) OnCommit(hook CommitHook) {
+	f.hooks = append(f.hooks, hook)
+}
+
+func (f *InMemoryUnitOfWorkFactory) Begin(ctx context.Context) (RP.IUnitOfWork, error) {
+	f.writer.Lock()
+	return f.newUnitOfWork(ctx, false), nil
+}
+
+// BeginRead - держит хранилище на чтение до Rollback, поэтому фиксации
+// других транзакций ждут, пока она не завершится
+func (f *InMemoryUnitOfWorkFactory) BeginRead(ctx context.Context) (RP.IUnitOfWork, error) {
+	f.store.mu.RLock()
+	return f.newUnitOfWork(ctx, true), nil
+}
+
+func (f *InMemoryUnitOfWorkFactory) newUnitOfWork(ctx context.Context, readOnly bool) *inMemoryUnitOfWork {
+	return &inMemoryUnitOfWork{
+		ctx:        ctx,
+		factory:    f,
+		readOnly:   readOnly,
+		animals:    newStagedAnimalRepository(f.store.Animals),
+		enclosures: newStagedEnclosureRepository(f.store.Enclosures),
+		schedules:  newStagedFeedingScheduleRepository(f.store.FeedingSchedules),
+		movements:  newStagedMovementRepository(f.store.Movements),
+		transfers:  newStagedTransferRequestRepository(f.store.TransferRequests),
+		users:      newStagedUserRepository(f.store.Users),
+		webhooks:   newStagedWebhookRepository(f.store.Webhooks),
+		medical:    newStagedMedicalRecordRepository(f.store.MedicalRecords),
+		carePlans:  newStagedCarePlanRepository(f.store.CarePlans),
+	}
+}
+
+type inMemoryUnitOfWork struct {
+	ctx        context.Context
+	factory    *InMemoryUnitOfWorkFactory
+	readOnly   bool
+	animals    *stagedAnimalRepository
+	enclosures *stagedEnclosureRepository
+	schedules  *stagedFeedingScheduleRepository
+	movements  *stagedMovementRepository
+	transfers  *stagedTransferRequestRepository
+	users      *stagedUserRepository
+	webhooks   *stagedWebhookRepository
+	medical    *stagedMedicalRecordRepository
+	carePlans  *stagedCarePlanRepository
+	done       bool
+}
+
+func (u *inMemoryUnitOfWork) Animals() RP.IAnimalRepository {
+	return u.animals
+}
+
+func (u *inMemoryUnitOfWork) Enclosures() RP.IEnclosureRepository {
+	return u.enclosures
+}
+
+func (u *inMemoryUnitOfWork) FeedingSchedules() RP.IFeedingScheduleRepository {
+	return u.schedules
+}
+
+func (u *inMemoryUnitOfWork) Movements() RP.IMovementRepository {
+	return u.movements
+}
+
+func (u *inMemoryUnitOfWork) TransferRequests() RP.ITransferRequestRepository {
+	return u.transfers
+}
+
+func (u *inMemoryUnitOfWork) Users() RP.IUserRepository {
+	return u.users
+}
+
+func (u *inMemoryUnitOfWork) Webhooks() RP.IWebhookRepository {
+	return u.webhooks
+}
+
+func (u *inMemoryUnitOfWork) MedicalRecords() RP.IMedicalRecordRepository {
+	return u.medical
+}
+
+func (u *inMemoryUnitOfWork) CarePlans() RP.ICarePlanRepository {
+	return u.carePlans
+}
+
+// Commit - применяет изменения во всех репозиториях сразу.
+// Блокировки всех репозиториев держатся до конца применения,
+// поэтому читатели не увидят состояние "наполовину".
+// Если запись успели изменить в обход транзакции, ничего не применяется.
+// Хуки вызываются уже после снятия блокировки записи, но по порядку коммитов.
+func (u *inMemoryUnitOfWork) Commit() error {
+	if u.done {
+		return model.ErrTransactionClosed
+	}
+	if u.readOnly {
+		u.finish()
+		return model.ErrReadOnlyTransaction
+	}
+
+	changes, err := u.apply()
+	if err != nil {
+		u.finish()
+		return err
+	}
+	u.factory.hooksMu.Lock()
+	defer u.factory.hooksMu.Unlock()
+	u.finish()
+	for _, hook := range u.factory.hooks {
+		hook(u.ctx, changes)
+	}
+	return nil
+}
+
+// apply - проверяет и применяет изменения под блокировкой хранилища
+func (u *inMemoryUnitOfWork) apply() ([]model.EntityChange, error) {
+	u.factory.store.lock()
+	defer u.factory.store.unlock()
+
+	if err := u.animals.validate(); err != nil {
+		return nil, err
+	}
+	if err := u.enclosures.validate(); err != nil {
+		return nil, err
+	}
+	if err := u.schedules.validate(); err != nil {
+		return nil, err
+	}
+	if err := u.transfers.validate(); err != nil {
+		return nil, err
+	}
+	if err := u.users.validate(); err != nil {
+		return nil, err
+	}
+	if err := u.webhooks.validate(); err != nil {
+		return nil, err
+	}
+	if err := u.medical.validate(); err != nil {
+		return nil, err
+	}
+	if err := u.carePlans.validate(); err != nil {
+		return nil, err
+	}
+	log, err := u.auditEntries()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := u.factory.store.Audit.chain(log.entries)
+	if err != nil {
+		return nil, err
+	}
+
+	u.animals.apply()
+	u.enclosures.apply()
+	u.schedules.apply()
+	u.movements.apply()
+	u.transfers.apply()
+	u.users.apply()
+	u.webhooks.apply()
+	u.medical.apply()
+	u.carePlans.apply()
+	u.factory.store.Audit.entries = append(u.factory.store.Audit.entries, entries...)
+
+	slog.DebugContext(u.ctx, "транзакция применена", "changes", len(entries))
+	return log.changes, nil
+}
+
+// auditEntries - записи аудита для всех изменений транзакции;
+// вызывается под блокировкой, до apply, пока в базе старые значения
+func (u *inMemoryUnitOfWork) auditEntries() (*auditLog, error) {
+	var log auditLog
+	if err := u.animals.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.enclosures.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.schedules.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.movements.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.transfers.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.users.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.webhooks.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.medical.audit(&log); err != nil {
+		return nil, err
+	}
+	if err := u.carePlans.audit(&log); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	actor := requestcontext.ActorFrom(u.ctx).Name
+	requestID := requestcontext.RequestID(u.ctx)
+	for i := range log.entries {
+		log.entries[i].At = now
+		log.entries[i].Actor = actor
+		log.entries[i].RequestID = requestID
+	}
+	return &log, nil
+}
+
+func (u *inMemoryUnitOfWork) Rollback() error {
+	if u.done {
+		return nil
+	}
+	u.finish()
+	if !u.readOnly {
+		slog.DebugContext(u.ctx, "транзакция отменена")
+	}
+	return nil
+}
+
+// auditLog - записи аудита транзакции и те же изменения в виде значений модели
+type auditLog struct {
+	entries []model.AuditEntry
+	changes []model.EntityChange
+}
+
+// record - добавляет запись, если состояние сущности действительно изменилось
+func (l *auditLog) record(entity string, id uuid.UUID, before any, after any) error {
+	entry, err := model.NewAuditEntry(entity, id, before, after)
+	if err != nil || entry == nil {
+		return err
+	}
+	l.entries = append(l.entries, *entry)
+	l.changes = append(l.changes, model.EntityChange{
+		Entity:   entity,
+		EntityID: id,
+		Action:   entry.Action,
+		Before:   before,
+		After:    after,
+	})
+	return nil
+}
+
+func (u *inMemoryUnitOfWork) finish() {
+	u.done = true
+	if u.readOnly {
+		u.factory.store.mu.RUnlock()
+		return
+	}
+	u.factory.writer.Unlock()
+}
+
+// stagedAnimalRepository - изменения животных внутри транзакции.
+// expected хранит версии, которые транзакция видела до своих изменений.
+type stagedAnimalRepository struct {
+	base     *InMemoryAnimalRepository
+	saved    map[uuid.UUID]model.Animal
+	deleted  map[uuid.UUID]struct{}
+	expected map[uuid.UUID]int
+}
+
+func newStagedAnimalRepository(base *InMemoryAnimalRepository) *stagedAnimalRepository {
+	return &stagedAnimalRepository{
+		base:     base,
+		saved:    make(map[uuid.UUID]model.Animal),
+		deleted:  make(map[uuid.UUID]struct{}),
+		expected: make(map[uuid.UUID]int),
+	}
+}
+
+func (r *stagedAnimalRepository) Save(animal model.Animal) error {
+	version := 0
+	if current, ok := r.find(animal.ID); ok {
+		version = current.Version
+	}
+	if animal.Version != version {
+		return model.ErrVersionConflict
+	}
+	if _, ok := r.expected[animal.ID]; !ok {
+		r.expected[animal.ID] = version
+	}
+
+	animal.Version++
+	delete(r.deleted, animal.ID)
+	r.saved[animal.ID] = animal
+	return nil
+}
+
+func (r *stagedAnimalRepository) FindByID(id uuid.UUID) (*model.Animal, error) {
+	animal, ok := r.find(id)
+	if !ok || animal.IsDeleted() {
+		return nil, model.ErrAnimalNotFound
+	}
+	return &animal, nil
+}
+
+func (r *stagedAnimalRepository) FindAll() ([]model.Animal, error) {
+	return r.list(false), nil
+}
+
+func (r *stagedAnimalRepository) FindDeletedByID(id uuid.UUID) (*model.Animal, error) {
+	animal, ok := r.find(id)
+	if !ok || !animal.IsDeleted() {
+		return nil, model.ErrAnimalNotFound
+	}
+	return &animal, nil
+}
+
+func (r *stagedAnimalRepository) FindDeleted() ([]model.Animal, error) {
+	return r.list(true), nil
+}
+
+// find - животное с учётом изменений транзакции, в том числе помеченное удалённым
+func (r *stagedAnimalRepository) find(id uuid.UUID) (model.Animal, bool) {
+	if _, ok := r.deleted[id]; ok {
+		return model.Animal{}, false
+	}
+	if animal, ok := r.saved[id]; ok {
+		return animal, true
+	}
+	return r.base.find(id)
+}
+
+func (r *stagedAnimalRepository) list(deleted bool) []model.Animal {
+	baseAnimals := r.base.list(deleted)
+	animals := make([]model.Animal, 0, len(baseAnimals)+len(r.saved))
+	for _, animal := range baseAnimals {
+		if _, ok := r.deleted[animal.ID]; ok {
+			continue
+		}
+		if _, ok := r.saved[animal.ID]; ok {
+			continue
+		}
+		animals = append(animals, animal)
+	}
+	for _, animal := range r.saved {
+		if animal.IsDeleted() == deleted {
+			animals = append(animals, animal)
+		}
+	}
+	return animals
+}
+
+func (r *stagedAnimalRepository) Delete(id uuid.UUID) error {
+	if current, ok := r.find(id); ok {
+		if _, ok := r.expected[id]; !ok {
+			r.expected[id] = current.Version
+		}
+	}
+	delete(r.saved, id)
+	r.deleted[id] = struct{}{}
+	return nil
+}
+
+func (r *stagedAnimalRepository) AnimalCount() int {
+	animals, _ := r.FindAll()
+	return len(model.PresentAnimals(animals))
+}
+
+// validate и apply вызываются под блокировкой базового репозитория
+func (r *stagedAnimalRepository) validate() error {
+	for id, version := range r.expected {
+		if r.base.animals[id].Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	return nil
+}
+
+func (r *stagedAnimalRepository) audit(log *auditLog) error {
+	for id := range r.deleted {
+		if before, ok := r.base.animals[id]; ok {
+			if err := log.record(model.EntityAnimal, id, before, nil); err != nil {
+				return err
+			}
+		}
+	}
+	for id, animal := range r.saved {
+		var before any
+		if current, ok := r.base.animals[id]; ok {
+			before = current
+		}
+		if err := log.record(model.EntityAnimal, id, before, animal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedAnimalRepository) apply() {
+	for id := range r.deleted {
+		delete(r.base.animals, id)
+	}
+	for id, animal := range r.saved {
+		r.base.animals[id] = animal
+	}
+}
+
+// stagedEnclosureRepository - изменения вольеров внутри транзакции
+type stagedEnclosureRepository struct {
+	base     *InMemoryEnclosureRepository
+	saved    map[uuid.UUID]model.Enclosure
+	deleted  map[uuid.UUID]struct{}
+	expected map[uuid.UUID]int
+}
+
+func newStagedEnclosureRepository(base *InMemoryEnclosureRepository) *stagedEnclosureRepository {
+	return &stagedEnclosureRepository{
+		base:     base,
+		saved:    make(map[uuid.UUID]model.Enclosure),
+		deleted:  make(map[uuid.UUID]struct{}),
+		expected: make(map[uuid.UUID]int),
+	}
+}
+
+func (r *stagedEnclosureRepository) Save(enclosure model.Enclosure) error {
+	version := 0
+	if current, ok := r.find(enclosure.ID); ok {
+		version = current.Version
+	}
+	if enclosure.Version != version {
+		return model.ErrVersionConflict
+	}
+	if other, err := r.FindByName(enclosure.Name); err == nil && other.ID != enclosure.ID && !enclosure.IsDeleted() {
+		return model.ErrEnclosureNameTaken
+	}
+	if _, ok := r.expected[enclosure.ID]; !ok {
+		r.expected[enclosure.ID] = version
+	}
+
+	enclosure.Version++
+	delete(r.deleted, enclosure.ID)
+	r.saved[enclosure.ID] = enclosure
+	return nil
+}
+
+func (r *stagedEnclosureRepository) FindByID(id uuid.UUID) (*model.Enclosure, error) {
+	enclosure, ok := r.find(id)
+	if !ok || enclosure.IsDeleted() {
+		return nil, model.ErrEnclosureNotFound
+	}
+	return &enclosure, nil
+}
+
+func (r *stagedEnclosureRepository) FindDeletedByID(id uuid.UUID) (*model.Enclosure, error) {
+	enclosure, ok := r.find(id)
+	if !ok || !enclosure.IsDeleted() {
+		return nil, model.ErrEnclosureNotFound
+	}
+	return &enclosure, nil
+}
+
+// find - вольер с учётом изменений транзакции, в том числе помеченный удалённым
+func (r *stagedEnclosureRepository) find(id uuid.UUID) (model.Enclosure, bool) {
+	if _, ok := r.deleted[id]; ok {
+		return model.Enclosure{}, false
+	}
+	if enclosure, ok := r.saved[id]; ok {
+		return enclosure, true
+	}
+	return r.base.find(id)
+}
+
+func (r *stagedEnclosureRepository) FindByName(name string) (*model.Enclosure, error) {
+	enclosures, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, enclosure := range enclosures {
+		if enclosure.HasName(name) {
+			return &enclosure, nil
+		}
+	}
+	return nil, model.ErrEnclosureNotFound
+}
+
+func (r *stagedEnclosureRepository) FindAll() ([]model.Enclosure, error) {
+	return r.list(false), nil
+}
+
+func (r *stagedEnclosureRepository) FindDeleted() ([]model.Enclosure, error) {
+	return r.list(true), nil
+}
+
+func (r *stagedEnclosureRepository) list(deleted bool) []model.Enclosure {
+	baseEnclosures := r.base.list(deleted)
+	enclosures := make([]model.Enclosure, 0, len(baseEnclosures)+len(r.saved))
+	for _, enclosure := range baseEnclosures {
+		if _, ok := r.deleted[enclosure.ID]; ok {
+			continue
+		}
+		if _, ok := r.saved[enclosure.ID]; ok {
+			continue
+		}
+		enclosures = append(enclosures, enclosure)
+	}
+	for _, enclosure := range r.saved {
+		if enclosure.IsDeleted() == deleted {
+			enclosures = append(enclosures, enclosure)
+		}
+	}
+	return enclosures
+}
+
+func (r *stagedEnclosureRepository) FindByType(animalType model.AnimalType) ([]model.Enclosure, error) {
+	enclosures, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []model.Enclosure
+	for _, enclosure := range enclosures {
+		if enclosure.Type == animalType {
+			result = append(result, enclosure)
+		}
+	}
+	return result, nil
+}
+
+func (r *stagedEnclosureRepository) FindWithAvailableSpace(minSpace int) ([]model.Enclosure, error) {
+	enclosures, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []model.Enclosure
+	for _, enclosure := range enclosures {
+		if enclosure.MaxCapacity-enclosure.CurrentCount >= minSpace {
+			result = append(result, enclosure)
+		}
+	}
+	return result, nil
+}
+
+func (r *stagedEnclosureRepository) Update(enclosure model.Enclosure) error {
+	if _, err := r.FindByID(enclosure.ID); err != nil {
+		return err
+	}
+	return r.Save(enclosure)
+}
+
+func (r *stagedEnclosureRepository) Delete(id uuid.UUID) error {
+	current, ok := r.find(id)
+	if !ok {
+		return model.ErrEnclosureNotFound
+	}
+	if _, ok := r.expected[id]; !ok {
+		r.expected[id] = current.Version
+	}
+	delete(r.saved, id)
+	r.deleted[id] = struct{}{}
+	return nil
+}
+
+// validate - кроме версий проверяет, что название не заняли в обход транзакции;
+// вольеры, изменённые или удалённые в самой транзакции, уже проверены при сохранении
+func (r *stagedEnclosureRepository) validate() error {
+	for id, version := range r.expected {
+		if r.base.enclosures[id].Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	for id, enclosure := range r.saved {
+		if enclosure.IsDeleted() {
+			continue
+		}
+		other, exists := r.base.findByName(enclosure.Name)
+		if !exists || other.ID == id {
+			continue
+		}
+		if _, touched := r.expected[other.ID]; !touched {
+			return model.ErrEnclosureNameTaken
+		}
+	}
+	return nil
+}
+
+func (r *stagedEnclosureRepository) audit(log *auditLog) error {
+	for id := range r.deleted {
+		if before, ok := r.base.enclosures[id]; ok {
+			if err := log.record(model.EntityEnclosure, id, before, nil); err != nil {
+				return err
+			}
+		}
+	}
+	for id, enclosure := range r.saved {
+		var before any
+		if current, ok := r.base.enclosures[id]; ok {
+			before = current
+		}
+		if err := log.record(model.EntityEnclosure, id, before, enclosure); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedEnclosureRepository) apply() {
+	for id := range r.deleted {
+		delete(r.base.enclosures, id)
+	}
+	for id, enclosure := range r.saved {
+		r.base.enclosures[id] = enclosure
+	}
+}
+
+// stagedFeedingScheduleRepository - хранит итоговый список расписаний
+// для каждого животного, которого коснулась транзакция
+type stagedFeedingScheduleRepository struct {
+	base     *InMemoryFeedingScheduleRepository
+	changed  map[uuid.UUID][]model.FeedingSchedule
+	expected map[uuid.UUID]int
+}
+
+func newStagedFeedingScheduleRepository(base *InMemoryFeedingScheduleRepository) *stagedFeedingScheduleRepository {
+	return &stagedFeedingScheduleRepository{
+		base:     base,
+		changed:  make(map[uuid.UUID][]model.FeedingSchedule),
+		expected: make(map[uuid.UUID]int),
+	}
+}
+
+func (r *stagedFeedingScheduleRepository) AddSchedule(schedule model.FeedingSchedule) error {
+	schedules := r.schedulesOf(schedule.AnimalID)
+	if schedule.ID == uuid.Nil {
+		schedule.ID = uuid.New()
+	}
+	schedule.Version++
+	r.changed[schedule.AnimalID] = append(schedules, schedule)
+	return nil
+}
+
+func (r *stagedFeedingScheduleRepository) GetScheduleByID(id uuid.UUID) (*model.FeedingSchedule, error) {
+	schedule, ok := r.find(id)
+	if !ok || schedule.IsDeleted() {
+		return nil, model.ErrScheduleNotFound
+	}
+	return &schedule, nil
+}
+
+func (r *stagedFeedingScheduleRepository) GetDeletedScheduleByID(id uuid.UUID) (*model.FeedingSchedule, error) {
+	schedule, ok := r.find(id)
+	if !ok || !schedule.IsDeleted() {
+		return nil, model.ErrScheduleNotFound
+	}
+	return &schedule, nil
+}
+
+// find - расписание с учётом изменений транзакции, в том числе удалённое
+func (r *stagedFeedingScheduleRepository) find(id uuid.UUID) (model.FeedingSchedule, bool) {
+	for _, schedules := range r.all() {
+		for _, schedule := range schedules {
+			if schedule.ID == id {
+				return schedule, true
+			}
+		}
+	}
+	return model.FeedingSchedule{}, false
+}
+
+func (r *stagedFeedingScheduleRepository) UpdateSchedule(schedule model.FeedingSchedule) error {
+	current, ok := r.find(schedule.ID)
+	if !ok {
+		return model.ErrScheduleNotFound
+	}
+	if current.Version != schedule.Version {
+		return model.ErrVersionConflict
+	}
+	if _, ok := r.expected[schedule.ID]; !ok {
+		r.expected[schedule.ID] = current.Version
+	}
+
+	r.changed[current.AnimalID] = withoutSchedule(r.schedulesOf(current.AnimalID), schedule.ID)
+	schedule.Version++
+	r.changed[schedule.AnimalID] = append(r.schedulesOf(schedule.AnimalID), schedule)
+	return nil
+}
+
+func (r *stagedFeedingScheduleRepository) GetSchedulesByAnimalID(animalID uuid.UUID) ([]model.FeedingSchedule, error) {
+	return activeSchedules(r.schedulesOf(animalID)), nil
+}
+
+func (r *stagedFeedingScheduleRepository) GetAllSchedules() (map[uuid.UUID][]model.FeedingSchedule, error) {
+	result := r.all()
+	for animalID, schedules := range result {
+		if active := activeSchedules(schedules); len(active) > 0 {
+			result[animalID] = active
+		} else {
+			delete(result, animalID)
+		}
+	}
+	return result, nil
+}
+
+func (r *stagedFeedingScheduleRepository) GetDeletedSchedules() ([]model.FeedingSchedule, error) {
+	deleted := make([]model.FeedingSchedule, 0)
+	for _, schedules := range r.all() {
+		for _, schedule := range schedules {
+			if schedule.IsDeleted() {
+				deleted = append(deleted, schedule)
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// schedulesOf - расписания животного с учётом транзакции, в том числе удалённые
+func (r *stagedFeedingScheduleRepository) schedulesOf(animalID uuid.UUID) []model.FeedingSchedule {
+	if schedules, ok := r.changed[animalID]; ok {
+		return append([]model.FeedingSchedule{}, schedules...)
+	}
+	return r.base.schedulesOf(animalID)
+}
+
+// all - все расписания с учётом транзакции, в том числе удалённые
+func (r *stagedFeedingScheduleRepository) all() map[uuid.UUID][]model.FeedingSchedule {
+	result := r.base.all()
+	for animalID, schedules := range r.changed {
+		if len(schedules) == 0 {
+			delete(result, animalID)
+			continue
+		}
+		result[animalID] = append([]model.FeedingSchedule{}, schedules...)
+	}
+	return result
+}
+
+func (r *stagedFeedingScheduleRepository) RemoveSchedule(animalID uuid.UUID, feedingTime time.Time) error {
+	schedules := r.schedulesOf(animalID)
+	newSchedules := make([]model.FeedingSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if !schedule.FeedingTime.Equal(feedingTime) {
+			newSchedules = append(newSchedules, schedule)
+		}
+	}
+	r.changed[animalID] = newSchedules
+	return nil
+}
+
+func (r *stagedFeedingScheduleRepository) ClearSchedules(animalID uuid.UUID) error {
+	r.changed[animalID] = nil
+	return nil
+}
+
+func (r *stagedFeedingScheduleRepository) DeleteSchedule(id uuid.UUID) error {
+	schedule, ok := r.find(id)
+	if !ok {
+		return model.ErrScheduleNotFound
+	}
+	r.changed[schedule.AnimalID] = withoutSchedule(r.schedulesOf(schedule.AnimalID), id)
+	return nil
+}
+
+func withoutSchedule(schedules []model.FeedingSchedule, id uuid.UUID) []model.FeedingSchedule {
+	result := make([]model.FeedingSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.ID != id {
+			result = append(result, schedule)
+		}
+	}
+	return result
+}
+
+func (r *stagedFeedingScheduleRepository) validate() error {
+	for id, version := range r.expected {
+		current, exists := r.base.findSchedule(id)
+		if !exists || current.Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	return nil
+}
+
+// audit - сравнивает расписания животного до и после транзакции по ID
+func (r *stagedFeedingScheduleRepository) audit(log *auditLog) error {
+	for animalID, schedules := range r.changed {
+		after := make(map[uuid.UUID]model.FeedingSchedule, len(schedules))
+		for _, schedule := range schedules {
+			after[schedule.ID] = schedule
+		}
+		for _, before := range r.base.schedules[animalID] {
+			// Расписание могло переехать к другому животному - тогда это изменение, а не удаление
+			if current, ok := after[before.ID]; ok {
+				if err := log.record(model.EntityFeedingSchedule, before.ID, before, current); err != nil {
+					return err
+				}
+				delete(after, before.ID)
+				continue
+			}
+			if moved, ok := r.movedSchedule(before.ID, animalID); ok {
+				if err := log.record(model.EntityFeedingSchedule, before.ID, before, moved); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := log.record(model.EntityFeedingSchedule, before.ID, before, nil); err != nil {
+				return err
+			}
+		}
+		for id, schedule := range after {
+			if _, existed := r.base.findSchedule(id); existed {
+				// Записано вместе со старым животным
+				continue
+			}
+			if err := log.record(model.EntityFeedingSchedule, id, nil, schedule); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// movedSchedule - новое состояние расписания, если оно теперь у другого животного
+func (r *stagedFeedingScheduleRepository) movedSchedule(id uuid.UUID, fromAnimalID uuid.UUID) (model.FeedingSchedule, bool) {
+	for animalID, schedules := range r.changed {
+		if animalID == fromAnimalID {
+			continue
+		}
+		for _, schedule := range schedules {
+			if schedule.ID == id {
+				return schedule, true
+			}
+		}
+	}
+	return model.FeedingSchedule{}, false
+}
+
+func (r *stagedFeedingScheduleRepository) apply() {
+	for animalID, schedules := range r.changed {
+		if len(schedules) == 0 {
+			delete(r.base.schedules, animalID)
+			continue
+		}
+		r.base.schedules[animalID] = schedules
+	}
+}
+
+// stagedMovementRepository - новые записи истории внутри транзакции;
+// purged - животные, история которых удаляется вместе с ними
+type stagedMovementRepository struct {
+	base   *InMemoryMovementRepository
+	added  []model.Movement
+	purged map[uuid.UUID]struct{}
+}
+
+func newStagedMovementRepository(base *InMemoryMovementRepository) *stagedMovementRepository {
+	return &stagedMovementRepository{base: base, purged: make(map[uuid.UUID]struct{})}
+}
+
+func (r *stagedMovementRepository) Add(movement model.Movement) error {
+	r.added = append(r.added, movement)
+	return nil
+}
+
+func (r *stagedMovementRepository) FindByAnimalID(animalID uuid.UUID) ([]model.Movement, error) {
+	if _, ok := r.purged[animalID]; ok {
+		return make([]model.Movement, 0), nil
+	}
+	result, err := r.base.FindByAnimalID(animalID)
+	if err != nil {
+		return nil, err
+	}
+	for _, movement := range r.added {
+		if movement.AnimalID == animalID {
+			result = append(result, movement)
+		}
+	}
+	return result, nil
+}
+
+func (r *stagedMovementRepository) FindAll() ([]model.Movement, error) {
+	result, err := r.base.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, r.added...)
+	return slices.DeleteFunc(result, func(movement model.Movement) bool {
+		_, ok := r.purged[movement.AnimalID]
+		return ok
+	}), nil
+}
+
+func (r *stagedMovementRepository) DeleteByAnimalID(animalID uuid.UUID) error {
+	r.purged[animalID] = struct{}{}
+	r.added = slices.DeleteFunc(r.added, func(movement model.Movement) bool {
+		return movement.AnimalID == animalID
+	})
+	return nil
+}
+
+func (r *stagedMovementRepository) audit(log *auditLog) error {
+	for _, movement := range r.base.movements {
+		if _, ok := r.purged[movement.AnimalID]; !ok {
+			continue
+		}
+		if err := log.record(model.EntityMovement, movement.ID, movement, nil); err != nil {
+			return err
+		}
+	}
+	for _, movement := range r.added {
+		if err := log.record(model.EntityMovement, movement.ID, nil, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedMovementRepository) apply() {
+	for animalID := range r.purged {
+		r.base.deleteByAnimalID(animalID)
+	}
+	r.base.movements = append(r.base.movements, r.added...)
+}
+
+// stagedTransferRequestRepository - изменения заявок на перемещение внутри транзакции
+type stagedTransferRequestRepository struct {
+	base     *InMemoryTransferRequestRepository
+	saved    map[uuid.UUID]model.TransferRequest
+	expected map[uuid.UUID]int
+	// purged - животные, заявки которых удаляются вместе с ними
+	purged map[uuid.UUID]struct{}
+}
+
+func newStagedTransferRequestRepository(base *InMemoryTransferRequestRepository) *stagedTransferRequestRepository {
+	return &stagedTransferRequestRepository{
+		base:     base,
+		saved:    make(map[uuid.UUID]model.TransferRequest),
+		expected: make(map[uuid.UUID]int),
+		purged:   make(map[uuid.UUID]struct{}),
+	}
+}
+
+func (r *stagedTransferRequestRepository) Save(request model.TransferRequest) error {
+	version := 0
+	if current, err := r.FindByID(request.ID); err == nil {
+		version = current.Version
+	}
+	if request.Version != version {
+		return model.ErrVersionConflict
+	}
+	if _, ok := r.expected[request.ID]; !ok {
+		r.expected[request.ID] = version
+	}
+
+	request.Version++
+	r.saved[request.ID] = request
+	return nil
+}
+
+func (r *stagedTransferRequestRepository) FindByID(id uuid.UUID) (*model.TransferRequest, error) {
+	if request, ok := r.saved[id]; ok {
+		return &request, nil
+	}
+	request, err := r.base.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.purged[request.AnimalID]; ok {
+		return nil, model.ErrTransferNotFound
+	}
+	return request, nil
+}
+
+func (r *stagedTransferRequestRepository) FindAll() ([]model.TransferRequest, error) {
+	baseRequests, err := r.base.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]model.TransferRequest, 0, len(baseRequests)+len(r.saved))
+	for _, request := range baseRequests {
+		if _, ok := r.purged[request.AnimalID]; ok {
+			continue
+		}
+		if _, ok := r.saved[request.ID]; !ok {
+			requests = append(requests, request)
+		}
+	}
+	for _, request := range r.saved {
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+func (r *stagedTransferRequestRepository) FindPendingByEnclosure(enclosureID uuid.UUID) ([]model.TransferRequest, error) {
+	requests, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return filterPendingByEnclosure(requests, enclosureID), nil
+}
+
+func (r *stagedTransferRequestRepository) DeleteByAnimalID(animalID uuid.UUID) error {
+	r.purged[animalID] = struct{}{}
+	for id, request := range r.saved {
+		if request.AnimalID == animalID {
+			delete(r.saved, id)
+		}
+	}
+	return nil
+}
+
+func (r *stagedTransferRequestRepository) validate() error {
+	for id, version := range r.expected {
+		if r.base.requests[id].Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	return nil
+}
+
+func (r *stagedTransferRequestRepository) audit(log *auditLog) error {
+	for id, request := range r.base.requests {
+		if _, ok := r.purged[request.AnimalID]; !ok {
+			continue
+		}
+		if err := log.record(model.EntityTransferRequest, id, request, nil); err != nil {
+			return err
+		}
+	}
+	for id, request := range r.saved {
+		var before any
+		if current, ok := r.base.requests[id]; ok {
+			before = current
+		}
+		if err := log.record(model.EntityTransferRequest, id, before, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedTransferRequestRepository) apply() {
+	for animalID := range r.purged {
+		r.base.deleteByAnimalID(animalID)
+	}
+	for id, request := range r.saved {
+		r.base.requests[id] = request
+	}
+}
+
+// stagedUserRepository - изменения пользователей внутри транзакции
+type stagedUserRepository struct {
+	base     *InMemoryUserRepository
+	saved    map[uuid.UUID]model.User
+	expected map[uuid.UUID]int
+}
+
+func newStagedUserRepository(base *InMemoryUserRepository) *stagedUserRepository {
+	return &stagedUserRepository{
+		base:     base,
+		saved:    make(map[uuid.UUID]model.User),
+		expected: make(map[uuid.UUID]int),
+	}
+}
+
+func (r *stagedUserRepository) Save(user model.User) error {
+	version := 0
+	if current, err := r.FindByID(user.ID); err == nil {
+		version = current.Version
+	}
+	if user.Version != version {
+		return model.ErrVersionConflict
+	}
+	if other, err := r.FindByUsername(user.Username); err == nil && other.ID != user.ID {
+		return model.ErrUserExists
+	}
+	if _, ok := r.expected[user.ID]; !ok {
+		r.expected[user.ID] = version
+	}
+
+	user.Version++
+	r.saved[user.ID] = user
+	return nil
+}
+
+func (r *stagedUserRepository) FindByID(id uuid.UUID) (*model.User, error) {
+	if user, ok := r.saved[id]; ok {
+		return &user, nil
+	}
+	return r.base.FindByID(id)
+}
+
+func (r *stagedUserRepository) FindByUsername(username string) (*model.User, error) {
+	users, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, model.ErrUserNotFound
+}
+
+func (r *stagedUserRepository) FindAll() ([]model.User, error) {
+	baseUsers, err := r.base.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]model.User, 0, len(baseUsers)+len(r.saved))
+	for _, user := range baseUsers {
+		if _, ok := r.saved[user.ID]; !ok {
+			users = append(users, user)
+		}
+	}
+	for _, user := range r.saved {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// validate - кроме версий проверяет, что имя не заняли в обход транзакции
+func (r *stagedUserRepository) validate() error {
+	for id, version := range r.expected {
+		if r.base.users[id].Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	for id, user := range r.saved {
+		if other, exists := r.base.findByUsername(user.Username); exists && other.ID != id {
+			return model.ErrUserExists
+		}
+	}
+	return nil
+}
+
+func (r *stagedUserRepository) audit(log *auditLog) error {
+	for id, user := range r.saved {
+		var before any
+		if current, ok := r.base.users[id]; ok {
+			before = current
+		}
+		if err := log.record(model.EntityUser, id, before, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedUserRepository) apply() {
+	for id, user := range r.saved {
+		r.base.users[id] = user
+	}
+}
+
+// stagedWebhookRepository - изменения подписок на вебхуки внутри транзакции
+type stagedWebhookRepository struct {
+	base     *InMemoryWebhookRepository
+	saved    map[uuid.UUID]model.WebhookSubscription
+	deleted  map[uuid.UUID]struct{}
+	expected map[uuid.UUID]int
+}
+
+func newStagedWebhookRepository(base *InMemoryWebhookRepository) *stagedWebhookRepository {
+	return &stagedWebhookRepository{
+		base:     base,
+		saved:    make(map[uuid.UUID]model.WebhookSubscription),
+		deleted:  make(map[uuid.UUID]struct{}),
+		expected: make(map[uuid.UUID]int),
+	}
+}
+
+func (r *stagedWebhookRepository) Save(subscription model.WebhookSubscription) error {
+	version := 0
+	if current, err := r.FindByID(subscription.ID); err == nil {
+		version = current.Version
+	}
+	if subscription.Version != version {
+		return model.ErrVersionConflict
+	}
+	r.expect(subscription.ID, version)
+
+	subscription.Version++
+	r.saved[subscription.ID] = subscription
+	return nil
+}
+
+func (r *stagedWebhookRepository) FindByID(id uuid.UUID) (*model.WebhookSubscription, error) {
+	if _, ok := r.deleted[id]; ok {
+		return nil, model.ErrWebhookNotFound
+	}
+	if subscription, ok := r.saved[id]; ok {
+		return &subscription, nil
+	}
+	return r.base.FindByID(id)
+}
+
+func (r *stagedWebhookRepository) FindAll() ([]model.WebhookSubscription, error) {
+	baseSubscriptions, err := r.base.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]model.WebhookSubscription, 0, len(baseSubscriptions)+len(r.saved))
+	for _, subscription := range baseSubscriptions {
+		_, saved := r.saved[subscription.ID]
+		_, deleted := r.deleted[subscription.ID]
+		if !saved && !deleted {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	for _, subscription := range r.saved {
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+func (r *stagedWebhookRepository) Delete(id uuid.UUID) error {
+	current, err := r.FindByID(id)
+	if err != nil {
+		return err
+	}
+	r.expect(id, current.Version)
+	delete(r.saved, id)
+	r.deleted[id] = struct{}{}
+	return nil
+}
+
+func (r *stagedWebhookRepository) expect(id uuid.UUID, version int) {
+	if _, ok := r.expected[id]; !ok {
+		r.expected[id] = version
+	}
+}
+
+func (r *stagedWebhookRepository) validate() error {
+	for id, version := range r.expected {
+		if r.base.subscriptions[id].Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	return nil
+}
+
+func (r *stagedWebhookRepository) audit(log *auditLog) error {
+	for id := range r.deleted {
+		if before, ok := r.base.subscriptions[id]; ok {
+			if err := log.record(model.EntityWebhook, id, before, nil); err != nil {
+				return err
+			}
+		}
+	}
+	for id, subscription := range r.saved {
+		var before any
+		if current, ok := r.base.subscriptions[id]; ok {
+			before = current
+		}
+		if err := log.record(model.EntityWebhook, id, before, subscription); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedWebhookRepository) apply() {
+	for id := range r.deleted {
+		delete(r.base.subscriptions, id)
+	}
+	for id, subscription := range r.saved {
+		r.base.subscriptions[id] = subscription
+	}
+}
+
+// stagedMedicalRecordRepository - изменения медицинских карт внутри транзакции
+type stagedMedicalRecordRepository struct {
+	base     *InMemoryMedicalRecordRepository
+	saved    map[uuid.UUID]model.MedicalCase
+	expected map[uuid.UUID]int
+	added    []model.MedicalEntry
+	// purged - животные, карты которых удаляются вместе с ними
+	purged map[uuid.UUID]struct{}
+}
+
+func newStagedMedicalRecordRepository(base *InMemoryMedicalRecordRepository) *stagedMedicalRecordRepository {
+	return &stagedMedicalRecordRepository{
+		base:     base,
+		saved:    make(map[uuid.UUID]model.MedicalCase),
+		expected: make(map[uuid.UUID]int),
+		purged:   make(map[uuid.UUID]struct{}),
+	}
+}
+
+func (r *stagedMedicalRecordRepository) SaveCase(medicalCase model.MedicalCase) error {
+	version := 0
+	if current, err := r.FindCaseByID(medicalCase.ID); err == nil {
+		version = current.Version
+	}
+	if medicalCase.Version != version {
+		return model.ErrVersionConflict
+	}
+	if _, ok := r.expected[medicalCase.ID]; !ok {
+		r.expected[medicalCase.ID] = version
+	}
+
+	medicalCase.Version++
+	r.saved[medicalCase.ID] = medicalCase
+	return nil
+}
+
+func (r *stagedMedicalRecordRepository) FindCaseByID(id uuid.UUID) (*model.MedicalCase, error) {
+	if medicalCase, ok := r.saved[id]; ok {
+		return &medicalCase, nil
+	}
+	medicalCase, err := r.base.FindCaseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.purged[medicalCase.AnimalID]; ok {
+		return nil, model.ErrMedicalCaseNotFound
+	}
+	return medicalCase, nil
+}
+
+func (r *stagedMedicalRecordRepository) AddEntry(entry model.MedicalEntry) error {
+	r.added = append(r.added, entry)
+	return nil
+}
+
+func (r *stagedMedicalRecordRepository) FindByAnimalID(animalID uuid.UUID) (*model.MedicalRecord, error) {
+	cases, entries := r.snapshot()
+	return buildMedicalRecord(animalID, cases, entries), nil
+}
+
+func (r *stagedMedicalRecordRepository) FindOpenCases() ([]model.MedicalCase, error) {
+	cases, _ := r.snapshot()
+	return filterOpenCases(cases), nil
+}
+
+// snapshot - случаи и записи базы вместе с изменениями транзакции
+func (r *stagedMedicalRecordRepository) snapshot() ([]model.MedicalCase, []model.MedicalEntry) {
+	r.base.mu.RLock()
+	defer r.base.mu.RUnlock()
+
+	cases := make([]model.MedicalCase, 0, len(r.base.cases)+len(r.saved))
+	for id, medicalCase := range r.base.cases {
+		if _, ok := r.purged[medicalCase.AnimalID]; ok {
+			continue
+		}
+		if _, ok := r.saved[id]; !ok {
+			cases = append(cases, medicalCase)
+		}
+	}
+	for _, medicalCase := range r.saved {
+		cases = append(cases, medicalCase)
+	}
+	entries := make([]model.MedicalEntry, 0, len(r.base.entries)+len(r.added))
+	for _, entry := range r.base.entries {
+		if _, ok := r.purged[entry.AnimalID]; !ok {
+			entries = append(entries, entry)
+		}
+	}
+	return cases, append(entries, r.added...)
+}
+
+func (r *stagedMedicalRecordRepository) DeleteByAnimalID(animalID uuid.UUID) error {
+	r.purged[animalID] = struct{}{}
+	for id, medicalCase := range r.saved {
+		if medicalCase.AnimalID == animalID {
+			delete(r.saved, id)
+		}
+	}
+	r.added = slices.DeleteFunc(r.added, func(entry model.MedicalEntry) bool {
+		return entry.AnimalID == animalID
+	})
+	return nil
+}
+
+func (r *stagedMedicalRecordRepository) validate() error {
+	for id, version := range r.expected {
+		if r.base.cases[id].Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	return nil
+}
+
+func (r *stagedMedicalRecordRepository) audit(log *auditLog) error {
+	for id, medicalCase := range r.base.cases {
+		if _, ok := r.purged[medicalCase.AnimalID]; !ok {
+			continue
+		}
+		if err := log.record(model.EntityMedicalCase, id, medicalCase, nil); err != nil {
+			return err
+		}
+	}
+	for _, entry := range r.base.entries {
+		if _, ok := r.purged[entry.AnimalID]; !ok {
+			continue
+		}
+		if err := log.record(model.EntityMedicalEntry, entry.ID, entry, nil); err != nil {
+			return err
+		}
+	}
+	for id, medicalCase := range r.saved {
+		var before any
+		if current, ok := r.base.cases[id]; ok {
+			before = current
+		}
+		if err := log.record(model.EntityMedicalCase, id, before, medicalCase); err != nil {
+			return err
+		}
+	}
+	for _, entry := range r.added {
+		if err := log.record(model.EntityMedicalEntry, entry.ID, nil, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedMedicalRecordRepository) apply() {
+	for animalID := range r.purged {
+		r.base.deleteByAnimalID(animalID)
+	}
+	for id, medicalCase := range r.saved {
+		r.base.cases[id] = medicalCase
+	}
+	r.base.entries = append(r.base.entries, r.added...)
+}
+
+// stagedCarePlanRepository - изменения планов профилактики внутри транзакции
+type stagedCarePlanRepository struct {
+	base     *InMemoryCarePlanRepository
+	saved    map[uuid.UUID]model.CarePlan
+	deleted  map[uuid.UUID]struct{}
+	expected map[uuid.UUID]int
+}
+
+func newStagedCarePlanRepository(base *InMemoryCarePlanRepository) *stagedCarePlanRepository {
+	return &stagedCarePlanRepository{
+		base:     base,
+		saved:    make(map[uuid.UUID]model.CarePlan),
+		deleted:  make(map[uuid.UUID]struct{}),
+		expected: make(map[uuid.UUID]int),
+	}
+}
+
+func (r *stagedCarePlanRepository) Save(plan model.CarePlan) error {
+	version := 0
+	if current, err := r.FindByID(plan.ID); err == nil {
+		version = current.Version
+	}
+	if plan.Version != version {
+		return model.ErrVersionConflict
+	}
+	r.expect(plan.ID, version)
+
+	plan.Version++
+	r.saved[plan.ID] = plan
+	return nil
+}
+
+func (r *stagedCarePlanRepository) FindByID(id uuid.UUID) (*model.CarePlan, error) {
+	if _, ok := r.deleted[id]; ok {
+		return nil, model.ErrCarePlanNotFound
+	}
+	if plan, ok := r.saved[id]; ok {
+		return &plan, nil
+	}
+	return r.base.FindByID(id)
+}
+
+func (r *stagedCarePlanRepository) FindBySpecies(species string) (*model.CarePlan, error) {
+	plans, err := r.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, plan := range plans {
+		if strings.EqualFold(plan.Species, species) {
+			return &plan, nil
+		}
+	}
+	return nil, model.ErrCarePlanNotFound
+}
+
+func (r *stagedCarePlanRepository) FindAll() ([]model.CarePlan, error) {
+	basePlans, err := r.base.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]model.CarePlan, 0, len(basePlans)+len(r.saved))
+	for _, plan := range basePlans {
+		_, saved := r.saved[plan.ID]
+		_, deleted := r.deleted[plan.ID]
+		if !saved && !deleted {
+			plans = append(plans, plan)
+		}
+	}
+	for _, plan := range r.saved {
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func (r *stagedCarePlanRepository) Delete(id uuid.UUID) error {
+	current, err := r.FindByID(id)
+	if err != nil {
+		return err
+	}
+	r.expect(id, current.Version)
+	delete(r.saved, id)
+	r.deleted[id] = struct{}{}
+	return nil
+}
+
+func (r *stagedCarePlanRepository) expect(id uuid.UUID, version int) {
+	if _, ok := r.expected[id]; !ok {
+		r.expected[id] = version
+	}
+}
+
+func (r *stagedCarePlanRepository) validate() error {
+	for id, version := range r.expected {
+		if r.base.plans[id].Version != version {
+			return model.ErrVersionConflict
+		}
+	}
+	return nil
+}
+
+func (r *stagedCarePlanRepository) audit(log *auditLog) error {
+	for id := range r.deleted {
+		if before, ok := r.base.plans[id]; ok {
+			if err := log.record(model.EntityCarePlan, id, before, nil); err != nil {
+				return err
+			}
+		}
+	}
+	for id, plan := range r.saved {
+		var before any
+		if current, ok := r.base.plans[id]; ok {
+			before = current
+		}
+		if err := log.record(model.EntityCarePlan, id, before, plan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stagedCarePlanRepository) apply() {
+	for id := range r.deleted {
+		delete(r.base.plans, id)
+	}
+	for id, plan := range r.saved {
+		r.base.plans[id] = plan
+	}
+}
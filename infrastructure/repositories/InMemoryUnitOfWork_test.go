@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"kpo-mini-dz2/domain/model"
+	"testing"
+	"time"
+)
+
+// waitTimeout - сколько ждать, чтобы считать, что горутина заблокирована
+const waitTimeout = 50 * time.Millisecond
+
+func newTestEnclosure(t *testing.T, name string) model.Enclosure {
+	t.Helper()
+	enclosure, err := model.NewEnclosure(name, model.Predator, model.Size{Lenght: 10, Width: 10, Height: 3}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *enclosure
+}
+
+func TestUnitOfWorkRollbackDiscardsChanges(t *testing.T) {
+	store := NewInMemoryStore(100)
+	factory := NewInMemoryUnitOfWorkFactory(store)
+
+	tx, err := factory.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Enclosures().Save(newTestEnclosure(t, "north")); err != nil {
+		t.Fatal(err)
+	}
+	tx.Rollback()
+
+	if enclosures, _ := store.Enclosures.FindAll(); len(enclosures) != 0 {
+		t.Fatalf("enclosures after rollback = %+v, want none", enclosures)
+	}
+	if err := tx.Commit(); !errors.Is(err, model.ErrTransactionClosed) {
+		t.Fatalf("Commit() after Rollback() = %v, want %v", err, model.ErrTransactionClosed)
+	}
+}
+
+func TestUnitOfWorkConflictAppliesNothing(t *testing.T) {
+	store := NewInMemoryStore(100)
+	factory := NewInMemoryUnitOfWorkFactory(store)
+	stale := newTestEnclosure(t, "north")
+	if err := store.Enclosures.Save(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, _ := factory.Begin(context.Background())
+	defer tx.Rollback()
+	if err := tx.Enclosures().Save(newTestEnclosure(t, "south")); err != nil {
+		t.Fatal(err)
+	}
+	loaded, _ := tx.Enclosures().FindByID(stale.ID)
+	loaded.Name = "north-renamed"
+	if err := tx.Enclosures().Save(*loaded); err != nil {
+		t.Fatal(err)
+	}
+	// Вольер изменили в обход транзакции
+	outside, _ := store.Enclosures.FindByID(stale.ID)
+	outside.MaxCapacity = 7
+	if err := store.Enclosures.Save(*outside); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Commit(); !errors.Is(err, model.ErrVersionConflict) {
+		t.Fatalf("Commit() = %v, want %v", err, model.ErrVersionConflict)
+	}
+	if enclosures, _ := store.Enclosures.FindAll(); len(enclosures) != 1 || enclosures[0].Name != "north" {
+		t.Fatalf("enclosures after conflict = %+v, want only the untouched north", enclosures)
+	}
+}
+
+func TestUnitOfWorkReadBlocksCommitUntilFinished(t *testing.T) {
+	store := NewInMemoryStore(100)
+	factory := NewInMemoryUnitOfWorkFactory(store)
+
+	read, err := factory.BeginRead(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, _ := read.Enclosures().FindAll()
+
+	committed := make(chan error, 1)
+	go func() {
+		tx, _ := factory.Begin(context.Background())
+		defer tx.Rollback()
+		if err := tx.Enclosures().Save(newTestEnclosure(t, "north")); err != nil {
+			committed <- err
+			return
+		}
+		committed <- tx.Commit()
+	}()
+
+	select {
+	case err := <-committed:
+		t.Fatalf("Commit() finished while a read transaction was open: %v", err)
+	case <-time.After(waitTimeout):
+	}
+	if after, _ := read.Enclosures().FindAll(); len(after) != len(before) {
+		t.Fatalf("read transaction saw %d enclosures, then %d", len(before), len(after))
+	}
+	if err := read.Commit(); !errors.Is(err, model.ErrReadOnlyTransaction) {
+		t.Fatalf("Commit() of a read transaction = %v, want %v", err, model.ErrReadOnlyTransaction)
+	}
+
+	if err := <-committed; err != nil {
+		t.Fatal(err)
+	}
+	if enclosures, _ := store.Enclosures.FindAll(); len(enclosures) != 1 {
+		t.Fatalf("enclosures after commit = %+v, want one", enclosures)
+	}
+}
+
+func TestUnitOfWorkHooksRunOutsideWriterLock(t *testing.T) {
+	store := NewInMemoryStore(100)
+	factory := NewInMemoryUnitOfWorkFactory(store)
+
+	release := make(chan struct{})
+	entered := make(chan struct{}, 2)
+	var order []string
+	factory.OnCommit(func(ctx context.Context, changes []model.EntityChange) {
+		entered <- struct{}{}
+		if len(order) == 0 {
+			<-release
+		}
+		order = append(order, changes[0].EntityID.String())
+	})
+
+	commit := func(enclosure model.Enclosure) chan error {
+		done := make(chan error, 1)
+		go func() {
+			tx, err := factory.Begin(context.Background())
+			if err != nil {
+				done <- err
+				return
+			}
+			defer tx.Rollback()
+			if err := tx.Enclosures().Save(enclosure); err != nil {
+				done <- err
+				return
+			}
+			done <- tx.Commit()
+		}()
+		return done
+	}
+
+	first, second := newTestEnclosure(t, "north"), newTestEnclosure(t, "south")
+	firstDone := commit(first)
+	<-entered
+
+	// Хук первой транзакции ещё работает, а вторая уже применила изменения
+	secondDone := commit(second)
+	deadline := time.After(time.Second)
+	for {
+		if _, err := store.Enclosures.FindByID(second.ID); err == nil {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("second transaction was not applied while the first hook was running")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	// Но её хуки ждут первые, чтобы подписчики видели коммиты по порядку
+	select {
+	case <-entered:
+		t.Fatal("second hook started before the first one finished")
+	case <-time.After(waitTimeout):
+	}
+
+	close(release)
+	for _, done := range []chan error{firstDone, secondDone} {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(order) != 2 || order[0] != first.ID.String() || order[1] != second.ID.String() {
+		t.Fatalf("hook order = %v, want [%s %s]", order, first.ID, second.ID)
+	}
+}
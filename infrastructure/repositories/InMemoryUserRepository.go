@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]model.User
+}
+
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{
+		users: make(map[uuid.UUID]model.User),
+	}
+}
+
+func (r *InMemoryUserRepository) Save(user model.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.users[user.ID]; exists && current.Version != user.Version {
+		return model.ErrVersionConflict
+	}
+	if other, exists := r.findByUsername(user.Username); exists && other.ID != user.ID {
+		return model.ErrUserExists
+	}
+
+	user.Version++
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) FindByID(id uuid.UUID) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[id]
+	if !exists {
+		return nil, model.ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (r *InMemoryUserRepository) FindByUsername(username string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.findByUsername(username)
+	if !exists {
+		return nil, model.ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (r *InMemoryUserRepository) FindAll() ([]model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]model.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// findByUsername вызывается под блокировкой
+func (r *InMemoryUserRepository) findByUsername(username string) (model.User, bool) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, true
+		}
+	}
+	return model.User{}, false
+}
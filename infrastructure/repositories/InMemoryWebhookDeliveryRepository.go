@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryWebhookDeliveryRepository - очередь доставок и их журнал.
+// Хранит не больше maxDeliveries записей: при переполнении первыми уходят
+// самые старые доставленные, затем самые старые недоставленные.
+// Ожидающие доставки не вытесняются. При файловом хранилище очередь,
+// недоставленные и журнал попыток сохраняются в снимке.
+type InMemoryWebhookDeliveryRepository struct {
+	mu            sync.RWMutex
+	deliveries    map[uuid.UUID]model.WebhookDelivery
+	order         []uuid.UUID // в порядке добавления
+	maxDeliveries int
+}
+
+func NewInMemoryWebhookDeliveryRepository(maxDeliveries int) *InMemoryWebhookDeliveryRepository {
+	return &InMemoryWebhookDeliveryRepository{
+		deliveries:    make(map[uuid.UUID]model.WebhookDelivery),
+		maxDeliveries: maxDeliveries,
+	}
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Add(delivery model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries[delivery.ID] = delivery
+	r.order = append(r.order, delivery.ID)
+	if len(r.order) > r.maxDeliveries {
+		r.evict()
+	}
+	return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) Save(delivery model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return model.ErrDeliveryNotFound
+	}
+	r.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) FindByID(id uuid.UUID) (*model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, exists := r.deliveries[id]
+	if !exists {
+		return nil, model.ErrDeliveryNotFound
+	}
+	return &delivery, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) FindBySubscription(subscriptionID uuid.UUID, limit int) ([]model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []model.WebhookDelivery
+	for i := len(r.order) - 1; i >= 0 && len(result) < limit; i-- {
+		if delivery := r.deliveries[r.order[i]]; delivery.SubscriptionID == subscriptionID {
+			result = append(result, delivery)
+		}
+	}
+	return result, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) FindDead() ([]model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []model.WebhookDelivery
+	for i := len(r.order) - 1; i >= 0; i-- {
+		if delivery := r.deliveries[r.order[i]]; delivery.Status == model.DeliveryDead {
+			result = append(result, delivery)
+		}
+	}
+	return result, nil
+}
+
+func (r *InMemoryWebhookDeliveryRepository) ClaimDue(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result []model.WebhookDelivery
+	for _, id := range r.order {
+		if len(result) >= limit {
+			break
+		}
+		delivery := r.deliveries[id]
+		if delivery.Status != model.DeliveryPending || delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery.Status = model.DeliveryInProgress
+		r.deliveries[id] = delivery
+		result = append(result, delivery)
+	}
+	return result, nil
+}
+
+// evict вызывается под блокировкой
+func (r *InMemoryWebhookDeliveryRepository) evict() {
+	for _, status := range []model.DeliveryStatus{model.DeliveryDelivered, model.DeliveryDead} {
+		index := slices.IndexFunc(r.order, func(id uuid.UUID) bool {
+			return r.deliveries[id].Status == status
+		})
+		if index >= 0 {
+			delete(r.deliveries, r.order[index])
+			r.order = slices.Delete(r.order, index, index+1)
+			return
+		}
+	}
+}
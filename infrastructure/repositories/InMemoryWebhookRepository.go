@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"kpo-mini-dz2/domain/model"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryWebhookRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[uuid.UUID]model.WebhookSubscription
+}
+
+func NewInMemoryWebhookRepository() *InMemoryWebhookRepository {
+	return &InMemoryWebhookRepository{
+		subscriptions: make(map[uuid.UUID]model.WebhookSubscription),
+	}
+}
+
+func (r *InMemoryWebhookRepository) Save(subscription model.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.subscriptions[subscription.ID]; exists && current.Version != subscription.Version {
+		return model.ErrVersionConflict
+	}
+
+	subscription.Version++
+	r.subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (r *InMemoryWebhookRepository) FindByID(id uuid.UUID) (*model.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, exists := r.subscriptions[id]
+	if !exists {
+		return nil, model.ErrWebhookNotFound
+	}
+	return &subscription, nil
+}
+
+func (r *InMemoryWebhookRepository) FindAll() ([]model.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriptions := make([]model.WebhookSubscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+func (r *InMemoryWebhookRepository) Delete(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.subscriptions[id]; !exists {
+		return model.ErrWebhookNotFound
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
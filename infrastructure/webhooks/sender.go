@@ -0,0 +1,44 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// maxResponseBody - сколько ответа получателя читается, чтобы переиспользовать соединение
+const maxResponseBody = 64 << 10
+
+// HTTPSender - отправляет вебхуки обычным POST-запросом
+type HTTPSender struct {
+	Client *http.Client
+}
+
+func NewHTTPSender() *HTTPSender {
+	return &HTTPSender{Client: &http.Client{
+		// Перенаправления не выполняются: получатель должен указать точный адрес
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("User-Agent", "zoo-webhooks/1")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, nil
+}
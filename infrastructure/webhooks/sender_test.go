@@ -0,0 +1,52 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHTTPSenderDoesNotFollowRedirects(t *testing.T) {
+	var redirected atomic.Bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected.Store(true)
+	}))
+	defer target.Close()
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	status, err := NewHTTPSender().Send(context.Background(), receiver.URL, nil, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if status != http.StatusTemporaryRedirect {
+		t.Fatalf("Send() status = %d, want %d", status, http.StatusTemporaryRedirect)
+	}
+	if redirected.Load() {
+		t.Fatal("Send() followed the redirect")
+	}
+}
+
+func TestHTTPSenderSendsHeadersAndBody(t *testing.T) {
+	var gotSignature, gotBody string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Zoo-Signature")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer receiver.Close()
+
+	status, err := NewHTTPSender().Send(context.Background(), receiver.URL, map[string]string{"X-Zoo-Signature": "t=1,v1=ab"}, []byte(`{"id":1}`))
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if status != http.StatusAccepted || gotSignature != "t=1,v1=ab" || gotBody != `{"id":1}` {
+		t.Fatalf("receiver got status %d, signature %q, body %q", status, gotSignature, gotBody)
+	}
+}
@@ -1,11 +1,36 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/fixtures"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/infrastructure/auth"
+	"kpo-mini-dz2/infrastructure/config"
+	"kpo-mini-dz2/infrastructure/logging"
+	"kpo-mini-dz2/infrastructure/metrics"
 	"kpo-mini-dz2/infrastructure/repositories"
+	"kpo-mini-dz2/infrastructure/signing"
+	"kpo-mini-dz2/infrastructure/webhooks"
 	"kpo-mini-dz2/presentation/controllers"
+	"kpo-mini-dz2/presentation/graphqlapi"
+	"kpo-mini-dz2/presentation/grpcapi"
+	appMiddleware "kpo-mini-dz2/presentation/middleware"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
-	_ "kpo-mini-dz2/docs"
+	"kpo-mini-dz2/docs"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -25,46 +50,476 @@ import (
 // @license.url https://opensource.org/licenses/MIT
 
 // @host localhost:8080
-// @BasePath /api/v1
+// @BasePath /
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description "Bearer <token>" from POST /api/auth/login
 
 func main() {
+	// 0. Конфигурация
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Ошибка конфигурации:", err)
+		os.Exit(2)
+	}
+	level, _ := cfg.SlogLevel()
+	slog.SetDefault(slog.New(logging.NewHandler(os.Stderr, cfg.LogFormat == config.LogFormatText, level)))
+	time.Local = cfg.Location()
+	slog.Info("effective configuration", "config", cfg.String())
+
+	// Контекст отменяется по SIGINT/SIGTERM, workersCtx - после остановки HTTP-сервера
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workers sync.WaitGroup
+
+	// 1. Инициализация репозиториев
+	store := repositories.NewInMemoryStore(cfg.Webhooks.LogSize)
+	var fileStore *repositories.FileSnapshotStore
+	if cfg.Storage.Backend == config.BackendFile {
+		fileStore = repositories.NewFileSnapshotStore(cfg.Storage.Path, store)
+		if err := fileStore.Load(); err != nil {
+			slog.Error("не удалось загрузить данные", "path", cfg.Storage.Path, "error", err)
+			os.Exit(1)
+		}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			fileStore.Run(workersCtx, cfg.Storage.FlushInterval)
+		}()
+	}
+	animalRepo := store.Animals
+	enclosureRepo := store.Enclosures
+	feedingRepo := store.FeedingSchedules
+	unitOfWork := repositories.NewInMemoryUnitOfWorkFactory(store)
+	eventBus := services.NewEventBus(cfg.Events.ReplaySize)
+	eventPublisher := services.NewZooEventPublisher(eventBus, animalRepo)
+	unitOfWork.OnCommit(eventPublisher.OnCommit)
+
+	var auditKey ed25519.PrivateKey
+	if cfg.Audit.SigningKeyPath != "" {
+		auditKey, err = signing.LoadOrCreateKey(cfg.Audit.SigningKeyPath)
+	} else {
+		slog.Warn("ключ подписи журнала не задан, выгрузки будут подписаны временным ключом")
+		auditKey, err = signing.GenerateKey()
+	}
+	if err != nil {
+		slog.Error("не удалось загрузить ключ подписи журнала", "error", err)
+		os.Exit(1)
+	}
+
+	tokenSecret := []byte(cfg.Auth.TokenSecret)
+	if len(tokenSecret) == 0 {
+		slog.Warn("секрет токенов не задан, после перезапуска всем придётся войти заново")
+		if tokenSecret, err = auth.GenerateSecret(); err != nil {
+			slog.Error("не удалось создать секрет токенов", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// 2. Инициализация сервисов
+	animalService := services.NewAnimalService(unitOfWork)
+	enclosureService := services.NewEnclosureService(unitOfWork)
+	transferService := services.NewAnimalTransferService(unitOfWork)
+	movementService := services.NewMovementHistoryService(store.Movements)
+	transferRequestService := services.NewTransferRequestService(unitOfWork, store.TransferRequests)
+	feedingService := services.NewFeedingService(unitOfWork, feedingRepo)
+	auditService := services.NewAuditService(store.Audit, auditKey)
+	if verification, err := auditService.Verify(); err == nil && !verification.Valid {
+		slog.Error("журнал аудита повреждён", "brokenAt", verification.BrokenAt, "reason", verification.Reason)
+	}
+	passwordHasher := auth.PBKDF2Hasher{}
+	userService := services.NewUserService(unitOfWork, store.Users, passwordHasher)
+	authService, err := services.NewAuthService(unitOfWork, store.Users, passwordHasher, auth.NewHMACTokenIssuer(tokenSecret, cfg.Auth.TokenTTL))
+	if err != nil {
+		slog.Error("не удалось инициализировать вход в систему", "error", err)
+		os.Exit(1)
+	}
+	if err := ensureAdmin(authService, cfg.Auth); err != nil {
+		slog.Error("не удалось создать администратора", "error", err)
+		os.Exit(1)
+	}
+	webhookDeliveries := store.WebhookDeliveries
+	webhookService := services.NewWebhookService(unitOfWork, store.Webhooks, webhookDeliveries)
+	webhookDispatcher := services.NewWebhookDispatcher(eventBus, store.Webhooks, webhookDeliveries, webhooks.NewHTTPSender(), services.WebhookRetryPolicy{
+		MaxAttempts:    cfg.Webhooks.MaxAttempts,
+		InitialBackoff: cfg.Webhooks.InitialBackoff,
+		MaxBackoff:     cfg.Webhooks.MaxBackoff,
+		Timeout:        cfg.Webhooks.Timeout,
+	}, cfg.Webhooks.Workers)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		webhookDispatcher.Run(workersCtx)
+	}()
+	bulkService := services.NewBulkService(unitOfWork, animalRepo, enclosureRepo)
+	medicalService := services.NewMedicalRecordService(unitOfWork, animalRepo, store.MedicalRecords)
+	systemCtx := requestcontext.WithActor(context.Background(), requestcontext.Actor{Name: "system"})
+	if opened, err := medicalService.CarryOverHealthStatus(systemCtx); err != nil {
+		slog.Error("не удалось перенести состояние здоровья в медицинские карты", "error", err)
+		os.Exit(1)
+	} else if opened > 0 {
+		slog.Info("открыты случаи для больных животных без медицинской карты", "cases", opened)
+	}
+	careService := services.NewPreventiveCareService(unitOfWork, store.CarePlans, animalRepo, store.MedicalRecords)
+	lineageService := services.NewLineageService(unitOfWork, animalRepo)
+	fixtureService := services.NewFixtureService(unitOfWork, animalRepo, enclosureRepo)
+	if cfg.Fixtures != "" {
+		if err := loadFixtures(fixtureService, cfg.Fixtures); err != nil {
+			slog.Error("не удалось загрузить описание зоопарка", "fixtures", cfg.Fixtures, "error", err)
+			os.Exit(1)
+		}
+	}
+	statisticsService := services.NewZooStatisticsService(unitOfWork, animalRepo, enclosureRepo, feedingRepo, store.Movements)
+
+	metricsRegistry := metrics.NewRegistry()
+	httpMetrics := metrics.NewHTTPMetrics(metricsRegistry)
+	metrics.RegisterZooMetrics(metricsRegistry, statisticsService)
+
+	if cfg.Scheduler.Enabled {
+		scheduler := services.NewFeedingScheduler(feedingRepo, cfg.Scheduler.Interval, func(e model.FeedingTimeEvent) {
+			slog.Info("время кормления", "animal", e.AnimalID, "food", e.FoodType, "at", e.FeedingTime)
+			eventPublisher.OnFeedingTime(e)
+		})
+		careScheduler := services.NewCareReminderScheduler(careService, cfg.Scheduler.Interval, func(task model.CareTask) {
+			slog.Info("пора провести профилактику", "animal", task.AnimalID, "item", task.Item, "due", task.DueAt)
+			eventPublisher.OnCareDue(task)
+		})
+		workers.Add(2)
+		go func() {
+			defer workers.Done()
+			scheduler.Run(workersCtx)
+		}()
+		go func() {
+			defer workers.Done()
+			careScheduler.Run(workersCtx)
+		}()
+	}
+
+	// Мягко удалённое очищается и при выключенном планировщике
+	archivePurger := services.NewArchivePurger(unitOfWork, cfg.Archive.Retention, cfg.Archive.PurgeInterval)
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		archivePurger.Run(requestcontext.WithActor(workersCtx, requestcontext.Actor{Name: "system"}))
+	}()
+
+	// 3. Инициализация контроллеров; ключи Idempotency-Key общие для REST, GraphQL и gRPC
+	idempotency := appMiddleware.NewIdempotencyGuard(repositories.NewInMemoryIdempotencyRepository(), cfg.Idempotency.Retention)
+	animalHandler := &controllers.AnimalHandler{Repo: animalRepo, Service: animalService}
+	enclosureHandler := &controllers.EnclosureHandler{Service: enclosureService}
+	transferHandler := &controllers.TransferHandler{Service: transferService}
+	movementHandler := &controllers.MovementHandler{Service: movementService}
+	transferRequestHandler := &controllers.TransferRequestHandler{Service: transferRequestService}
+	zooStatsHandler := &controllers.ZooStatisticsHandler{AnimalRepo: animalRepo, EnclosureRepo: enclosureRepo, Service: statisticsService}
+	feedingHandler := controllers.NewFeedingHandler(feedingService)
+	auditHandler := &controllers.AuditHandler{Service: auditService}
+	eventsHandler := controllers.NewEventsHandler(eventBus, cfg.Events.Heartbeat)
+	authHandler := &controllers.AuthHandler{Service: authService, Users: userService}
+	userHandler := &controllers.UserHandler{Service: userService}
+	webhookHandler := &controllers.WebhookHandler{Service: webhookService}
+	bulkHandler := &controllers.BulkHandler{Service: bulkService}
+	fixtureHandler := &controllers.FixtureHandler{Service: fixtureService}
+	medicalHandler := &controllers.MedicalRecordHandler{Service: medicalService}
+	careHandler := &controllers.PreventiveCareHandler{Service: careService}
+	lineageHandler := &controllers.LineageHandler{Service: lineageService}
+	graphqlHandler, err := graphqlapi.NewHandler(graphqlapi.Services{
+		UnitOfWork:  unitOfWork,
+		Animals:     animalService,
+		Enclosures:  enclosureService,
+		Feeding:     feedingService,
+		Transfers:   transferService,
+		Movements:   movementService,
+		Statistics:  statisticsService,
+		Idempotency: idempotency,
+	})
+	if err != nil {
+		slog.Error("не удалось разобрать схему GraphQL", "error", err)
+		os.Exit(1)
+	}
+	healthHandler := &controllers.HealthHandler{Checks: []controllers.HealthCheck{
+		{Name: "animals", Check: func() error { _, err := animalRepo.FindAll(); return err }},
+		{Name: "enclosures", Check: func() error { _, err := enclosureRepo.FindAll(); return err }},
+		{Name: "feedingSchedules", Check: func() error { _, err := feedingRepo.GetAllSchedules(); return err }},
+	}}
+	if fileStore != nil {
+		healthHandler.Checks = append(healthHandler.Checks, controllers.HealthCheck{Name: "storage", Check: fileStore.Check})
+	}
+
 	r := chi.NewRouter()
 
 	// Middleware
-	r.Use(middleware.Logger)
+	r.Use(appMiddleware.RequestID)
+	r.Use(appMiddleware.Logger)
+	r.Use(appMiddleware.Metrics(httpMetrics.Requests, httpMetrics.Duration))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.AllowContentType("application/json"))
+	r.Use(appMiddleware.CORS(cfg.CORS.AllowedOrigins, cfg.CORS.AllowedMethods, cfg.CORS.AllowedHeaders))
+	// CSV и YAML принимают только маршруты загрузки, остальным они не разберутся как JSON
+	r.Use(middleware.AllowContentType("application/json", "text/csv", "application/yaml"))
 
-	// 1. Инициализация репозиториев
-	animalRepo := repositories.NewAnimalRepository()
-	enclosureRepo := repositories.NewInMemoryEnclosureRepository()
-	//feedingRepo := repositories.NewInMemoryFeedingScheduleRepository()
+	r.Get("/healthz", healthHandler.Liveness)
+	r.Get("/readyz", healthHandler.Readiness)
+	r.Method(http.MethodGet, "/metrics", metricsRegistry.Handler())
 
-	// 2. Инициализация контроллеров
-	animalHandler := &controllers.AnimalHandler{Repo: animalRepo}
-	zooStatsHandler := &controllers.ZooStatisticsHandler{AnimalRepo: animalRepo, EnclosureRepo: enclosureRepo}
-	//feedingHandler := &controllers.FeedingHandler{Repo: feedingRepo}
-
-	// 4. API роуты
+	// 4. API роуты; права ролей - в model/role.go
+	can := appMiddleware.Require
 	r.Route("/api", func(r chi.Router) {
+		r.Use(appMiddleware.Authenticate(authService))
+		r.Use(appMiddleware.Idempotency(idempotency))
+
+		// Вход и сотрудники
+		r.Post("/auth/login", authHandler.Login)
+		r.With(can(model.PermRead)).Get("/auth/me", authHandler.Me)
+		r.Route("/users", func(r chi.Router) {
+			r.Use(can(model.PermManageUsers))
+			r.Get("/", userHandler.GetAll)
+			r.Post("/", userHandler.Create)
+			r.Get("/{id}", userHandler.GetByID)
+			r.Put("/{id}", userHandler.Update)
+		})
+		// Загрузка описаний зоопарка
+		r.Route("/fixtures", func(r chi.Router) {
+			r.Use(can(model.PermLoadFixtures))
+			r.Post("/", fixtureHandler.Load)
+			r.Post("/demo", fixtureHandler.LoadDemo)
+		})
+		// Вебхуки
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(can(model.PermManageWebhooks))
+			r.Get("/", webhookHandler.GetAll)
+			r.Post("/", webhookHandler.Create)
+			r.Get("/dead-letters", webhookHandler.DeadLetters)
+			r.Post("/deliveries/{id}/redeliver", webhookHandler.Redeliver)
+			r.Get("/{id}", webhookHandler.GetByID)
+			r.Put("/{id}", webhookHandler.Update)
+			r.Delete("/{id}", webhookHandler.Delete)
+			r.Get("/{id}/deliveries", webhookHandler.Deliveries)
+		})
 		// Животные
 		r.Route("/animals", func(r chi.Router) {
-			r.Get("/", animalHandler.GetAll)
-			r.Post("/", animalHandler.Create)
-			r.Get("/{id}", animalHandler.GetByID)
-			r.Delete("/{id}", animalHandler.Delete)
+			r.With(can(model.PermRead)).Get("/", animalHandler.GetAll)
+			r.With(can(model.PermAdmitAnimals)).Post("/", animalHandler.Create)
+			r.With(can(model.PermManageAnimals)).Post("/import", bulkHandler.ImportAnimals)
+			r.With(can(model.PermRead)).Get("/export", bulkHandler.ExportAnimals)
+			r.With(can(model.PermRead)).Get("/{id}", animalHandler.GetByID)
+			// Здоровье так не меняется: оно следует из случаев медицинской карты
+			r.With(can(model.PermManageAnimals)).Put("/{id}", animalHandler.Update)
+			r.With(can(model.PermDeleteAnimals)).Delete("/{id}", animalHandler.Delete)
+			r.With(can(model.PermDeleteAnimals)).Post("/{id}/restore", animalHandler.Restore)
+			r.With(can(model.PermChangeLifecycle)).Post("/{id}/state", animalHandler.ChangeState)
+			r.With(can(model.PermRead)).Get("/{id}/movements", movementHandler.GetAnimalMovements)
+			r.With(can(model.PermManageAnimals)).Put("/{id}/parents", lineageHandler.SetParents)
+			r.With(can(model.PermRead)).Get("/{id}/lineage", lineageHandler.GetLineage)
+			r.With(can(model.PermReadMedical)).Get("/{id}/medical", medicalHandler.GetRecord)
+			r.With(can(model.PermManageMedical)).Post("/{id}/medical/cases", medicalHandler.OpenCase)
+			r.With(can(model.PermManageMedical)).Post("/{id}/medical/cases/{caseId}/close", medicalHandler.CloseCase)
+			r.With(can(model.PermManageMedical)).Post("/{id}/medical/entries", medicalHandler.AddEntry)
+		})
+		r.With(can(model.PermReadMedical)).Get("/medical/cases", medicalHandler.GetOpenCases)
+		// Родословные
+		r.Route("/lineage", func(r chi.Router) {
+			r.Use(can(model.PermRead))
+			r.Get("/kinship", lineageHandler.GetKinship)
+			r.Get("/issues", lineageHandler.GetIssues)
+			r.Get("/pairs", lineageHandler.GetPairs)
+		})
+		// Профилактика
+		r.Route("/vet", func(r chi.Router) {
+			r.With(can(model.PermReadMedical)).Get("/due", careHandler.GetDue)
+			r.With(can(model.PermReadMedical)).Get("/plans", careHandler.GetPlans)
+			r.With(can(model.PermManageMedical)).Post("/plans", careHandler.CreatePlan)
+			r.With(can(model.PermReadMedical)).Get("/plans/{id}", careHandler.GetPlan)
+			r.With(can(model.PermManageMedical)).Put("/plans/{id}", careHandler.UpdatePlan)
+			r.With(can(model.PermManageMedical)).Delete("/plans/{id}", careHandler.DeletePlan)
 		})
 		// Вольеры
 		r.Route("/enclosures", func(r chi.Router) {
-			r.Get("/", zooStatsHandler.GetAllEnclosures)
+			r.With(can(model.PermRead)).Get("/", zooStatsHandler.GetAllEnclosures)
+			r.With(can(model.PermManageEnclosures)).Post("/", enclosureHandler.Create)
+			r.With(can(model.PermManageEnclosures)).Post("/import", bulkHandler.ImportEnclosures)
+			r.With(can(model.PermRead)).Get("/export", bulkHandler.ExportEnclosures)
+			r.With(can(model.PermRead)).Get("/{id}", zooStatsHandler.GetEnclosureByID)
+			r.With(can(model.PermManageEnclosures)).Put("/{id}", enclosureHandler.Update)
+			r.With(can(model.PermDeleteEnclosures)).Delete("/{id}", enclosureHandler.Delete)
+			r.With(can(model.PermDeleteEnclosures)).Post("/{id}/restore", enclosureHandler.Restore)
+			r.With(can(model.PermRead)).Get("/{id}/occupancy", movementHandler.GetOccupancy)
+			if cfg.Features.TransferRequests {
+				r.With(can(model.PermRead)).Get("/{id}/transfer-requests", transferRequestHandler.GetPendingByEnclosure)
+			}
+		})
+		// Перемещения
+		r.With(can(model.PermMoveAnimals)).Post("/transfers", transferHandler.Transfer)
+		if cfg.Features.BatchTransfers {
+			r.With(can(model.PermMoveAnimals)).Post("/transfers/batch", transferHandler.TransferBatch)
+		}
+		if cfg.Features.TransferRequests {
+			r.Route("/transfer-requests", func(r chi.Router) {
+				r.With(can(model.PermRead)).Get("/", transferRequestHandler.GetAll)
+				r.With(can(model.PermRequestTransfers)).Post("/", transferRequestHandler.Create)
+				r.With(can(model.PermRead)).Get("/{id}", transferRequestHandler.GetByID)
+				r.With(can(model.PermApproveTransfers)).Post("/{id}/approve", transferRequestHandler.Approve)
+				r.With(can(model.PermApproveTransfers)).Post("/{id}/reject", transferRequestHandler.Reject)
+				r.With(can(model.PermRequestTransfers)).Post("/{id}/schedule", transferRequestHandler.Schedule)
+				r.With(can(model.PermRequestTransfers)).Post("/{id}/execute", transferRequestHandler.Execute)
+				r.With(can(model.PermRequestTransfers)).Post("/{id}/cancel", transferRequestHandler.Cancel)
+			})
+		}
+		// Кормления
+		r.Route("/schedules", func(r chi.Router) {
+			r.With(can(model.PermManageFeeding)).Post("/", feedingHandler.AddSchedule)
+			r.With(can(model.PermManageFeeding)).Delete("/", feedingHandler.RemoveSchedule)
+			r.With(can(model.PermRead)).Get("/{animalID}", feedingHandler.GetAnimalSchedules)
+			r.With(can(model.PermManageFeeding)).Put("/{id}", feedingHandler.UpdateSchedule)
+			r.With(can(model.PermManageFeeding)).Post("/{id}/done", feedingHandler.MarkDone)
+			r.With(can(model.PermManageFeeding)).Post("/{id}/restore", feedingHandler.RestoreSchedule)
+		})
+		// Статистика
+		r.With(can(model.PermRead)).Get("/statistics", zooStatsHandler.GetStatistics)
+		// Живые события
+		r.With(can(model.PermRead)).Get("/events/stream", eventsHandler.Stream)
+		// Журнал изменений
+		r.Route("/audit", func(r chi.Router) {
+			r.Use(can(model.PermReadAudit))
+			r.Get("/", auditHandler.Find)
+			r.Get("/verify", auditHandler.Verify)
+			r.Get("/export", auditHandler.Export)
+			r.Get("/public-key", auditHandler.PublicKey)
 		})
 	})
-	r.Get("/swagger/*", httpSwagger.WrapHandler)
-	// 6. Запуск сервера
-	port := ":3000"
-	println("Swagger docs: http://localhost" + port + "/swagger/index.html")
-	err := http.ListenAndServe(port, r)
+	// GraphQL - права на мутации проверяет сам обработчик, как у маршрутов REST
+	r.With(appMiddleware.Authenticate(authService), can(model.PermRead)).Method(http.MethodPost, "/graphql", graphqlHandler)
+	if cfg.Features.Swagger {
+		docs.SwaggerInfo.Host = ""
+		docs.SwaggerInfo.BasePath = "/"
+		r.Get("/swagger/*", httpSwagger.WrapHandler)
+		slog.Info("Swagger docs: http://localhost" + cfg.ListenAddr + "/swagger/index.html")
+	}
+
+	// 5. Запуск сервера
+	server := &http.Server{Addr: cfg.ListenAddr, Handler: r}
+	// Потоки событий сами не завершатся - закрываем их, чтобы Shutdown не ждал таймаута
+	server.RegisterOnShutdown(eventsHandler.Close)
+	serverErr := make(chan error, 2)
+	go func() {
+		slog.Info("сервер запущен", "addr", cfg.ListenAddr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// gRPC API - на отдельном порту, поверх тех же сервисов
+	var grpcServer *grpcapi.Server
+	if cfg.GRPC.Enabled {
+		listener, err := net.Listen("tcp", cfg.GRPC.ListenAddr)
+		if err != nil {
+			slog.Error("не удалось открыть порт gRPC", "addr", cfg.GRPC.ListenAddr, "error", err)
+			os.Exit(1)
+		}
+		grpcServer = grpcapi.NewServer(grpcapi.Services{
+			AnimalRepo:    animalRepo,
+			EnclosureRepo: enclosureRepo,
+			Animals:       animalService,
+			Enclosures:    enclosureService,
+			Feeding:       feedingService,
+			Transfers:     transferService,
+			Movements:     movementService,
+			Statistics:    statisticsService,
+			Events:        eventBus,
+			Idempotency:   idempotency,
+		}, authService)
+		go func() {
+			slog.Info("gRPC-сервер запущен", "addr", cfg.GRPC.ListenAddr)
+			serverErr <- grpcServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("сервер остановлен с ошибкой", "error", err)
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+	}
+
+	// 6. Остановка: дожидаемся текущих запросов, затем фоновых задач, затем сохраняем данные
+	slog.Info("остановка сервера", "timeout", cfg.ShutdownTimeout)
+	healthHandler.SetDraining()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	var stopping sync.WaitGroup
+	if grpcServer != nil {
+		stopping.Add(1)
+		go func() {
+			defer stopping.Done()
+			if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+				slog.Error("не все вызовы gRPC завершились до таймаута", "error", err)
+			}
+		}()
+	}
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("не все запросы завершились до таймаута", "error", err)
+	}
+	stopping.Wait()
+
+	stopWorkers()
+	workers.Wait()
+
+	if fileStore != nil {
+		if err := fileStore.Flush(); err != nil {
+			slog.Error("не удалось сохранить данные", "path", cfg.Storage.Path, "error", err)
+			os.Exit(1)
+		}
+	}
+	slog.Info("сервер остановлен")
+}
+
+// ensureAdmin - создаёт администратора при первом запуске; если пароль не задан,
+// генерирует его и один раз выводит в stderr, минуя лог
+func ensureAdmin(authService *services.AuthService, cfg config.AuthConfig) error {
+	password := cfg.AdminPassword
+	generated := password == ""
+	if generated {
+		secret, err := auth.GenerateSecret()
+		if err != nil {
+			return err
+		}
+		password = base64.RawURLEncoding.EncodeToString(secret[:12])
+	}
+
+	ctx := requestcontext.WithActor(context.Background(), requestcontext.Actor{Name: "system"})
+	created, err := authService.EnsureAdmin(ctx, cfg.AdminUsername, password)
+	if err != nil || !created {
+		return err
+	}
+	if generated {
+		// Пароль пишется один раз прямо в stderr, а не в лог: записи лога уходят в сборщики и хранятся долго
+		fmt.Fprintf(os.Stderr, "пароль администратора %s: %s\n", cfg.AdminUsername, password)
+		slog.Warn("создан администратор со сгенерированным паролем, он выведен в stderr; смените его после входа", "username", cfg.AdminUsername)
+	} else {
+		slog.Info("создан администратор", "username", cfg.AdminUsername)
+	}
+	return nil
+}
+
+// loadFixtures - загружает описание зоопарка при запуске, если зоопарк пуст
+func loadFixtures(fixtureService *services.FixtureService, source string) error {
+	fixture, err := fixtures.Load(source)
+	if err != nil {
+		return err
+	}
+	// Описание загружается с правами администратора: в нём бывают и больные животные
+	ctx := requestcontext.WithActor(context.Background(), requestcontext.Actor{Name: "system", Role: model.RoleAdmin})
+	report, loaded, err := fixtureService.LoadIfEmpty(ctx, fixture, time.Now())
 	if err != nil {
-		panic(err)
+		return err
+	}
+	if !loaded {
+		slog.Info("зоопарк не пуст, описание не загружается", "fixtures", source)
+		return nil
 	}
+	slog.Info("загружено описание зоопарка", "fixtures", source,
+		"enclosures", len(report.Enclosures), "animals", len(report.Animals), "feedings", report.Feedings)
+	return nil
 }
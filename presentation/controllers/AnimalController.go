@@ -2,16 +2,20 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"kpo-mini-dz2/application/services"
 	"kpo-mini-dz2/domain/model"
 	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
 type AnimalHandler struct {
-	Repo RP.IAnimalRepository
+	Repo    RP.IAnimalRepository
+	Service *services.AnimalService
 }
 
 // Create godoc
@@ -20,7 +24,11 @@ type AnimalHandler struct {
 // @Accept json
 // @Produce json
 // @Param animal body model.Animal true "Animal Data"
+// @Param Idempotency-Key header string false "Retries with the same key return the first response"
 // @Success 201 {object} model.Animal
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 409 {string} string "Enclosure is full or incompatible"
+// @Security BearerAuth
 // @Router /api/animals [post]
 func (h *AnimalHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var newAnimal model.Animal
@@ -31,25 +39,66 @@ func (h *AnimalHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.Repo.Save(newAnimal)
+	animal, err := h.Service.AddAnimal(r.Context(), newAnimal)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
+	setETag(w, animal.Version)
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(newAnimal)
+	json.NewEncoder(w).Encode(animal)
 }
 
 // GetAll godoc
 // @Summary Получить всех животных
+// @Description Without state only animals currently in the zoo; state=deceased, loaned-out etc. lists departed ones.
+// @Description Deleted animals are listed only with includeDeleted=true.
 // @Tags animals
 // @Produce json
+// @Param state query string false "Lifecycle state"
+// @Param includeDeleted query bool false "Also list deleted animals"
 // @Success 200 {array} model.Animal
+// @Failure 400 {string} string "Invalid state"
+// @Security BearerAuth
 // @Router /api/animals [get]
 func (h *AnimalHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	withDeleted, err := includeDeleted(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 	animals, err := h.Repo.FindAll()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if withDeleted {
+		deleted, err := h.Repo.FindDeleted()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		animals = append(animals, deleted...)
+	}
+
+	state := model.LifecycleState(r.URL.Query().Get("state"))
+	switch {
+	case state == "":
+		animals = model.PresentAnimals(animals)
+	case !state.IsValid():
+		http.Error(w, "Invalid state", http.StatusBadRequest)
+		return
+	default:
+		inState := make([]model.Animal, 0)
+		for _, animal := range animals {
+			if animal.State == state {
+				inState = append(inState, animal)
+			}
+		}
+		animals = inState
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(animals)
@@ -60,7 +109,10 @@ func (h *AnimalHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Tags animals
 // @Produce json
 // @Param id path string true "Animal ID"
+// @Param includeDeleted query bool false "Also find a deleted animal"
 // @Success 200 {object} model.Animal
+// @Header 200 {string} ETag "Animal version"
+// @Security BearerAuth
 // @Router /api/animals/{id} [get]
 func (h *AnimalHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -69,22 +121,82 @@ func (h *AnimalHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid ID format", http.StatusBadRequest)
 		return
 	}
+	withDeleted, err := includeDeleted(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
 	animal, err := h.Repo.FindByID(id)
+	if errors.Is(err, model.ErrAnimalNotFound) && withDeleted {
+		animal, err = h.Repo.FindDeletedByID(id)
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	setETag(w, animal.Version)
+	json.NewEncoder(w).Encode(animal)
+}
+
+// Update godoc
+// @Summary Изменить данные животного
+// @Tags animals
+// @Accept json
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param If-Match header string false "Expected animal version (ETag)"
+// @Param animal body model.Animal true "Animal Data"
+// @Success 200 {object} model.Animal
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 404 {string} string "Animal not found"
+// @Failure 409 {string} string "New animal type does not fit the enclosure"
+// @Failure 412 {string} string "Animal was modified by someone else"
+// @Security BearerAuth
+// @Router /api/animals/{id} [put]
+func (h *AnimalHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var data model.Animal
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	animal, err := h.Service.UpdateAnimal(r.Context(), id, data, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, animal.Version)
 	json.NewEncoder(w).Encode(animal)
 }
 
 // Delete godoc
 // @Summary Удалить животное
+// @Description The animal is archived with its feedings and can be restored until the archive retention expires.
 // @Tags animals
 // @Param id path string true "Animal ID"
+// @Param If-Match header string false "Expected animal version (ETag)"
 // @Success 204
+// @Failure 404 {string} string "Animal not found"
+// @Failure 412 {string} string "Animal was modified by someone else"
+// @Security BearerAuth
 // @Router /api/animals/{id} [delete]
 func (h *AnimalHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -94,11 +206,111 @@ func (h *AnimalHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.Repo.Delete(id)
+	version, err := ifMatchVersion(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
+		return
+	}
+
+	err = h.Service.DeleteAnimal(r.Context(), id, version)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Restore godoc
+// @Summary Восстановить удалённое животное
+// @Description Feedings deleted together with the animal are restored too. The animal comes back without an enclosure.
+// @Tags animals
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param If-Match header string false "Expected animal version (ETag)"
+// @Success 200 {object} model.Animal
+// @Failure 404 {string} string "No deleted animal with this ID"
+// @Failure 412 {string} string "Animal was modified by someone else"
+// @Security BearerAuth
+// @Router /api/animals/{id}/restore [post]
+func (h *AnimalHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	animal, err := h.Service.RestoreAnimal(r.Context(), id, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, animal.Version)
+	json.NewEncoder(w).Encode(animal)
+}
+
+// ChangeStateRequest - enclosureId - куда заселить животное, вернувшееся из другого зоопарка
+type ChangeStateRequest struct {
+	State       model.LifecycleState `json:"state"`
+	Date        time.Time            `json:"date"`
+	Reason      string               `json:"reason"`
+	EnclosureID uuid.UUID            `json:"enclosureId,omitempty"`
+}
+
+// ChangeState godoc
+// @Summary Сменить состояние жизненного цикла животного
+// @Description incoming, quarantine, on-display, off-display, loaned-out, transferred-out, deceased.
+// @Description Departed animals (loaned-out, transferred-out, deceased) leave their enclosure and active listings;
+// @Description their pending feedings and transfer requests are cancelled. Transitions are kept in stateHistory.
+// @Tags animals
+// @Accept json
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param If-Match header string false "Expected animal version (ETag)"
+// @Param request body ChangeStateRequest true "New state, date and reason"
+// @Success 200 {object} model.Animal
+// @Failure 400 {string} string "Missing date or reason"
+// @Failure 404 {string} string "Animal not found"
+// @Failure 409 {string} string "Transition is not allowed"
+// @Failure 412 {string} string "Animal was modified by someone else"
+// @Security BearerAuth
+// @Router /api/animals/{id}/state [post]
+func (h *AnimalHandler) ChangeState(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req ChangeStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	animal, err := h.Service.ChangeLifecycleState(r.Context(), id, services.LifecycleChange{
+		State:       req.State,
+		Date:        req.Date,
+		Reason:      req.Reason,
+		EnclosureID: req.EnclosureID,
+	}, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, animal.Version)
+	json.NewEncoder(w).Encode(animal)
+}
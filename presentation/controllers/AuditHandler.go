@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/infrastructure/signing"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditHandler struct {
+	Service *services.AuditService
+}
+
+// Find godoc
+// @Summary Журнал изменений
+// @Description Who changed what and when, newest first
+// @Tags audit
+// @Produce json
+// @Param entity query string false "animal, enclosure, feedingSchedule, movement or transferRequest"
+// @Param entityId query string false "Entity ID"
+// @Param actor query string false "Who made the change"
+// @Param action query string false "create, update, delete, archive or restore"
+// @Param requestId query string false "X-Request-ID of the change"
+// @Param from query string false "From time in RFC3339, inclusive"
+// @Param to query string false "To time in RFC3339, exclusive"
+// @Param limit query int false "Max entries, 100 by default"
+// @Success 200 {array} model.AuditEntry
+// @Failure 400 {string} string "Invalid filter"
+// @Security BearerAuth
+// @Router /api/audit [get]
+func (h *AuditHandler) Find(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := model.AuditFilter{
+		Entity:    query.Get("entity"),
+		Actor:     query.Get("actor"),
+		Action:    model.AuditAction(query.Get("action")),
+		RequestID: query.Get("requestId"),
+	}
+
+	var err error
+	if value := query.Get("entityId"); value != "" {
+		if filter.EntityID, err = uuid.Parse(value); err != nil {
+			http.Error(w, "Invalid entityId format", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("from"); value != "" {
+		if filter.From, err = time.Parse(time.RFC3339, value); err != nil {
+			http.Error(w, "Invalid from, expected RFC3339", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if filter.To, err = time.Parse(time.RFC3339, value); err != nil {
+			http.Error(w, "Invalid to, expected RFC3339", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, err := h.Service.Find(filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// Verify godoc
+// @Summary Проверить цепочку журнала изменений
+// @Description Detects edited, removed or reordered audit entries
+// @Tags audit
+// @Produce json
+// @Success 200 {object} model.AuditVerification
+// @Failure 409 {object} model.AuditVerification "Chain is broken"
+// @Security BearerAuth
+// @Router /api/audit/verify [get]
+func (h *AuditHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.Service.Verify()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !verification.Valid {
+		w.WriteHeader(http.StatusConflict)
+	}
+	json.NewEncoder(w).Encode(verification)
+}
+
+// Export godoc
+// @Summary Подписанная выгрузка журнала для проверяющих
+// @Description Whole audit log signed with the zoo Ed25519 key; check it with cmd/auditverify
+// @Tags audit
+// @Produce json
+// @Success 200 {object} model.AuditBundle
+// @Failure 409 {string} string "Chain is broken"
+// @Security BearerAuth
+// @Router /api/audit/export [get]
+func (h *AuditHandler) Export(w http.ResponseWriter, r *http.Request) {
+	bundle, err := h.Service.Export()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	filename := "zoo-audit-" + bundle.ExportedAt.Format("20060102-150405") + ".json"
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	json.NewEncoder(w).Encode(bundle)
+}
+
+// PublicKey godoc
+// @Summary Открытый ключ для проверки выгрузок
+// @Tags audit
+// @Produce plain
+// @Success 200 {string} string "PEM-encoded Ed25519 public key"
+// @Security BearerAuth
+// @Router /api/audit/public-key [get]
+func (h *AuditHandler) PublicKey(w http.ResponseWriter, r *http.Request) {
+	data, err := signing.EncodePublicKey(h.Service.PublicKey())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Write(data)
+}
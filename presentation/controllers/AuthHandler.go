@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+)
+
+type AuthHandler struct {
+	Service *services.AuthService
+	Users   *services.UserService
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Login godoc
+// @Summary Войти и получить токен доступа
+// @Description Token goes to the Authorization header as "Bearer <token>"
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginRequest true "Username and password"
+// @Success 200 {object} services.LoginResult
+// @Failure 401 {string} string "Invalid username or password"
+// @Router /api/auth/login [post]
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Service.Login(req.Username, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(result)
+}
+
+// Me godoc
+// @Summary Текущий пользователь
+// @Tags auth
+// @Produce json
+// @Success 200 {object} model.User
+// @Failure 401 {string} string "Not logged in"
+// @Security BearerAuth
+// @Router /api/auth/me [get]
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	actor := requestcontext.ActorFrom(r.Context())
+	if !actor.IsAuthenticated() {
+		writeError(w, model.ErrUnauthorized)
+		return
+	}
+
+	user, err := h.Users.GetUser(actor.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"kpo-mini-dz2/application/dataexchange"
+	"kpo-mini-dz2/application/services"
+	"net/http"
+)
+
+const maxImportBytes = 10 << 20
+
+type BulkHandler struct {
+	Service *services.BulkService
+}
+
+// ImportEnclosures godoc
+// @Summary Загрузить вольеры из CSV или JSON
+// @Description Body is a CSV file with a header row (id, name, type, length, width, height, maxCapacity) or a JSON array with the same fields.
+// @Description Every row is validated; mode=all-or-nothing (default) loads nothing if any row is invalid, mode=skip-invalid loads the valid rows.
+// @Tags bulk
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param mode query string false "all-or-nothing or skip-invalid"
+// @Param format query string false "csv or json, Content-Type by default"
+// @Success 200 {object} services.ImportReport "Rows loaded"
+// @Failure 400 {string} string "Invalid file"
+// @Failure 409 {object} services.ImportReport "Some rows are invalid, nothing loaded"
+// @Security BearerAuth
+// @Router /api/enclosures/import [post]
+func (h *BulkHandler) ImportEnclosures(w http.ResponseWriter, r *http.Request) {
+	mode, format, ok := importParams(w, r)
+	if !ok {
+		return
+	}
+	records, err := dataexchange.DecodeEnclosures(http.MaxBytesReader(w, r.Body, maxImportBytes), format)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	report, err := h.Service.ImportEnclosures(r.Context(), records, mode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeImportReport(w, report)
+}
+
+// ImportAnimals godoc
+// @Summary Загрузить животных из CSV или JSON
+// @Description Body is a CSV file with a header row (id, name, species, animalType, birthDate, gender, healthStatus, foodType, favoriteFood, enclosure)
+// @Description or a JSON array with the same fields. enclosure is an enclosure ID or name; birthDate is YYYY-MM-DD.
+// @Description Every row is validated; mode=all-or-nothing (default) loads nothing if any row is invalid, mode=skip-invalid loads the valid rows.
+// @Tags bulk
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param mode query string false "all-or-nothing or skip-invalid"
+// @Param format query string false "csv or json, Content-Type by default"
+// @Success 200 {object} services.ImportReport "Rows loaded"
+// @Failure 400 {string} string "Invalid file"
+// @Failure 409 {object} services.ImportReport "Some rows are invalid, nothing loaded"
+// @Security BearerAuth
+// @Router /api/animals/import [post]
+func (h *BulkHandler) ImportAnimals(w http.ResponseWriter, r *http.Request) {
+	mode, format, ok := importParams(w, r)
+	if !ok {
+		return
+	}
+	records, err := dataexchange.DecodeAnimals(http.MaxBytesReader(w, r.Body, maxImportBytes), format)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+
+	report, err := h.Service.ImportAnimals(r.Context(), records, mode)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeImportReport(w, report)
+}
+
+// ExportEnclosures godoc
+// @Summary Выгрузить вольеры в CSV или JSON
+// @Description Same columns as the import, so the file can be loaded back
+// @Tags bulk
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "csv or json (default)"
+// @Success 200 {array} dataexchange.EnclosureRecord
+// @Failure 400 {string} string "Unknown format"
+// @Security BearerAuth
+// @Router /api/enclosures/export [get]
+func (h *BulkHandler) ExportEnclosures(w http.ResponseWriter, r *http.Request) {
+	format, ok := exportFormat(w, r)
+	if !ok {
+		return
+	}
+	records, err := h.Service.ExportEnclosures()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeExportHeaders(w, format, "enclosures")
+	dataexchange.EncodeEnclosures(w, format, records)
+}
+
+// ExportAnimals godoc
+// @Summary Выгрузить животных в CSV или JSON
+// @Description Same columns as the import; enclosure is the enclosure name when it has one, otherwise its ID
+// @Tags bulk
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "csv or json (default)"
+// @Success 200 {array} dataexchange.AnimalRecord
+// @Failure 400 {string} string "Unknown format"
+// @Security BearerAuth
+// @Router /api/animals/export [get]
+func (h *BulkHandler) ExportAnimals(w http.ResponseWriter, r *http.Request) {
+	format, ok := exportFormat(w, r)
+	if !ok {
+		return
+	}
+	records, err := h.Service.ExportAnimals()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeExportHeaders(w, format, "animals")
+	dataexchange.EncodeAnimals(w, format, records)
+}
+
+// importParams - формат берётся из ?format, иначе из Content-Type
+func importParams(w http.ResponseWriter, r *http.Request) (services.ImportMode, dataexchange.Format, bool) {
+	mode, err := services.ParseImportMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		writeError(w, err)
+		return "", "", false
+	}
+	value := r.URL.Query().Get("format")
+	if value == "" {
+		value = r.Header.Get("Content-Type")
+	}
+	if value == "" {
+		value = string(dataexchange.FormatJSON)
+	}
+	format, err := dataexchange.ParseFormat(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", "", false
+	}
+	return mode, format, true
+}
+
+func exportFormat(w http.ResponseWriter, r *http.Request) (dataexchange.Format, bool) {
+	value := r.URL.Query().Get("format")
+	if value == "" {
+		return dataexchange.FormatJSON, true
+	}
+	format, err := dataexchange.ParseFormat(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+	return format, true
+}
+
+func writeDecodeError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "File is too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	http.Error(w, "Invalid file: "+err.Error(), http.StatusBadRequest)
+}
+
+// writeImportReport - 409, если из-за ошибок ничего не загружено
+func writeImportReport(w http.ResponseWriter, report *services.ImportReport) {
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Applied {
+		w.WriteHeader(http.StatusConflict)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+func writeExportHeaders(w http.ResponseWriter, format dataexchange.Format, name string) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+"."+string(format)+`"`)
+}
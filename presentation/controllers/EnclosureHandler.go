@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type EnclosureHandler struct {
+	Service *services.EnclosureService
+}
+
+type AddEnclosureRequest struct {
+	Name        string           `json:"name,omitempty"`
+	Type        model.AnimalType `json:"type"`
+	Size        model.Size       `json:"size"`
+	MaxCapacity int              `json:"maxCapacity"`
+}
+
+// Create godoc
+// @Summary Добавить вольер
+// @Tags enclosures
+// @Accept json
+// @Produce json
+// @Param enclosure body AddEnclosureRequest true "Enclosure data"
+// @Success 201 {object} model.Enclosure
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 409 {string} string "Enclosure name is taken"
+// @Security BearerAuth
+// @Router /api/enclosures [post]
+func (h *EnclosureHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req AddEnclosureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	enclosure, err := h.Service.AddEnclosure(r.Context(), req.Name, req.Type, req.Size, req.MaxCapacity)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, enclosure.Version)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(enclosure)
+}
+
+// Update godoc
+// @Summary Изменить вольер
+// @Tags enclosures
+// @Accept json
+// @Produce json
+// @Param id path string true "Enclosure ID"
+// @Param If-Match header string false "Expected enclosure version (ETag)"
+// @Param enclosure body AddEnclosureRequest true "Enclosure data"
+// @Success 200 {object} model.Enclosure
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 404 {string} string "Enclosure not found"
+// @Failure 412 {string} string "Enclosure was modified by someone else"
+// @Security BearerAuth
+// @Router /api/enclosures/{id} [put]
+func (h *EnclosureHandler) Update(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req AddEnclosureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	enclosure, err := h.Service.UpdateEnclosure(r.Context(), id, req.Name, req.Type, req.Size, req.MaxCapacity, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, enclosure.Version)
+	json.NewEncoder(w).Encode(enclosure)
+}
+
+// Delete godoc
+// @Summary Удалить вольер
+// @Description The enclosure is archived and can be restored empty until the archive retention expires.
+// @Tags enclosures
+// @Param id path string true "Enclosure ID"
+// @Param If-Match header string false "Expected enclosure version (ETag)"
+// @Success 204
+// @Failure 404 {string} string "Enclosure not found"
+// @Failure 412 {string} string "Enclosure was modified by someone else"
+// @Security BearerAuth
+// @Router /api/enclosures/{id} [delete]
+func (h *EnclosureHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := h.Service.DeleteEnclosure(r.Context(), id, version); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Restore godoc
+// @Summary Восстановить удалённый вольер
+// @Tags enclosures
+// @Produce json
+// @Param id path string true "Enclosure ID"
+// @Param If-Match header string false "Expected enclosure version (ETag)"
+// @Success 200 {object} model.Enclosure
+// @Failure 404 {string} string "No deleted enclosure with this ID"
+// @Failure 409 {string} string "Enclosure name is taken"
+// @Failure 412 {string} string "Enclosure was modified by someone else"
+// @Security BearerAuth
+// @Router /api/enclosures/{id}/restore [post]
+func (h *EnclosureHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	enclosure, err := h.Service.RestoreEnclosure(r.Context(), id, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, enclosure.Version)
+	json.NewEncoder(w).Encode(enclosure)
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventsHandler struct {
+	bus *services.EventBus
+	// heartbeat - интервал комментариев в пустом потоке
+	heartbeat time.Duration
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func NewEventsHandler(bus *services.EventBus, heartbeat time.Duration) *EventsHandler {
+	return &EventsHandler{bus: bus, heartbeat: heartbeat, closing: make(chan struct{})}
+}
+
+// Close - завершает открытые потоки; сами они не заканчиваются,
+// и без этого остановка сервера ждала бы таймаута
+func (h *EventsHandler) Close() {
+	h.closeOnce.Do(func() { close(h.closing) })
+}
+
+// Stream godoc
+// @Summary Поток событий зоопарка
+// @Description Server-Sent Events: animal.created, animal.deleted, animal.moved, animal.healthChanged, feeding.due, feeding.completed.
+// @Description After a reconnect send Last-Event-ID to receive missed events from the replay buffer;
+// @Description a "stream.truncated" event means some of them were already evicted.
+// @Tags events
+// @Produce text/event-stream
+// @Param type query string false "Comma-separated event types"
+// @Param enclosureId query string false "Only events touching this enclosure"
+// @Param Last-Event-ID header int false "Resume after this event"
+// @Success 200 {object} model.ZooEvent
+// @Failure 400 {string} string "Invalid filter"
+// @Security BearerAuth
+// @Router /api/events/stream [get]
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+	var after int64
+	if lastEventID != "" {
+		if after, err = strconv.ParseInt(lastEventID, 10, 64); err != nil {
+			http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	subscription, missed, truncated := h.bus.Subscribe(filter, after)
+	defer subscription.Close()
+
+	if truncated {
+		fmt.Fprint(w, "event: stream.truncated\ndata: {}\n\n")
+	}
+	for _, event := range missed {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.closing:
+			return
+		case event, ok := <-subscription.Events():
+			// Клиент не успевал читать - он переподключится с Last-Event-ID
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event model.ZooEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+func parseEventFilter(r *http.Request) (model.EventFilter, error) {
+	query := r.URL.Query()
+	var filter model.EventFilter
+
+	for _, value := range query["type"] {
+		for _, name := range strings.Split(value, ",") {
+			eventType := model.EventType(strings.TrimSpace(name))
+			if eventType == "" {
+				continue
+			}
+			if !eventType.IsValid() {
+				return filter, fmt.Errorf("Unknown event type %q", eventType)
+			}
+			filter.Types = append(filter.Types, eventType)
+		}
+	}
+	if value := query.Get("enclosureId"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid enclosureId format")
+		}
+		filter.EnclosureID = id
+	}
+	return filter, nil
+}
@@ -2,9 +2,8 @@ package controllers
 
 import (
 	"encoding/json"
+	"kpo-mini-dz2/application/services"
 	"kpo-mini-dz2/domain/model"
-	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
-	"kpo-mini-dz2/infrastructure/repositories"
 	"net/http"
 	"time"
 
@@ -13,12 +12,12 @@ import (
 )
 
 type FeedingHandler struct {
-	Repo RP.IFeedingScheduleRepository
+	Service *services.FeedingService
 }
 
-func NewFeedingHandler(repo *repositories.InMemoryFeedingScheduleRepository) *FeedingHandler {
+func NewFeedingHandler(service *services.FeedingService) *FeedingHandler {
 	return &FeedingHandler{
-		Repo: repo,
+		Service: service,
 	}
 }
 
@@ -28,6 +27,11 @@ type AddScheduleRequest struct {
 	FoodType    model.FoodType `json:"foodType"`
 }
 
+type UpdateScheduleRequest struct {
+	FeedingTime time.Time      `json:"feedingTime"`
+	FoodType    model.FoodType `json:"foodType"`
+}
+
 type RemoveScheduleRequest struct {
 	AnimalID    uuid.UUID `json:"animalId"`
 	FeedingTime time.Time `json:"feedingTime"`
@@ -40,9 +44,12 @@ type RemoveScheduleRequest struct {
 // @Accept json
 // @Produce json
 // @Param schedule body AddScheduleRequest true "Feeding schedule information"
+// @Param Idempotency-Key header string false "Retries with the same key return the first response"
 // @Success 201 {object} map[string]string
 // @Failure 400 {string} string "Invalid request body"
-// @Failure 500 {string} string "Internal server error"
+// @Failure 404 {string} string "Animal not found"
+// @Failure 409 {string} string "Animal has left the zoo"
+// @Security BearerAuth
 // @Router /api/schedules [post]
 func (h *FeedingHandler) AddSchedule(w http.ResponseWriter, r *http.Request) {
 	var req AddScheduleRequest
@@ -51,15 +58,9 @@ func (h *FeedingHandler) AddSchedule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	schedule := model.FeedingSchedule{
-		AnimalID:    req.AnimalID,
-		FeedingTime: req.FeedingTime,
-		FoodType:    req.FoodType,
-	}
-
-	err := h.Repo.AddSchedule(schedule)
+	_, err := h.Service.AddFeedingSchedule(r.Context(), req.AnimalID, req.FeedingTime, req.FoodType)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -69,15 +70,89 @@ func (h *FeedingHandler) AddSchedule(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// UpdateSchedule godoc
+// @Summary Update feeding schedule
+// @Description Changes feeding time and food type; honours If-Match to avoid lost updates
+// @Tags feeding_schedule
+// @Accept json
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param If-Match header string false "Expected schedule version (ETag)"
+// @Param schedule body UpdateScheduleRequest true "New schedule data"
+// @Success 200 {object} model.FeedingSchedule
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 404 {string} string "Schedule not found"
+// @Failure 412 {string} string "Schedule was modified by someone else"
+// @Security BearerAuth
+// @Router /api/schedules/{id} [put]
+func (h *FeedingHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req UpdateScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.Service.UpdateFeedingSchedule(r.Context(), id, req.FeedingTime, req.FoodType, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, updated.Version)
+	json.NewEncoder(w).Encode(updated)
+}
+
+// MarkDone godoc
+// @Summary Mark feeding as done
+// @Tags feeding_schedule
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} model.FeedingSchedule
+// @Failure 404 {string} string "Schedule not found"
+// @Failure 409 {string} string "Feeding is already marked as done"
+// @Security BearerAuth
+// @Router /api/schedules/{id}/done [post]
+func (h *FeedingHandler) MarkDone(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.Service.MarkFeedingDone(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, updated.Version)
+	json.NewEncoder(w).Encode(updated)
+}
+
 // DeleteSchedule godoc
 // @Summary Delete feeding schedule
-// @Description Delete schedule by animalID and time from repository
+// @Description Delete schedule by animalID and time; it stays restorable until the archive retention expires
 // @Tags feeding_schedule
 // @Accept json
 // @Produce json
 // @Success 201 {object} map[string]string
 // @Failure 400 {string} string "Invalid request body"
 // @Failure 500 {string} string "Internal server error"
+// @Security BearerAuth
 // @Router /api/schedules [delete]
 func (h *FeedingHandler) RemoveSchedule(w http.ResponseWriter, r *http.Request) {
 	var req RemoveScheduleRequest
@@ -87,9 +162,9 @@ func (h *FeedingHandler) RemoveSchedule(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.Repo.RemoveSchedule(req.AnimalID, req.FeedingTime)
+	err := h.Service.RemoveFeedingSchedule(r.Context(), req.AnimalID, req.FeedingTime)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -99,15 +174,52 @@ func (h *FeedingHandler) RemoveSchedule(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// RestoreSchedule godoc
+// @Summary Restore a deleted feeding schedule
+// @Description The animal must not be deleted; feedings of a deleted animal come back when the animal is restored
+// @Tags feeding_schedule
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param If-Match header string false "Expected schedule version (ETag)"
+// @Success 200 {object} model.FeedingSchedule
+// @Failure 404 {string} string "No deleted schedule with this ID, or its animal is deleted"
+// @Failure 412 {string} string "Schedule was modified by someone else"
+// @Security BearerAuth
+// @Router /api/schedules/{id}/restore [post]
+func (h *FeedingHandler) RestoreSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	restored, err := h.Service.RestoreFeedingSchedule(r.Context(), id, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, restored.Version)
+	json.NewEncoder(w).Encode(restored)
+}
+
 // GetSchedule godoc
 // @Summary Get feeding schedule by ID
 // @Description Get feeding schedule by ID
 // @Tags feeding_schedule
 // @Accept json
 // @Produce json
+// @Param includeDeleted query bool false "Also list deleted schedules"
 // @Success 201 {object} model.FeedingSchedule
 // @Failure 400 {string} string "Invalid request body"
 // @Failure 500 {string} string "Internal server error"
+// @Security BearerAuth
 // @Router /api/schedules [get]
 func (h *FeedingHandler) GetAnimalSchedules(w http.ResponseWriter, r *http.Request) {
 	animalIDStr := chi.URLParam(r, "animalID")
@@ -116,12 +228,25 @@ func (h *FeedingHandler) GetAnimalSchedules(w http.ResponseWriter, r *http.Reque
 		http.Error(w, "Invalid animal ID", http.StatusBadRequest)
 		return
 	}
+	withDeleted, err := includeDeleted(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
-	schedules, err := h.Repo.GetSchedulesByAnimalID(animalID)
+	schedules, err := h.Service.GetAnimalSchedules(animalID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if withDeleted {
+		deleted, err := h.Service.GetDeletedSchedules(animalID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		schedules = append(schedules, deleted...)
+	}
 
 	if schedules == nil {
 		schedules = []model.FeedingSchedule{}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"encoding/json"
+	"io"
+	"kpo-mini-dz2/application/fixtures"
+	"kpo-mini-dz2/application/services"
+	"net/http"
+	"time"
+)
+
+type FixtureHandler struct {
+	Service *services.FixtureService
+}
+
+// Load godoc
+// @Summary Загрузить описание зоопарка (администратор)
+// @Description Body is a YAML or JSON fixture: species, enclosures, animals and feedings that refer to each other by key.
+// @Description Everything is loaded in one transaction; if any entry is invalid nothing is loaded and all errors are returned.
+// @Tags fixtures
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Success 201 {object} services.FixtureReport
+// @Failure 400 {string} string "Invalid fixture"
+// @Security BearerAuth
+// @Router /api/fixtures [post]
+func (h *FixtureHandler) Load(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	fixture, err := fixtures.Parse(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.load(w, r, fixture)
+}
+
+// LoadDemo godoc
+// @Summary Загрузить встроенный демонстрационный зоопарк (администратор)
+// @Description Fails with 400 if the demo enclosure names are already taken, e.g. when the demo is loaded twice
+// @Tags fixtures
+// @Produce json
+// @Success 201 {object} services.FixtureReport
+// @Failure 400 {string} string "Demo zoo conflicts with existing data"
+// @Security BearerAuth
+// @Router /api/fixtures/demo [post]
+func (h *FixtureHandler) LoadDemo(w http.ResponseWriter, r *http.Request) {
+	fixture, err := fixtures.Load(fixtures.Demo)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	h.load(w, r, fixture)
+}
+
+func (h *FixtureHandler) load(w http.ResponseWriter, r *http.Request, fixture *fixtures.Fixture) {
+	report, err := h.Service.Load(r.Context(), fixture, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(report)
+}
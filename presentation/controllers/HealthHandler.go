@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// HealthCheck - проверка одной зависимости для /readyz
+type HealthCheck struct {
+	Name  string
+	Check func() error
+}
+
+type HealthHandler struct {
+	Checks   []HealthCheck
+	draining atomic.Bool
+}
+
+// SetDraining - сервер останавливается, новые запросы на него направлять не нужно
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
+
+// Liveness godoc
+// @Summary Liveness probe
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// Readiness godoc
+// @Summary Readiness probe: checks repositories and storage
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	status := http.StatusOK
+	result := map[string]string{}
+
+	if h.draining.Load() {
+		status = http.StatusServiceUnavailable
+		result["server"] = "shutting down"
+	}
+	for _, check := range h.Checks {
+		if err := check.Check(); err != nil {
+			status = http.StatusServiceUnavailable
+			result[check.Name] = err.Error()
+			continue
+		}
+		result[check.Name] = "ok"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
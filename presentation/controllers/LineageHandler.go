@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type LineageHandler struct {
+	Service *services.LineageService
+}
+
+// SetParentsRequest - отсутствующий родитель считается неизвестным
+type SetParentsRequest struct {
+	Sire *model.Parent `json:"sire"`
+	Dam  *model.Parent `json:"dam"`
+}
+
+// SetParents godoc
+// @Summary Указать отца и мать животного
+// @Description Each parent is either {"animalId"} of a zoo animal or {"external": {"institution", "studbookId", "name"}}.
+// @Description A zoo parent must be of the right sex and species, born earlier and not a descendant of the animal.
+// @Tags lineage
+// @Accept json
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param If-Match header string false "Expected animal version (ETag)"
+// @Param parents body SetParentsRequest true "Sire and dam; omitted means unknown"
+// @Success 200 {object} model.Animal
+// @Failure 400 {string} string "Invalid parents"
+// @Failure 404 {string} string "Animal not found"
+// @Failure 409 {string} string "Parent is a descendant of the animal"
+// @Failure 412 {string} string "Animal was modified by someone else"
+// @Security BearerAuth
+// @Router /api/animals/{id}/parents [put]
+func (h *LineageHandler) SetParents(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req SetParentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	animal, err := h.Service.SetParents(r.Context(), id, req.Sire, req.Dam, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, animal.Version)
+	json.NewEncoder(w).Encode(animal)
+}
+
+// GetLineage godoc
+// @Summary Родословная животного
+// @Description Ancestors (sire, dam) and descendants (offspring) to the given number of generations,
+// @Description each with its inbreeding coefficient. Parents from other institutions have no known ancestors.
+// @Tags lineage
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param generations query int false "Generations in each direction, 3 by default, at most 10"
+// @Success 200 {object} model.LineageNode
+// @Failure 400 {string} string "Invalid generations"
+// @Failure 404 {string} string "Animal not found"
+// @Security BearerAuth
+// @Router /api/animals/{id}/lineage [get]
+func (h *LineageHandler) GetLineage(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	generations := services.DefaultLineageGenerations
+	if value := r.URL.Query().Get("generations"); value != "" {
+		if generations, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid generations", http.StatusBadRequest)
+			return
+		}
+	}
+
+	lineage, err := h.Service.GetLineage(id, generations)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lineage)
+}
+
+// GetKinship godoc
+// @Summary Родство внутри видов
+// @Description Mean kinship and inbreeding of animals currently in the zoo, per species.
+// @Description Animals are listed from the lowest mean kinship, i.e. the most valuable for breeding.
+// @Tags lineage
+// @Produce json
+// @Param species query string false "Only this species"
+// @Success 200 {array} model.SpeciesKinship
+// @Security BearerAuth
+// @Router /api/lineage/kinship [get]
+func (h *LineageHandler) GetKinship(w http.ResponseWriter, r *http.Request) {
+	kinship, err := h.Service.GetKinship(r.URL.Query().Get("species"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(kinship)
+}
+
+// GetIssues godoc
+// @Summary Пробелы и ошибки в родословных
+// @Description Unknown sire or dam, parents missing from the zoo, parents of the wrong sex, species or age, circular parentage.
+// @Tags lineage
+// @Produce json
+// @Param species query string false "Only this species"
+// @Success 200 {array} model.LineageIssue
+// @Security BearerAuth
+// @Router /api/lineage/issues [get]
+func (h *LineageHandler) GetIssues(w http.ResponseWriter, r *http.Request) {
+	issues, err := h.Service.GetIssues(r.URL.Query().Get("species"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(issues)
+}
+
+// GetPairs godoc
+// @Summary Рекомендуемые пары для разведения
+// @Description Male/female pairs within each species, best first. Sick animals, pairs related by 0.125 or more and pairs
+// @Description whose ancestry has a missing or circular parent (kinship unknown) are excluded
+// @Description and listed with the reason. Score weighs offspring inbreeding (0.6), breeding age (0.25) and whether the pair
+// @Description already shares an enclosure (0.15); every factor explains its score. minAge and maxAge are given together.
+// @Tags lineage
+// @Produce json
+// @Param species query string false "Only this species"
+// @Param minAge query int false "Breeding age from, full years; default depends on the animal type"
+// @Param maxAge query int false "Breeding age to, full years"
+// @Success 200 {array} model.BreedingRecommendations
+// @Failure 400 {string} string "Invalid age range"
+// @Security BearerAuth
+// @Router /api/lineage/pairs [get]
+func (h *LineageHandler) GetPairs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var ages *model.AgeRange
+	if query.Has("minAge") || query.Has("maxAge") {
+		minAge, err := strconv.Atoi(query.Get("minAge"))
+		if err != nil {
+			http.Error(w, "Invalid minAge", http.StatusBadRequest)
+			return
+		}
+		maxAge, err := strconv.Atoi(query.Get("maxAge"))
+		if err != nil {
+			http.Error(w, "Invalid maxAge", http.StatusBadRequest)
+			return
+		}
+		ages = &model.AgeRange{Min: minAge, Max: maxAge}
+	}
+
+	pairs, err := h.Service.RecommendPairs(query.Get("species"), ages, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pairs)
+}
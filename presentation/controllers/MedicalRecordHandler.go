@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type MedicalRecordHandler struct {
+	Service *services.MedicalRecordService
+}
+
+// MedicalRecordResponse - карта вместе с состоянием здоровья, которое из неё следует
+type MedicalRecordResponse struct {
+	model.MedicalRecord
+	HealthStatus model.HealthStatus `json:"healthStatus"`
+}
+
+type OpenCaseRequest struct {
+	Title string `json:"title"`
+}
+
+type CloseCaseRequest struct {
+	Resolution string `json:"resolution"`
+}
+
+// AddMedicalEntryRequest - кроме полей вида записи: medication для medication,
+// vaccination для vaccination, labResult для labResult
+type AddMedicalEntryRequest struct {
+	Kind        model.MedicalEntryKind `json:"kind"`
+	Date        time.Time              `json:"date"`
+	CaseID      *uuid.UUID             `json:"caseId,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Notes       string                 `json:"notes,omitempty"`
+	Medication  *model.Medication      `json:"medication,omitempty"`
+	Vaccination *model.Vaccination     `json:"vaccination,omitempty"`
+	LabResult   *model.LabResult       `json:"labResult,omitempty"`
+}
+
+// GetRecord godoc
+// @Summary Медицинская карта животного
+// @Description Cases and dated entries (examinations, diagnoses, treatments, medications, vaccinations, lab results), oldest first
+// @Tags medical
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Success 200 {object} MedicalRecordResponse
+// @Failure 404 {string} string "Animal not found"
+// @Security BearerAuth
+// @Router /api/animals/{id}/medical [get]
+func (h *MedicalRecordHandler) GetRecord(w http.ResponseWriter, r *http.Request) {
+	animalID, ok := animalIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	record, err := h.Service.GetRecord(animalID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MedicalRecordResponse{MedicalRecord: *record, HealthStatus: record.HealthStatus()})
+}
+
+// GetOpenCases godoc
+// @Summary Открытые медицинские случаи всех животных
+// @Tags medical
+// @Produce json
+// @Success 200 {array} model.MedicalCase
+// @Security BearerAuth
+// @Router /api/medical/cases [get]
+func (h *MedicalRecordHandler) GetOpenCases(w http.ResponseWriter, r *http.Request) {
+	cases, err := h.Service.GetOpenCases()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cases)
+}
+
+// OpenCase godoc
+// @Summary Открыть медицинский случай
+// @Description The animal becomes sick until all its open cases are closed
+// @Tags medical
+// @Accept json
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param request body OpenCaseRequest true "Reason for the case"
+// @Success 201 {object} model.MedicalCase
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 404 {string} string "Animal not found"
+// @Security BearerAuth
+// @Router /api/animals/{id}/medical/cases [post]
+func (h *MedicalRecordHandler) OpenCase(w http.ResponseWriter, r *http.Request) {
+	animalID, ok := animalIDParam(w, r)
+	if !ok {
+		return
+	}
+	var req OpenCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	medicalCase, err := h.Service.OpenCase(r.Context(), animalID, req.Title)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, medicalCase.Version)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(medicalCase)
+}
+
+// CloseCase godoc
+// @Summary Закрыть медицинский случай
+// @Description Closing the last open case heals the animal
+// @Tags medical
+// @Accept json
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param caseId path string true "Case ID"
+// @Param If-Match header string false "Case version from ETag"
+// @Param request body CloseCaseRequest true "Resolution"
+// @Success 200 {object} model.MedicalCase
+// @Failure 404 {string} string "Animal or case not found"
+// @Failure 409 {string} string "Case is already closed"
+// @Failure 412 {string} string "Version conflict"
+// @Security BearerAuth
+// @Router /api/animals/{id}/medical/cases/{caseId}/close [post]
+func (h *MedicalRecordHandler) CloseCase(w http.ResponseWriter, r *http.Request) {
+	animalID, ok := animalIDParam(w, r)
+	if !ok {
+		return
+	}
+	caseID, err := uuid.Parse(chi.URLParam(r, "caseId"))
+	if err != nil {
+		http.Error(w, "Invalid case ID format", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req CloseCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	medicalCase, err := h.Service.CloseCase(r.Context(), animalID, caseID, req.Resolution, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, medicalCase.Version)
+	json.NewEncoder(w).Encode(medicalCase)
+}
+
+// AddEntry godoc
+// @Summary Добавить запись в медицинскую карту
+// @Description kind: examination, diagnosis, treatment (summary required), medication (medication.name and dosage),
+// @Description vaccination (vaccination.vaccine), labResult (labResult.test and value). caseId links the entry to an open case.
+// @Tags medical
+// @Accept json
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Param request body AddMedicalEntryRequest true "Entry"
+// @Success 201 {object} model.MedicalEntry
+// @Failure 400 {string} string "Invalid entry"
+// @Failure 404 {string} string "Animal or case not found"
+// @Failure 409 {string} string "Case is closed"
+// @Security BearerAuth
+// @Router /api/animals/{id}/medical/entries [post]
+func (h *MedicalRecordHandler) AddEntry(w http.ResponseWriter, r *http.Request) {
+	animalID, ok := animalIDParam(w, r)
+	if !ok {
+		return
+	}
+	var req AddMedicalEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.Service.AddEntry(r.Context(), model.MedicalEntry{
+		AnimalID:    animalID,
+		CaseID:      req.CaseID,
+		Kind:        req.Kind,
+		Date:        req.Date,
+		Summary:     req.Summary,
+		Notes:       req.Notes,
+		Medication:  req.Medication,
+		Vaccination: req.Vaccination,
+		LabResult:   req.LabResult,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+func animalIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type MovementHandler struct {
+	Service *services.MovementHistoryService
+}
+
+// GetAnimalMovements godoc
+// @Summary История перемещений животного
+// @Tags movements
+// @Produce json
+// @Param id path string true "Animal ID"
+// @Success 200 {array} model.Movement
+// @Failure 400 {string} string "Invalid ID format"
+// @Security BearerAuth
+// @Router /api/animals/{id}/movements [get]
+func (h *MovementHandler) GetAnimalMovements(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	movements, err := h.Service.GetAnimalMovements(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(movements)
+}
+
+// GetOccupancy godoc
+// @Summary Кто находился в вольере в заданный момент
+// @Tags movements
+// @Produce json
+// @Param id path string true "Enclosure ID"
+// @Param at query string false "Moment in RFC3339, defaults to now"
+// @Success 200 {array} model.Occupancy
+// @Failure 400 {string} string "Invalid ID or time format"
+// @Security BearerAuth
+// @Router /api/enclosures/{id}/occupancy [get]
+func (h *MovementHandler) GetOccupancy(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	at := time.Now()
+	if atStr := r.URL.Query().Get("at"); atStr != "" {
+		at, err = time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			http.Error(w, "Invalid time format, expected RFC3339", http.StatusBadRequest)
+			return
+		}
+	}
+
+	occupancy, err := h.Service.GetOccupancy(id, at)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(occupancy)
+}
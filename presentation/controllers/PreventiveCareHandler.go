@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// defaultDueDays - на сколько дней вперёд по умолчанию показываются процедуры
+const defaultDueDays = 30
+
+type PreventiveCareHandler struct {
+	Service *services.PreventiveCareService
+}
+
+type CreateCarePlanRequest struct {
+	Species string               `json:"species"`
+	Items   []model.CarePlanItem `json:"items"`
+}
+
+type UpdateCarePlanRequest struct {
+	Items []model.CarePlanItem `json:"items"`
+}
+
+// GetPlans godoc
+// @Summary Планы профилактики по видам
+// @Tags vet
+// @Produce json
+// @Success 200 {array} model.CarePlan
+// @Security BearerAuth
+// @Router /api/vet/plans [get]
+func (h *PreventiveCareHandler) GetPlans(w http.ResponseWriter, r *http.Request) {
+	plans, err := h.Service.GetPlans()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plans)
+}
+
+// GetPlan godoc
+// @Summary План профилактики по id
+// @Tags vet
+// @Produce json
+// @Param id path string true "Plan ID"
+// @Success 200 {object} model.CarePlan
+// @Failure 404 {string} string "Plan not found"
+// @Security BearerAuth
+// @Router /api/vet/plans/{id} [get]
+func (h *PreventiveCareHandler) GetPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.Service.GetPlan(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, plan.Version)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// CreatePlan godoc
+// @Summary Создать план профилактики для вида
+// @Description Each item: name, kind (examination, vaccination, treatment, ...), everyMonths, firstAtMonths (age of the first one).
+// @Description A medical entry of the same kind whose vaccine, medication, test or summary contains the item name counts as done.
+// @Tags vet
+// @Accept json
+// @Produce json
+// @Param plan body CreateCarePlanRequest true "Species name and items"
+// @Success 201 {object} model.CarePlan
+// @Failure 400 {string} string "Invalid plan"
+// @Failure 409 {string} string "Species already has a plan"
+// @Security BearerAuth
+// @Router /api/vet/plans [post]
+func (h *PreventiveCareHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	var req CreateCarePlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.Service.CreatePlan(r.Context(), req.Species, req.Items)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, plan.Version)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// UpdatePlan godoc
+// @Summary Заменить процедуры плана профилактики
+// @Tags vet
+// @Accept json
+// @Produce json
+// @Param id path string true "Plan ID"
+// @Param If-Match header string false "Expected plan version (ETag)"
+// @Param plan body UpdateCarePlanRequest true "New items"
+// @Success 200 {object} model.CarePlan
+// @Failure 400 {string} string "Invalid plan"
+// @Failure 404 {string} string "Plan not found"
+// @Failure 412 {string} string "Plan was modified by someone else"
+// @Security BearerAuth
+// @Router /api/vet/plans/{id} [put]
+func (h *PreventiveCareHandler) UpdatePlan(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req UpdateCarePlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	plan, err := h.Service.UpdatePlan(r.Context(), id, req.Items, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, plan.Version)
+	json.NewEncoder(w).Encode(plan)
+}
+
+// DeletePlan godoc
+// @Summary Удалить план профилактики
+// @Tags vet
+// @Param id path string true "Plan ID"
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Plan not found"
+// @Security BearerAuth
+// @Router /api/vet/plans/{id} [delete]
+func (h *PreventiveCareHandler) DeletePlan(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.DeletePlan(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDue godoc
+// @Summary Процедуры профилактики, которые пора выполнить
+// @Description Overdue tasks and tasks due within the next days, earliest first.
+// @Description Due dates follow the species plan from the birth date or the last matching medical entry.
+// @Tags vet
+// @Produce json
+// @Param days query int false "Look-ahead in days, 30 by default"
+// @Param status query string false "due or overdue"
+// @Param animalId query string false "Only this animal"
+// @Success 200 {array} model.CareTask
+// @Failure 400 {string} string "Invalid query"
+// @Security BearerAuth
+// @Router /api/vet/due [get]
+func (h *PreventiveCareHandler) GetDue(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	days := defaultDueDays
+	if value := query.Get("days"); value != "" {
+		var err error
+		if days, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+	filter := services.CareTaskFilter{Status: model.CareTaskStatus(query.Get("status"))}
+	if value := query.Get("animalId"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			http.Error(w, "Invalid animalId", http.StatusBadRequest)
+			return
+		}
+		filter.AnimalID = id
+	}
+
+	tasks, err := h.Service.GetDueTasks(time.Now(), time.Duration(days)*24*time.Hour, filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tasks)
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type TransferHandler struct {
+	Service *services.AnimalTransferService
+}
+
+type TransferRequest struct {
+	AnimalID      uuid.UUID `json:"animalId"`
+	ToEnclosureID uuid.UUID `json:"toEnclosureId"`
+	Reason        string    `json:"reason"`
+}
+
+// Transfer godoc
+// @Summary Переместить животное в другой вольер
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param transfer body TransferRequest true "Transfer data"
+// @Success 200 {object} map[string]string
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 404 {string} string "Animal or enclosure not found"
+// @Failure 409 {string} string "Enclosure is full or incompatible"
+// @Security BearerAuth
+// @Router /api/transfers [post]
+func (h *TransferHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	var req TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.TransferAnimal(r.Context(), req.AnimalID, req.ToEnclosureID, req.Reason); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Animal transferred successfully",
+	})
+}
+
+type BatchTransferRequest struct {
+	Moves  []services.TransferMove `json:"moves"`
+	Apply  bool                    `json:"apply"`
+	Reason string                  `json:"reason"`
+}
+
+// TransferBatch godoc
+// @Summary Пакетное перемещение с предварительной проверкой
+// @Description Moves are checked in order, each against the result of the previous ones. A move into a full enclosure is valid
+// @Description only if later moves of the batch free a place there (a swap). With apply=true they are applied only if every move is valid.
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param batch body BatchTransferRequest true "Moves to simulate or apply"
+// @Success 200 {object} services.BatchTransferResult "All moves are valid"
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 409 {object} services.BatchTransferResult "Some moves are invalid, nothing applied"
+// @Security BearerAuth
+// @Router /api/transfers/batch [post]
+func (h *TransferHandler) TransferBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Service.TransferBatch(r.Context(), req.Moves, req.Apply, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !result.Valid {
+		w.WriteHeader(http.StatusConflict)
+	}
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type TransferRequestHandler struct {
+	Service *services.TransferRequestService
+}
+
+type CreateTransferRequestRequest struct {
+	AnimalID      uuid.UUID `json:"animalId"`
+	ToEnclosureID uuid.UUID `json:"toEnclosureId"`
+	PlannedDate   time.Time `json:"plannedDate"`
+	Reason        string    `json:"reason"`
+}
+
+type RejectTransferRequestRequest struct {
+	Comment string `json:"comment"`
+}
+
+type ScheduleTransferRequestRequest struct {
+	PlannedDate time.Time `json:"plannedDate"`
+}
+
+// Create godoc
+// @Summary Создать заявку на перемещение
+// @Tags transfer_requests
+// @Accept json
+// @Produce json
+// @Param request body CreateTransferRequestRequest true "Transfer request data"
+// @Success 201 {object} model.TransferRequest
+// @Failure 400 {string} string "Invalid request body"
+// @Failure 404 {string} string "Animal or enclosure not found"
+// @Security BearerAuth
+// @Router /api/transfer-requests [post]
+func (h *TransferRequestHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateTransferRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	request, err := h.Service.CreateRequest(r.Context(), req.AnimalID, req.ToEnclosureID, req.PlannedDate, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, request.Version)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(request)
+}
+
+// GetAll godoc
+// @Summary Все заявки на перемещение
+// @Tags transfer_requests
+// @Produce json
+// @Success 200 {array} model.TransferRequest
+// @Security BearerAuth
+// @Router /api/transfer-requests [get]
+func (h *TransferRequestHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.Service.GetAllRequests()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests)
+}
+
+// GetByID godoc
+// @Summary Заявка на перемещение по ID
+// @Tags transfer_requests
+// @Produce json
+// @Param id path string true "Transfer request ID"
+// @Success 200 {object} model.TransferRequest
+// @Failure 404 {string} string "Transfer request not found"
+// @Security BearerAuth
+// @Router /api/transfer-requests/{id} [get]
+func (h *TransferRequestHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	request, err := h.Service.GetRequest(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, request.Version)
+	json.NewEncoder(w).Encode(request)
+}
+
+// GetPendingByEnclosure godoc
+// @Summary Незакрытые заявки на перемещение по вольеру
+// @Tags transfer_requests
+// @Produce json
+// @Param id path string true "Enclosure ID"
+// @Success 200 {array} model.TransferRequest
+// @Security BearerAuth
+// @Router /api/enclosures/{id}/transfer-requests [get]
+func (h *TransferRequestHandler) GetPendingByEnclosure(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	requests, err := h.Service.GetPendingByEnclosure(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(requests)
+}
+
+// Approve godoc
+// @Summary Одобрить заявку (куратор или администратор)
+// @Tags transfer_requests
+// @Produce json
+// @Param id path string true "Transfer request ID"
+// @Param If-Match header string false "Expected request version (ETag)"
+// @Success 200 {object} model.TransferRequest
+// @Failure 403 {string} string "Not allowed to approve"
+// @Failure 409 {string} string "Request is not awaiting approval"
+// @Security BearerAuth
+// @Router /api/transfer-requests/{id}/approve [post]
+func (h *TransferRequestHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, func(id uuid.UUID, version int) (*model.TransferRequest, error) {
+		return h.Service.Approve(r.Context(), id, version)
+	})
+}
+
+// Reject godoc
+// @Summary Отклонить заявку (куратор или администратор)
+// @Tags transfer_requests
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer request ID"
+// @Param If-Match header string false "Expected request version (ETag)"
+// @Param body body RejectTransferRequestRequest false "Rejection comment"
+// @Success 200 {object} model.TransferRequest
+// @Failure 403 {string} string "Not allowed to reject"
+// @Failure 409 {string} string "Request is not awaiting approval"
+// @Security BearerAuth
+// @Router /api/transfer-requests/{id}/reject [post]
+func (h *TransferRequestHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	var req RejectTransferRequestRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	h.handle(w, r, func(id uuid.UUID, version int) (*model.TransferRequest, error) {
+		return h.Service.Reject(r.Context(), id, version, req.Comment)
+	})
+}
+
+// Schedule godoc
+// @Summary Назначить дату перемещения по одобренной заявке
+// @Tags transfer_requests
+// @Accept json
+// @Produce json
+// @Param id path string true "Transfer request ID"
+// @Param If-Match header string false "Expected request version (ETag)"
+// @Param body body ScheduleTransferRequestRequest true "Planned date"
+// @Success 200 {object} model.TransferRequest
+// @Failure 409 {string} string "Request is not approved"
+// @Security BearerAuth
+// @Router /api/transfer-requests/{id}/schedule [post]
+func (h *TransferRequestHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	var req ScheduleTransferRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.handle(w, r, func(id uuid.UUID, version int) (*model.TransferRequest, error) {
+		return h.Service.Schedule(r.Context(), id, version, req.PlannedDate)
+	})
+}
+
+// Execute godoc
+// @Summary Выполнить перемещение по одобренной заявке
+// @Tags transfer_requests
+// @Produce json
+// @Param id path string true "Transfer request ID"
+// @Param If-Match header string false "Expected request version (ETag)"
+// @Success 200 {object} model.TransferRequest
+// @Failure 409 {string} string "Request is not approved or enclosure is full"
+// @Security BearerAuth
+// @Router /api/transfer-requests/{id}/execute [post]
+func (h *TransferRequestHandler) Execute(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, func(id uuid.UUID, version int) (*model.TransferRequest, error) {
+		return h.Service.Execute(r.Context(), id, version)
+	})
+}
+
+// Cancel godoc
+// @Summary Отменить заявку
+// @Tags transfer_requests
+// @Produce json
+// @Param id path string true "Transfer request ID"
+// @Param If-Match header string false "Expected request version (ETag)"
+// @Success 200 {object} model.TransferRequest
+// @Failure 409 {string} string "Request is already closed"
+// @Security BearerAuth
+// @Router /api/transfer-requests/{id}/cancel [post]
+func (h *TransferRequestHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.handle(w, r, func(id uuid.UUID, version int) (*model.TransferRequest, error) {
+		return h.Service.Cancel(r.Context(), id, version)
+	})
+}
+
+// handle - общая часть действий над заявкой: разбор ID и If-Match, ответ
+func (h *TransferRequestHandler) handle(w http.ResponseWriter, r *http.Request, action func(id uuid.UUID, version int) (*model.TransferRequest, error)) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	request, err := action(id, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, request.Version)
+	json.NewEncoder(w).Encode(request)
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type UserHandler struct {
+	Service *services.UserService
+}
+
+type CreateUserRequest struct {
+	Username string     `json:"username"`
+	Role     model.Role `json:"role"`
+	Password string     `json:"password"`
+}
+
+// UpdateUserRequest - отсутствующие поля не меняются
+type UpdateUserRequest struct {
+	Role     *model.Role `json:"role,omitempty"`
+	Disabled *bool       `json:"disabled,omitempty"`
+	Password *string     `json:"password,omitempty"`
+}
+
+// GetAll godoc
+// @Summary Список сотрудников (администратор)
+// @Tags users
+// @Produce json
+// @Success 200 {array} model.User
+// @Security BearerAuth
+// @Router /api/users [get]
+func (h *UserHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	users, err := h.Service.GetUsers()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(users)
+}
+
+// GetByID godoc
+// @Summary Сотрудник по id (администратор)
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} model.User
+// @Failure 404 {string} string "User not found"
+// @Security BearerAuth
+// @Router /api/users/{id} [get]
+func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.Service.GetUser(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, user.Version)
+	json.NewEncoder(w).Encode(user)
+}
+
+// Create godoc
+// @Summary Добавить сотрудника (администратор)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body CreateUserRequest true "keeper, vet, curator, admin or public"
+// @Success 201 {object} model.User
+// @Failure 400 {string} string "Invalid user data"
+// @Failure 409 {string} string "Username is taken"
+// @Security BearerAuth
+// @Router /api/users [post]
+func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.Service.CreateUser(r.Context(), req.Username, req.Role, req.Password)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, user.Version)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(user)
+}
+
+// Update godoc
+// @Summary Изменить роль, блокировку или пароль сотрудника (администратор)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param If-Match header string false "Expected user version (ETag)"
+// @Param user body UpdateUserRequest true "Fields to change"
+// @Success 200 {object} model.User
+// @Failure 400 {string} string "Invalid user data"
+// @Failure 404 {string} string "User not found"
+// @Failure 412 {string} string "User was modified by someone else"
+// @Security BearerAuth
+// @Router /api/users/{id} [put]
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.Service.UpdateUser(r.Context(), id, services.UserUpdate{
+		Role:     req.Role,
+		Disabled: req.Disabled,
+		Password: req.Password,
+	}, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, user.Version)
+	json.NewEncoder(w).Encode(user)
+}
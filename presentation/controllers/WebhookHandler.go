@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"encoding/json"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	Service *services.WebhookService
+}
+
+// CreateWebhookRequest - пустой secret будет сгенерирован
+type CreateWebhookRequest struct {
+	URL        string            `json:"url"`
+	EventTypes []model.EventType `json:"eventTypes"`
+	Secret     string            `json:"secret,omitempty"`
+}
+
+// UpdateWebhookRequest - отсутствующие поля не меняются
+type UpdateWebhookRequest struct {
+	URL          *string            `json:"url,omitempty"`
+	EventTypes   *[]model.EventType `json:"eventTypes,omitempty"`
+	Active       *bool              `json:"active,omitempty"`
+	RotateSecret bool               `json:"rotateSecret,omitempty"`
+}
+
+// WebhookWithSecret - подписка вместе с секретом; секрет возвращается
+// только при создании и при смене
+type WebhookWithSecret struct {
+	model.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// GetAll godoc
+// @Summary Подписки на вебхуки (администратор)
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} model.WebhookSubscription
+// @Security BearerAuth
+// @Router /api/webhooks [get]
+func (h *WebhookHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.Service.GetWebhooks()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscriptions)
+}
+
+// GetByID godoc
+// @Summary Подписка на вебхуки по id (администратор)
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} model.WebhookSubscription
+// @Failure 404 {string} string "Webhook not found"
+// @Security BearerAuth
+// @Router /api/webhooks/{id} [get]
+func (h *WebhookHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.Service.GetWebhook(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, subscription.Version)
+	json.NewEncoder(w).Encode(subscription)
+}
+
+// Create godoc
+// @Summary Подписать внешнюю систему на события (администратор)
+// @Description Events are POSTed as JSON with X-Zoo-Event, X-Zoo-Delivery and
+// @Description X-Zoo-Signature: "t=<unix>,v1=<hex HMAC-SHA256 of '<unix>.<body>' with the secret>".
+// @Description Empty eventTypes means all events. The secret is returned only once.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body CreateWebhookRequest true "Target URL, event types and optional secret"
+// @Success 201 {object} WebhookWithSecret
+// @Failure 400 {string} string "Invalid webhook data"
+// @Security BearerAuth
+// @Router /api/webhooks [post]
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.Service.CreateWebhook(r.Context(), req.URL, req.EventTypes, req.Secret)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, subscription.Version)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(WebhookWithSecret{WebhookSubscription: *subscription, Secret: subscription.Secret})
+}
+
+// Update godoc
+// @Summary Изменить подписку на вебхуки (администратор)
+// @Description rotateSecret issues a new secret and returns it in the response.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param If-Match header string false "Expected webhook version (ETag)"
+// @Param webhook body UpdateWebhookRequest true "Fields to change"
+// @Success 200 {object} model.WebhookSubscription
+// @Failure 400 {string} string "Invalid webhook data"
+// @Failure 404 {string} string "Webhook not found"
+// @Failure 412 {string} string "Webhook was modified by someone else"
+// @Security BearerAuth
+// @Router /api/webhooks/{id} [put]
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.Service.UpdateWebhook(r.Context(), id, services.WebhookUpdate{
+		URL:          req.URL,
+		EventTypes:   req.EventTypes,
+		Active:       req.Active,
+		RotateSecret: req.RotateSecret,
+	}, version)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, subscription.Version)
+	if req.RotateSecret {
+		json.NewEncoder(w).Encode(WebhookWithSecret{WebhookSubscription: *subscription, Secret: subscription.Secret})
+		return
+	}
+	json.NewEncoder(w).Encode(subscription)
+}
+
+// Delete godoc
+// @Summary Удалить подписку на вебхуки (администратор)
+// @Tags webhooks
+// @Param id path string true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Webhook not found"
+// @Security BearerAuth
+// @Router /api/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.DeleteWebhook(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Deliveries godoc
+// @Summary Журнал доставок подписки (администратор)
+// @Description Newest first, with every attempt's status code, error and duration
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Max deliveries, 100 by default"
+// @Success 200 {array} model.WebhookDelivery
+// @Failure 404 {string} string "Webhook not found"
+// @Security BearerAuth
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	var limit int
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	deliveries, err := h.Service.GetDeliveries(id, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// DeadLetters godoc
+// @Summary Недоставленные события (администратор)
+// @Description Deliveries that exhausted all retries, newest first
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} model.WebhookDelivery
+// @Security BearerAuth
+// @Router /api/webhooks/dead-letters [get]
+func (h *WebhookHandler) DeadLetters(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.Service.GetDeadLetters()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// Redeliver godoc
+// @Summary Отправить недоставленное событие заново (администратор)
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 202 {object} model.WebhookDelivery
+// @Failure 404 {string} string "Delivery not found"
+// @Failure 409 {string} string "Delivery is not dead"
+// @Security BearerAuth
+// @Router /api/webhooks/deliveries/{id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.Service.Redeliver(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(delivery)
+}
@@ -2,10 +2,13 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
+	"kpo-mini-dz2/application/services"
 	"kpo-mini-dz2/domain/model"
 	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -14,6 +17,37 @@ import (
 type ZooStatisticsHandler struct {
 	AnimalRepo    RP.IAnimalRepository
 	EnclosureRepo RP.IEnclosureRepository
+	Service       *services.ZooStatisticsService
+}
+
+// GetStatistics godoc
+// @Summary Статистика зоопарка
+// @Description Animals by species and health, enclosure occupancy, upcoming and missed feedings, transfers per day
+// @Tags ZooStat
+// @Produce json
+// @Param days query int false "Days of transfer history, 7 by default"
+// @Success 200 {object} services.ZooStatistics
+// @Failure 400 {string} string "Invalid days"
+// @Security BearerAuth
+// @Router /api/statistics [get]
+func (h *ZooStatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request) {
+	days := 7
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		if days, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+
+	statistics, err := h.Service.GetStatistics(time.Now(), days)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statistics)
 }
 
 func (h *ZooStatisticsHandler) GetAllAnimals(w http.ResponseWriter, r *http.Request) {
@@ -24,21 +58,36 @@ func (h *ZooStatisticsHandler) GetAllAnimals(w http.ResponseWriter, r *http.Requ
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(animals)
+	json.NewEncoder(w).Encode(model.PresentAnimals(animals))
 }
 
 // GetAllEnclosures godoc
 // @Summary Получить все клетки
 // @Tags ZooStat
 // @Produce json
+// @Param includeDeleted query bool false "Also list deleted enclosures"
 // @Success 200 {object} model.Enclosure
+// @Security BearerAuth
 // @Router /api/zoostat/ [get]
 func (h *ZooStatisticsHandler) GetAllEnclosures(w http.ResponseWriter, r *http.Request) {
+	withDeleted, err := includeDeleted(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 	enclosures, err := h.EnclosureRepo.FindAll()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if withDeleted {
+		deleted, err := h.EnclosureRepo.FindDeleted()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		enclosures = append(enclosures, deleted...)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(enclosures)
@@ -49,6 +98,7 @@ func (h *ZooStatisticsHandler) GetAllEnclosures(w http.ResponseWriter, r *http.R
 // @Tags ZooStat
 // @Produce json
 // @Success 200 {array} model.Animal
+// @Security BearerAuth
 // @Router /api/zoostat/{species} [get]
 func (h *ZooStatisticsHandler) GetAnimalsBySpecies(w http.ResponseWriter, r *http.Request) {
 	speciesName := chi.URLParam(r, "species")
@@ -60,7 +110,7 @@ func (h *ZooStatisticsHandler) GetAnimalsBySpecies(w http.ResponseWriter, r *htt
 	}
 
 	filteredAnimals := make([]model.Animal, 0)
-	for _, animal := range animals {
+	for _, animal := range model.PresentAnimals(animals) {
 		if animal.Species.Name == speciesName {
 			filteredAnimals = append(filteredAnimals, animal)
 		}
@@ -76,6 +126,7 @@ func (h *ZooStatisticsHandler) GetAnimalsBySpecies(w http.ResponseWriter, r *htt
 // @Tags ZooStat
 // @Produce json
 // @Success 200 {array} model.Enclosure
+// @Security BearerAuth
 // @Router /api/zoostat/{type} [get]
 func (h *ZooStatisticsHandler) GetEnclosuresByType(w http.ResponseWriter, r *http.Request) {
 	typeParam := chi.URLParam(r, "type")
@@ -96,6 +147,7 @@ func (h *ZooStatisticsHandler) GetEnclosuresByType(w http.ResponseWriter, r *htt
 // @Tags ZooStat
 // @Produce json
 // @Success 200 {array} model.Enclosure
+// @Security BearerAuth
 // @Router /api/zoostat/space [get]
 func (h *ZooStatisticsHandler) GetEnclosuresWithAvailableSpace(w http.ResponseWriter, r *http.Request) {
 	// Получаем параметр minSpace из query, если есть
@@ -123,6 +175,7 @@ func (h *ZooStatisticsHandler) GetEnclosuresWithAvailableSpace(w http.ResponseWr
 // @Tags ZooStat
 // @Produce json
 // @Success 200 {integer} int "Animal count"
+// @Security BearerAuth
 // @Router /api/zoostat/count [get]
 func (h *ZooStatisticsHandler) GetAnimalCount(w http.ResponseWriter, r *http.Request) {
 	count := h.AnimalRepo.AnimalCount()
@@ -141,6 +194,7 @@ func (h *ZooStatisticsHandler) GetAnimalCount(w http.ResponseWriter, r *http.Req
 // @Tags ZooStat
 // @Produce json
 // @Success 200 {integer} int "Animal count"
+// @Security BearerAuth
 // @Router /api/zoostat/count [get]
 func (h *ZooStatisticsHandler) GetEnclosureByID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -149,13 +203,22 @@ func (h *ZooStatisticsHandler) GetEnclosureByID(w http.ResponseWriter, r *http.R
 		http.Error(w, "Invalid ID format", http.StatusBadRequest)
 		return
 	}
+	withDeleted, err := includeDeleted(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
 	enclosure, err := h.EnclosureRepo.FindByID(id)
+	if errors.Is(err, model.ErrEnclosureNotFound) && withDeleted {
+		enclosure, err = h.EnclosureRepo.FindDeletedByID(id)
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	setETag(w, enclosure.Version)
 	json.NewEncoder(w).Encode(enclosure)
 }
@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"strconv"
+)
+
+// includeDeleted - параметр ?includeDeleted: показывать ли мягко удалённые записи
+func includeDeleted(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("includeDeleted")
+	if value == "" {
+		return false, nil
+	}
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: includeDeleted должен быть true или false", model.ErrValidation)
+	}
+	return include, nil
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"errors"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+)
+
+// errorStatus - сопоставляет доменную ошибку с HTTP-кодом
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrAnimalNotFound),
+		errors.Is(err, model.ErrEnclosureNotFound),
+		errors.Is(err, model.ErrScheduleNotFound),
+		errors.Is(err, model.ErrTransferNotFound),
+		errors.Is(err, model.ErrUserNotFound),
+		errors.Is(err, model.ErrWebhookNotFound),
+		errors.Is(err, model.ErrDeliveryNotFound),
+		errors.Is(err, model.ErrMedicalCaseNotFound),
+		errors.Is(err, model.ErrCarePlanNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrEnclosureFull),
+		errors.Is(err, model.ErrIncompatibleEnclosure),
+		errors.Is(err, model.ErrInvalidTransition),
+		errors.Is(err, model.ErrCircularParentage),
+		errors.Is(err, model.ErrFeedingAlreadyDone),
+		errors.Is(err, model.ErrAuditChainBroken),
+		errors.Is(err, model.ErrUserExists),
+		errors.Is(err, model.ErrEnclosureNameTaken),
+		errors.Is(err, model.ErrCarePlanExists):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrUnauthorized),
+		errors.Is(err, model.ErrInvalidCredentials):
+		return http.StatusUnauthorized
+	case errors.Is(err, model.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, model.ErrValidation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), errorStatus(err))
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// setETag - версия записи отдаётся клиенту как ETag
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", fmt.Sprintf("\"%d\"", version))
+}
+
+// ifMatchVersion - версия из заголовка If-Match.
+// Без заголовка или со значением "*" версия не проверяется.
+func ifMatchVersion(r *http.Request) (int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return model.AnyVersion, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("%w: некорректный If-Match", model.ErrValidation)
+	}
+	return version, nil
+}
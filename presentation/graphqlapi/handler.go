@@ -0,0 +1,208 @@
+// Package graphqlapi - GraphQL API зоопарка поверх тех же прикладных сервисов, что и REST;
+// схема - в schema.graphql
+package graphqlapi
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"kpo-mini-dz2/presentation/middleware"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	maxDepth       = 12
+	maxQueryLength = 64 << 10
+)
+
+// Services - зависимости GraphQL API; чтение идёт прямо в репозитории,
+// через одну транзакцию только для чтения на запрос
+type Services struct {
+	UnitOfWork RP.IUnitOfWorkFactory
+	Animals    *services.AnimalService
+	Enclosures *services.EnclosureService
+	Feeding    *services.FeedingService
+	Transfers  *services.AnimalTransferService
+	Movements  *services.MovementHistoryService
+	Statistics *services.ZooStatisticsService
+	// Idempotency - ключи Idempotency-Key, общие с REST и gRPC
+	Idempotency *middleware.IdempotencyGuard
+}
+
+type Handler struct {
+	deps   *Services
+	schema *graphql.Schema
+}
+
+func NewHandler(deps Services) (*Handler, error) {
+	parsed, err := graphql.ParseSchema(schema, &resolver{deps: &deps},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxQueryLength(maxQueryLength),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{deps: &deps, schema: parsed}, nil
+}
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ServeHTTP godoc
+// @Summary GraphQL API
+// @Description Animals, enclosures, species, feeding schedules and statistics as a connected graph,
+// @Description with filters and mutations for the core use cases. Schema: presentation/graphqlapi/schema.graphql.
+// @Description Errors carry extensions.code: NOT_FOUND, CONFLICT, VERSION_CONFLICT, BAD_INPUT, FORBIDDEN, UNAUTHENTICATED, INTERNAL.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body graphqlRequest true "query, operationName, variables"
+// @Param Idempotency-Key header string false "Retries with the same key return the first response"
+// @Success 200 {object} object
+// @Failure 400 {string} string "Invalid request body"
+// @Security BearerAuth
+// @Router /graphql [post]
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Query == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	key := r.Header.Get(middleware.IdempotencyKeyHeader)
+	if key == "" || h.deps.Idempotency == nil {
+		writeResponse(w, h.exec(r.Context(), req))
+		return
+	}
+	h.serveIdempotent(w, r, req, key)
+}
+
+// serveIdempotent - запрос с заголовком Idempotency-Key выполняется один раз,
+// повтор получает сохранённый первый ответ. Ответ с ошибкой INTERNAL
+// не сохраняется, как ответы 5xx в REST.
+func (h *Handler) serveIdempotent(w http.ResponseWriter, r *http.Request, req graphqlRequest, key string) {
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	record, existing, err := h.deps.Idempotency.Reserve(requestcontext.ActorFrom(r.Context()), key, fingerprint)
+	if errors.Is(err, middleware.ErrIdempotencyKeyTooLong) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if existing != nil {
+		middleware.Replay(w, *existing, fingerprint)
+		return
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			h.deps.Idempotency.Release(r.Context(), record.Key)
+		}
+	}()
+
+	response := h.exec(r.Context(), req)
+	body, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !hasInternalError(response) {
+		record.StatusCode = http.StatusOK
+		record.Header = http.Header{"Content-Type": {"application/json"}}
+		record.Body = body
+		h.deps.Idempotency.Complete(r.Context(), record)
+		completed = true
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// exec - выполняет запрос; ошибкам резолверов проставляется extensions.code
+func (h *Handler) exec(ctx context.Context, req graphqlRequest) *graphql.Response {
+	ctx = withLoader(ctx, newLoader(ctx, h.deps))
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	for _, queryError := range response.Errors {
+		if queryError.ResolverError == nil {
+			continue
+		}
+		if queryError.Extensions == nil {
+			queryError.Extensions = map[string]any{}
+		}
+		queryError.Extensions["code"] = errorCode(queryError.ResolverError)
+	}
+	return response
+}
+
+func writeResponse(w http.ResponseWriter, response *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func hasInternalError(response *graphql.Response) bool {
+	for _, queryError := range response.Errors {
+		if queryError.Extensions["code"] == "INTERNAL" {
+			return true
+		}
+	}
+	return false
+}
+
+// requestFingerprint - запрос, операция и переменные; json.Marshal сортирует
+// ключи, поэтому порядок полей в теле не влияет
+func requestFingerprint(req graphqlRequest) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(append([]byte("graphql\n"), data...))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// errorCode - код доменной ошибки для extensions.code, как errorStatus в controllers
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, model.ErrAnimalNotFound),
+		errors.Is(err, model.ErrEnclosureNotFound),
+		errors.Is(err, model.ErrScheduleNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, model.ErrEnclosureFull),
+		errors.Is(err, model.ErrIncompatibleEnclosure),
+		errors.Is(err, model.ErrInvalidTransition),
+		errors.Is(err, model.ErrFeedingAlreadyDone),
+		errors.Is(err, model.ErrEnclosureNameTaken):
+		return "CONFLICT"
+	case errors.Is(err, model.ErrForbidden):
+		return "FORBIDDEN"
+	case errors.Is(err, model.ErrUnauthorized):
+		return "UNAUTHENTICATED"
+	case errors.Is(err, model.ErrVersionConflict):
+		return "VERSION_CONFLICT"
+	case errors.Is(err, model.ErrValidation):
+		return "BAD_INPUT"
+	default:
+		return "INTERNAL"
+	}
+}
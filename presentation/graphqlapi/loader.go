@@ -0,0 +1,204 @@
+package graphqlapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// batch - данные, которые загружаются один раз на запрос, сколько бы
+// полей их ни спросили; поля разрешаются параллельно, поэтому под мьютексом
+type batch[T any] struct {
+	mu     sync.Mutex
+	loaded bool
+	value  T
+	load   func() (T, error)
+}
+
+func (b *batch[T]) get() (T, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.loaded {
+		value, err := b.load()
+		if err != nil {
+			return value, err
+		}
+		b.value, b.loaded = value, true
+	}
+	return b.value, nil
+}
+
+func (b *batch[T]) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var zero T
+	b.value, b.loaded = zero, false
+}
+
+// animalIndex - list только животные в зоопарке, all и byID - включая выбывших
+type animalIndex struct {
+	list        []model.Animal
+	all         []model.Animal
+	byID        map[uuid.UUID]model.Animal
+	byEnclosure map[uuid.UUID][]model.Animal
+}
+
+type enclosureIndex struct {
+	list []model.Enclosure
+	byID map[uuid.UUID]model.Enclosure
+}
+
+type scheduleIndex struct {
+	byAnimal map[uuid.UUID][]model.FeedingSchedule
+	byID     map[uuid.UUID]model.FeedingSchedule
+}
+
+// zooView - данные всех репозиториев, прочитанные в одной транзакции
+// только для чтения, чтобы животные, вольеры и кормления сходились между собой
+type zooView struct {
+	animals    []model.Animal
+	enclosures []model.Enclosure
+	schedules  map[uuid.UUID][]model.FeedingSchedule
+	movements  map[uuid.UUID][]model.Movement
+}
+
+// loader - снимок репозиториев на время одного запроса: вместо обращения
+// к репозиторию на каждое животное вольера или кормление животного
+// все данные читаются один раз, а индексы строятся по мере надобности
+type loader struct {
+	view       batch[zooView]
+	animals    batch[animalIndex]
+	enclosures batch[enclosureIndex]
+	schedules  batch[scheduleIndex]
+	movements  batch[map[uuid.UUID][]model.Movement]
+}
+
+func newLoader(ctx context.Context, deps *Services) *loader {
+	l := &loader{}
+	l.view.load = func() (zooView, error) {
+		tx, err := deps.UnitOfWork.BeginRead(ctx)
+		if err != nil {
+			return zooView{}, err
+		}
+		defer tx.Rollback()
+
+		var view zooView
+		if view.animals, err = tx.Animals().FindAll(); err != nil {
+			return zooView{}, err
+		}
+		if view.enclosures, err = tx.Enclosures().FindAll(); err != nil {
+			return zooView{}, err
+		}
+		if view.schedules, err = tx.FeedingSchedules().GetAllSchedules(); err != nil {
+			return zooView{}, err
+		}
+		if view.movements, err = services.NewMovementHistoryService(tx.Movements()).GetMovementsByAnimal(); err != nil {
+			return zooView{}, err
+		}
+		return view, nil
+	}
+	l.animals.load = func() (animalIndex, error) {
+		view, err := l.view.get()
+		if err != nil {
+			return animalIndex{}, err
+		}
+		animals := slices.Clone(view.animals)
+		sort.Slice(animals, func(i, j int) bool { return animals[i].Name < animals[j].Name })
+		index := animalIndex{
+			list:        model.PresentAnimals(animals),
+			all:         animals,
+			byID:        make(map[uuid.UUID]model.Animal, len(animals)),
+			byEnclosure: make(map[uuid.UUID][]model.Animal),
+		}
+		for _, animal := range animals {
+			index.byID[animal.ID] = animal
+			if animal.EnclosureID != uuid.Nil {
+				index.byEnclosure[animal.EnclosureID] = append(index.byEnclosure[animal.EnclosureID], animal)
+			}
+		}
+		return index, nil
+	}
+	l.enclosures.load = func() (enclosureIndex, error) {
+		view, err := l.view.get()
+		if err != nil {
+			return enclosureIndex{}, err
+		}
+		enclosures := slices.Clone(view.enclosures)
+		sort.Slice(enclosures, func(i, j int) bool { return enclosures[i].ID.String() < enclosures[j].ID.String() })
+		index := enclosureIndex{list: enclosures, byID: make(map[uuid.UUID]model.Enclosure, len(enclosures))}
+		for _, enclosure := range enclosures {
+			index.byID[enclosure.ID] = enclosure
+		}
+		return index, nil
+	}
+	l.schedules.load = func() (scheduleIndex, error) {
+		view, err := l.view.get()
+		if err != nil {
+			return scheduleIndex{}, err
+		}
+		all := view.schedules
+		index := scheduleIndex{byAnimal: make(map[uuid.UUID][]model.FeedingSchedule, len(all)), byID: make(map[uuid.UUID]model.FeedingSchedule)}
+		for animalID, schedules := range all {
+			schedules = slices.Clone(schedules)
+			sort.Slice(schedules, func(i, j int) bool { return schedules[i].FeedingTime.Before(schedules[j].FeedingTime) })
+			index.byAnimal[animalID] = schedules
+			for _, schedule := range schedules {
+				index.byID[schedule.ID] = schedule
+			}
+		}
+		return index, nil
+	}
+	l.movements.load = func() (map[uuid.UUID][]model.Movement, error) {
+		view, err := l.view.get()
+		return view.movements, err
+	}
+	return l
+}
+
+// invalidate - после мутации следующие поля должны видеть новое состояние
+func (l *loader) invalidate() {
+	l.view.reset()
+	l.animals.reset()
+	l.enclosures.reset()
+	l.schedules.reset()
+	l.movements.reset()
+}
+
+func (l *loader) animal(id uuid.UUID) (*model.Animal, error) {
+	index, err := l.animals.get()
+	if err != nil {
+		return nil, err
+	}
+	animal, ok := index.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &animal, nil
+}
+
+func (l *loader) enclosure(id uuid.UUID) (*model.Enclosure, error) {
+	index, err := l.enclosures.get()
+	if err != nil {
+		return nil, err
+	}
+	enclosure, ok := index.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &enclosure, nil
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, l *loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func loaderFrom(ctx context.Context) *loader {
+	return ctx.Value(loaderKey{}).(*loader)
+}
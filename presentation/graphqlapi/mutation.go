@@ -0,0 +1,355 @@
+package graphqlapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// require - права на мутацию, как у соответствующего маршрута REST
+func require(ctx context.Context, permissions ...model.Permission) error {
+	actor := requestcontext.ActorFrom(ctx)
+	if !actor.IsAuthenticated() {
+		return model.ErrUnauthorized
+	}
+	for _, permission := range permissions {
+		if actor.Role.Can(permission) {
+			return nil
+		}
+	}
+	return model.ErrForbidden
+}
+
+// expectedVersion - отсутствующая версия не проверяется
+func expectedVersion(version *int32) int {
+	if version == nil {
+		return model.AnyVersion
+	}
+	return int(*version)
+}
+
+type speciesInput struct {
+	AnimalType string
+	Name       string
+}
+
+type foodInput struct {
+	FoodType string
+	Name     string
+}
+
+type animalInput struct {
+	Name         string
+	Species      speciesInput
+	BirthDate    graphql.Time
+	HealthStatus string
+	Gender       string
+	FavoriteFood foodInput
+}
+
+func (in animalInput) model() model.Animal {
+	return model.Animal{
+		Name:         in.Name,
+		Species:      model.Species{AnimalType: modelValue[model.AnimalType](in.Species.AnimalType), Name: in.Species.Name},
+		BirthDate:    in.BirthDate.Time,
+		HealthStatus: modelValue[model.HealthStatus](in.HealthStatus),
+		Gender:       modelValue[model.Gender](in.Gender),
+		FavoriteFood: model.Food{FoodType: modelValue[model.FoodType](in.FavoriteFood.FoodType), Name: in.FavoriteFood.Name},
+	}
+}
+
+type sizeInput struct {
+	Length int32
+	Width  int32
+	Height int32
+}
+
+type enclosureInput struct {
+	Name        *string
+	Type        string
+	Size        sizeInput
+	MaxCapacity int32
+}
+
+func (in enclosureInput) name() string {
+	if in.Name == nil {
+		return ""
+	}
+	return *in.Name
+}
+
+func (in enclosureInput) size() model.Size {
+	return model.Size{Lenght: int(in.Size.Length), Width: int(in.Size.Width), Height: int(in.Size.Height)}
+}
+
+func (r *resolver) CreateAnimal(ctx context.Context, args struct {
+	Input       animalInput
+	EnclosureID *graphql.ID
+}) (*animalResolver, error) {
+	if err := require(ctx, model.PermAdmitAnimals); err != nil {
+		return nil, err
+	}
+	data := args.Input.model()
+	if args.EnclosureID != nil {
+		var err error
+		if data.EnclosureID, err = parseID(*args.EnclosureID, "enclosureId"); err != nil {
+			return nil, err
+		}
+	}
+
+	animal, err := r.deps.Animals.AddAnimal(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &animalResolver{*animal}, nil
+}
+
+func (r *resolver) UpdateAnimal(ctx context.Context, args struct {
+	ID              graphql.ID
+	Input           animalInput
+	ExpectedVersion *int32
+}) (*animalResolver, error) {
+	if err := require(ctx, model.PermManageAnimals); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	animal, err := r.deps.Animals.UpdateAnimal(ctx, id, args.Input.model(), expectedVersion(args.ExpectedVersion))
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &animalResolver{*animal}, nil
+}
+
+func (r *resolver) DeleteAnimal(ctx context.Context, args struct {
+	ID              graphql.ID
+	ExpectedVersion *int32
+}) (bool, error) {
+	if err := require(ctx, model.PermDeleteAnimals); err != nil {
+		return false, err
+	}
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Animals.DeleteAnimal(ctx, id, expectedVersion(args.ExpectedVersion)); err != nil {
+		return false, err
+	}
+	loaderFrom(ctx).invalidate()
+	return true, nil
+}
+
+func (r *resolver) CreateEnclosure(ctx context.Context, args struct{ Input enclosureInput }) (*enclosureResolver, error) {
+	if err := require(ctx, model.PermManageEnclosures); err != nil {
+		return nil, err
+	}
+
+	enclosure, err := r.deps.Enclosures.AddEnclosure(ctx, args.Input.name(), modelValue[model.AnimalType](args.Input.Type), args.Input.size(), int(args.Input.MaxCapacity))
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &enclosureResolver{*enclosure}, nil
+}
+
+func (r *resolver) UpdateEnclosure(ctx context.Context, args struct {
+	ID              graphql.ID
+	Input           enclosureInput
+	ExpectedVersion *int32
+}) (*enclosureResolver, error) {
+	if err := require(ctx, model.PermManageEnclosures); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	enclosure, err := r.deps.Enclosures.UpdateEnclosure(ctx, id, args.Input.name(), modelValue[model.AnimalType](args.Input.Type), args.Input.size(), int(args.Input.MaxCapacity), expectedVersion(args.ExpectedVersion))
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &enclosureResolver{*enclosure}, nil
+}
+
+func (r *resolver) DeleteEnclosure(ctx context.Context, args struct {
+	ID              graphql.ID
+	ExpectedVersion *int32
+}) (bool, error) {
+	if err := require(ctx, model.PermDeleteEnclosures); err != nil {
+		return false, err
+	}
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Enclosures.DeleteEnclosure(ctx, id, expectedVersion(args.ExpectedVersion)); err != nil {
+		return false, err
+	}
+	loaderFrom(ctx).invalidate()
+	return true, nil
+}
+
+func (r *resolver) TransferAnimal(ctx context.Context, args struct {
+	AnimalID      graphql.ID
+	ToEnclosureID graphql.ID
+	Reason        *string
+}) (*animalResolver, error) {
+	if err := require(ctx, model.PermMoveAnimals); err != nil {
+		return nil, err
+	}
+	animalID, err := parseID(args.AnimalID, "animalId")
+	if err != nil {
+		return nil, err
+	}
+	toEnclosureID, err := parseID(args.ToEnclosureID, "toEnclosureId")
+	if err != nil {
+		return nil, err
+	}
+	var reason string
+	if args.Reason != nil {
+		reason = *args.Reason
+	}
+
+	if err := r.deps.Transfers.TransferAnimal(ctx, animalID, toEnclosureID, reason); err != nil {
+		return nil, err
+	}
+	l := loaderFrom(ctx)
+	l.invalidate()
+	animal, err := l.animal(animalID)
+	if err != nil {
+		return nil, err
+	}
+	if animal == nil {
+		return nil, model.ErrAnimalNotFound
+	}
+	return &animalResolver{*animal}, nil
+}
+
+type transferMoveInput struct {
+	AnimalID      graphql.ID
+	ToEnclosureID graphql.ID
+}
+
+func (r *resolver) TransferBatch(ctx context.Context, args struct {
+	Moves  []transferMoveInput
+	Apply  bool
+	Reason *string
+}) (*transferBatchResolver, error) {
+	if err := require(ctx, model.PermMoveAnimals); err != nil {
+		return nil, err
+	}
+	moves := make([]services.TransferMove, 0, len(args.Moves))
+	for _, move := range args.Moves {
+		animalID, err := parseID(move.AnimalID, "animalId")
+		if err != nil {
+			return nil, err
+		}
+		toEnclosureID, err := parseID(move.ToEnclosureID, "toEnclosureId")
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, services.TransferMove{AnimalID: animalID, ToEnclosureID: toEnclosureID})
+	}
+	var reason string
+	if args.Reason != nil {
+		reason = *args.Reason
+	}
+
+	result, err := r.deps.Transfers.TransferBatch(ctx, moves, args.Apply, reason)
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &transferBatchResolver{*result}, nil
+}
+
+func (r *resolver) AddFeedingSchedule(ctx context.Context, args struct {
+	AnimalID    graphql.ID
+	FeedingTime graphql.Time
+	FoodType    string
+}) (*scheduleResolver, error) {
+	if err := require(ctx, model.PermManageFeeding); err != nil {
+		return nil, err
+	}
+	animalID, err := parseID(args.AnimalID, "animalId")
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := r.deps.Feeding.AddFeedingSchedule(ctx, animalID, args.FeedingTime.Time, modelValue[model.FoodType](args.FoodType))
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &scheduleResolver{*schedule}, nil
+}
+
+func (r *resolver) UpdateFeedingSchedule(ctx context.Context, args struct {
+	ID              graphql.ID
+	FeedingTime     graphql.Time
+	FoodType        string
+	ExpectedVersion *int32
+}) (*scheduleResolver, error) {
+	if err := require(ctx, model.PermManageFeeding); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := r.deps.Feeding.UpdateFeedingSchedule(ctx, id, args.FeedingTime.Time, modelValue[model.FoodType](args.FoodType), expectedVersion(args.ExpectedVersion))
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &scheduleResolver{*schedule}, nil
+}
+
+func (r *resolver) MarkFeedingDone(ctx context.Context, args struct{ ID graphql.ID }) (*scheduleResolver, error) {
+	if err := require(ctx, model.PermManageFeeding); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := r.deps.Feeding.MarkFeedingDone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	loaderFrom(ctx).invalidate()
+	return &scheduleResolver{*schedule}, nil
+}
+
+func (r *resolver) RemoveFeedingSchedule(ctx context.Context, args struct {
+	AnimalID    graphql.ID
+	FeedingTime graphql.Time
+}) (bool, error) {
+	if err := require(ctx, model.PermManageFeeding); err != nil {
+		return false, err
+	}
+	animalID, err := parseID(args.AnimalID, "animalId")
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.deps.Feeding.RemoveFeedingSchedule(ctx, animalID, args.FeedingTime.Time); err != nil {
+		return false, err
+	}
+	loaderFrom(ctx).invalidate()
+	return true, nil
+}
@@ -0,0 +1,210 @@
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+	"kpo-mini-dz2/domain/model"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// resolver - корневой резолвер запросов и мутаций
+type resolver struct {
+	deps *Services
+}
+
+func parseID(id graphql.ID, name string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: некорректный %s", model.ErrValidation, name)
+	}
+	return parsed, nil
+}
+
+type animalFilter struct {
+	State        *string
+	EnclosureID  *graphql.ID
+	Species      *string
+	AnimalType   *string
+	HealthStatus *string
+	Gender       *string
+	Name         *string
+}
+
+func filterAnimals(animals []model.Animal, filter *animalFilter) ([]*animalResolver, error) {
+	if filter == nil {
+		filter = &animalFilter{}
+	}
+	enclosureID := uuid.Nil
+	if filter.EnclosureID != nil {
+		var err error
+		if enclosureID, err = parseID(*filter.EnclosureID, "enclosureId"); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*animalResolver, 0)
+	for _, animal := range animals {
+		switch {
+		case filter.State != nil && animal.State != lifecycleState(*filter.State),
+			filter.EnclosureID != nil && animal.EnclosureID != enclosureID,
+			filter.Species != nil && animal.Species.Name != *filter.Species,
+			filter.AnimalType != nil && animal.Species.AnimalType != modelValue[model.AnimalType](*filter.AnimalType),
+			filter.HealthStatus != nil && animal.HealthStatus != modelValue[model.HealthStatus](*filter.HealthStatus),
+			filter.Gender != nil && animal.Gender != modelValue[model.Gender](*filter.Gender),
+			filter.Name != nil && !strings.Contains(strings.ToLower(animal.Name), strings.ToLower(*filter.Name)):
+			continue
+		}
+		result = append(result, &animalResolver{animal})
+	}
+	return result, nil
+}
+
+func (r *resolver) Animals(ctx context.Context, args struct{ Filter *animalFilter }) ([]*animalResolver, error) {
+	index, err := loaderFrom(ctx).animals.get()
+	if err != nil {
+		return nil, err
+	}
+	if args.Filter != nil && args.Filter.State != nil {
+		return filterAnimals(index.all, args.Filter)
+	}
+	return filterAnimals(index.list, args.Filter)
+}
+
+func (r *resolver) Animal(ctx context.Context, args struct{ ID graphql.ID }) (*animalResolver, error) {
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return nil, err
+	}
+	return animalByID(ctx, id)
+}
+
+type enclosureFilter struct {
+	Type          *string
+	WithFreeSpace *bool
+}
+
+func (r *resolver) Enclosures(ctx context.Context, args struct{ Filter *enclosureFilter }) ([]*enclosureResolver, error) {
+	index, err := loaderFrom(ctx).enclosures.get()
+	if err != nil {
+		return nil, err
+	}
+	filter := args.Filter
+	if filter == nil {
+		filter = &enclosureFilter{}
+	}
+
+	result := make([]*enclosureResolver, 0)
+	for _, enclosure := range index.list {
+		switch {
+		case filter.Type != nil && enclosure.Type != modelValue[model.AnimalType](*filter.Type),
+			filter.WithFreeSpace != nil && *filter.WithFreeSpace && enclosure.CurrentCount >= enclosure.MaxCapacity:
+			continue
+		}
+		result = append(result, &enclosureResolver{enclosure})
+	}
+	return result, nil
+}
+
+func (r *resolver) Enclosure(ctx context.Context, args struct{ ID graphql.ID }) (*enclosureResolver, error) {
+	id, err := parseID(args.ID, "id")
+	if err != nil {
+		return nil, err
+	}
+	return enclosureByID(ctx, id)
+}
+
+func (r *resolver) Species(ctx context.Context, args struct{ AnimalType *string }) ([]*speciesResolver, error) {
+	index, err := loaderFrom(ctx).animals.get()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[model.Species]bool)
+	result := make([]*speciesResolver, 0)
+	for _, animal := range index.list {
+		if seen[animal.Species] {
+			continue
+		}
+		if args.AnimalType != nil && animal.Species.AnimalType != modelValue[model.AnimalType](*args.AnimalType) {
+			continue
+		}
+		seen[animal.Species] = true
+		result = append(result, &speciesResolver{animal.Species})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].species.Name < result[j].species.Name })
+	return result, nil
+}
+
+type scheduleFilter struct {
+	AnimalID    *graphql.ID
+	PendingOnly *bool
+	MissedOnly  *bool
+}
+
+func (r *resolver) Schedules(ctx context.Context, args struct{ Filter *scheduleFilter }) ([]*scheduleResolver, error) {
+	index, err := loaderFrom(ctx).schedules.get()
+	if err != nil {
+		return nil, err
+	}
+	filter := args.Filter
+	if filter == nil {
+		filter = &scheduleFilter{}
+	}
+
+	var schedules []model.FeedingSchedule
+	if filter.AnimalID != nil {
+		animalID, err := parseID(*filter.AnimalID, "animalId")
+		if err != nil {
+			return nil, err
+		}
+		schedules = index.byAnimal[animalID]
+	} else {
+		for _, animalSchedules := range index.byAnimal {
+			schedules = append(schedules, animalSchedules...)
+		}
+		sort.Slice(schedules, func(i, j int) bool { return schedules[i].FeedingTime.Before(schedules[j].FeedingTime) })
+	}
+
+	now := time.Now()
+	result := make([]*scheduleResolver, 0)
+	for _, schedule := range schedules {
+		switch {
+		case filter.PendingOnly != nil && *filter.PendingOnly && schedule.DoneAt != nil,
+			filter.MissedOnly != nil && *filter.MissedOnly && !schedule.IsMissed(now):
+			continue
+		}
+		result = append(result, &scheduleResolver{schedule})
+	}
+	return result, nil
+}
+
+func (r *resolver) Stats(ctx context.Context, args struct{ TransferDays int32 }) (*statisticsResolver, error) {
+	statistics, err := r.deps.Statistics.GetStatistics(time.Now(), int(args.TransferDays))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &statisticsResolver{
+		animalCount:      int32(statistics.AnimalCount),
+		enclosureCount:   int32(statistics.EnclosureCount),
+		animalsBySpecies: make([]*speciesHealthCountResolver, 0, len(statistics.AnimalsBySpecies)),
+		occupancy:        make([]*occupancyResolver, 0, len(statistics.Occupancy)),
+		feedings:         statistics.Feedings,
+		transfersPerDay:  make([]*dailyCountResolver, 0, len(statistics.TransfersPerDay)),
+	}
+	for _, count := range statistics.AnimalsBySpecies {
+		result.animalsBySpecies = append(result.animalsBySpecies, &speciesHealthCountResolver{count})
+	}
+	for _, enclosure := range statistics.Occupancy {
+		result.occupancy = append(result.occupancy, &occupancyResolver{enclosure})
+	}
+	for _, day := range statistics.TransfersPerDay {
+		result.transfersPerDay = append(result.transfersPerDay, &dailyCountResolver{day})
+	}
+	return result, nil
+}
@@ -0,0 +1,354 @@
+package graphqlapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Значения перечислений в схеме - имена констант модели в верхнем регистре
+func enumValue[T ~string](value T) string {
+	return strings.ToUpper(string(value))
+}
+
+func modelValue[T ~string](value string) T {
+	return T(strings.ToLower(value))
+}
+
+// lifecycleEnum и lifecycleState - в перечислениях GraphQL нет дефисов
+func lifecycleEnum(state model.LifecycleState) string {
+	return enumValue(model.LifecycleState(strings.ReplaceAll(string(state), "-", "_")))
+}
+
+func lifecycleState(value string) model.LifecycleState {
+	return modelValue[model.LifecycleState](strings.ReplaceAll(value, "_", "-"))
+}
+
+func graphqlID(id uuid.UUID) graphql.ID {
+	return graphql.ID(id.String())
+}
+
+type speciesResolver struct {
+	species model.Species
+}
+
+func (r *speciesResolver) Name() string       { return r.species.Name }
+func (r *speciesResolver) AnimalType() string { return enumValue(r.species.AnimalType) }
+
+func (r *speciesResolver) Count(ctx context.Context) (int32, error) {
+	animals, err := r.Animals(ctx)
+	return int32(len(animals)), err
+}
+
+func (r *speciesResolver) Animals(ctx context.Context) ([]*animalResolver, error) {
+	index, err := loaderFrom(ctx).animals.get()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*animalResolver, 0)
+	for _, animal := range index.list {
+		if animal.Species == r.species {
+			result = append(result, &animalResolver{animal})
+		}
+	}
+	return result, nil
+}
+
+type foodResolver struct {
+	food model.Food
+}
+
+func (r *foodResolver) FoodType() string { return enumValue(r.food.FoodType) }
+func (r *foodResolver) Name() string     { return r.food.Name }
+
+type sizeResolver struct {
+	size model.Size
+}
+
+func (r *sizeResolver) Length() int32 { return int32(r.size.Lenght) }
+func (r *sizeResolver) Width() int32  { return int32(r.size.Width) }
+func (r *sizeResolver) Height() int32 { return int32(r.size.Height) }
+
+type animalResolver struct {
+	animal model.Animal
+}
+
+func (r *animalResolver) ID() graphql.ID              { return graphqlID(r.animal.ID) }
+func (r *animalResolver) Name() string                { return r.animal.Name }
+func (r *animalResolver) Species() *speciesResolver   { return &speciesResolver{r.animal.Species} }
+func (r *animalResolver) BirthDate() graphql.Time     { return graphql.Time{Time: r.animal.BirthDate} }
+func (r *animalResolver) HealthStatus() string        { return enumValue(r.animal.HealthStatus) }
+func (r *animalResolver) Gender() string              { return enumValue(r.animal.Gender) }
+func (r *animalResolver) FavoriteFood() *foodResolver { return &foodResolver{r.animal.FavoriteFood} }
+func (r *animalResolver) State() string               { return lifecycleEnum(r.animal.State) }
+func (r *animalResolver) Version() int32              { return int32(r.animal.Version) }
+
+func (r *animalResolver) Enclosure(ctx context.Context) (*enclosureResolver, error) {
+	if r.animal.EnclosureID == uuid.Nil {
+		return nil, nil
+	}
+	return enclosureByID(ctx, r.animal.EnclosureID)
+}
+
+func (r *animalResolver) Schedules(ctx context.Context, args struct{ PendingOnly bool }) ([]*scheduleResolver, error) {
+	index, err := loaderFrom(ctx).schedules.get()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*scheduleResolver, 0)
+	for _, schedule := range index.byAnimal[r.animal.ID] {
+		if args.PendingOnly && schedule.DoneAt != nil {
+			continue
+		}
+		result = append(result, &scheduleResolver{schedule})
+	}
+	return result, nil
+}
+
+func (r *animalResolver) Movements(ctx context.Context) ([]*movementResolver, error) {
+	byAnimal, err := loaderFrom(ctx).movements.get()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*movementResolver, 0, len(byAnimal[r.animal.ID]))
+	for _, movement := range byAnimal[r.animal.ID] {
+		result = append(result, &movementResolver{movement})
+	}
+	return result, nil
+}
+
+func (r *animalResolver) Sire() *parentResolver { return newParentResolver(r.animal.Sire) }
+func (r *animalResolver) Dam() *parentResolver  { return newParentResolver(r.animal.Dam) }
+
+type parentResolver struct {
+	parent model.Parent
+}
+
+func newParentResolver(parent *model.Parent) *parentResolver {
+	if parent == nil {
+		return nil
+	}
+	return &parentResolver{*parent}
+}
+
+func (r *parentResolver) Animal(ctx context.Context) (*animalResolver, error) {
+	if r.parent.AnimalID == nil {
+		return nil, nil
+	}
+	return animalByID(ctx, *r.parent.AnimalID)
+}
+
+func (r *parentResolver) Institution() *string {
+	if r.parent.External == nil {
+		return nil
+	}
+	return &r.parent.External.Institution
+}
+
+func (r *parentResolver) StudbookID() *string {
+	if r.parent.External == nil {
+		return nil
+	}
+	return &r.parent.External.StudbookID
+}
+
+// Name - у животного другого учреждения без имени - номер в племенной книге,
+// у удалённого животного зоопарка - его id
+func (r *parentResolver) Name(ctx context.Context) (string, error) {
+	if external := r.parent.External; external != nil {
+		if external.Name != "" {
+			return external.Name, nil
+		}
+		return external.StudbookID, nil
+	}
+	animal, err := animalByID(ctx, *r.parent.AnimalID)
+	if err != nil || animal == nil {
+		return r.parent.AnimalID.String(), err
+	}
+	return animal.animal.Name, nil
+}
+
+func animalByID(ctx context.Context, id uuid.UUID) (*animalResolver, error) {
+	animal, err := loaderFrom(ctx).animal(id)
+	if err != nil || animal == nil {
+		return nil, err
+	}
+	return &animalResolver{*animal}, nil
+}
+
+type enclosureResolver struct {
+	enclosure model.Enclosure
+}
+
+func (r *enclosureResolver) ID() graphql.ID      { return graphqlID(r.enclosure.ID) }
+func (r *enclosureResolver) Type() string        { return enumValue(r.enclosure.Type) }
+func (r *enclosureResolver) Size() *sizeResolver { return &sizeResolver{r.enclosure.Size} }
+func (r *enclosureResolver) CurrentCount() int32 { return int32(r.enclosure.CurrentCount) }
+func (r *enclosureResolver) MaxCapacity() int32  { return int32(r.enclosure.MaxCapacity) }
+func (r *enclosureResolver) Version() int32      { return int32(r.enclosure.Version) }
+
+// Name - null у вольера без названия
+func (r *enclosureResolver) Name() *string {
+	if r.enclosure.Name == "" {
+		return nil
+	}
+	return &r.enclosure.Name
+}
+
+func (r *enclosureResolver) FreeSpace() int32 {
+	return int32(max(r.enclosure.MaxCapacity-r.enclosure.CurrentCount, 0))
+}
+
+func (r *enclosureResolver) Animals(ctx context.Context, args struct{ Filter *animalFilter }) ([]*animalResolver, error) {
+	index, err := loaderFrom(ctx).animals.get()
+	if err != nil {
+		return nil, err
+	}
+	return filterAnimals(index.byEnclosure[r.enclosure.ID], args.Filter)
+}
+
+func enclosureByID(ctx context.Context, id uuid.UUID) (*enclosureResolver, error) {
+	enclosure, err := loaderFrom(ctx).enclosure(id)
+	if err != nil || enclosure == nil {
+		return nil, err
+	}
+	return &enclosureResolver{*enclosure}, nil
+}
+
+type scheduleResolver struct {
+	schedule model.FeedingSchedule
+}
+
+func (r *scheduleResolver) ID() graphql.ID { return graphqlID(r.schedule.ID) }
+func (r *scheduleResolver) FeedingTime() graphql.Time {
+	return graphql.Time{Time: r.schedule.FeedingTime}
+}
+func (r *scheduleResolver) FoodType() string { return enumValue(r.schedule.FoodType) }
+func (r *scheduleResolver) Missed() bool     { return r.schedule.IsMissed(time.Now()) }
+func (r *scheduleResolver) Version() int32   { return int32(r.schedule.Version) }
+
+func (r *scheduleResolver) DoneAt() *graphql.Time {
+	if r.schedule.DoneAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.schedule.DoneAt}
+}
+
+func (r *scheduleResolver) Animal(ctx context.Context) (*animalResolver, error) {
+	return animalByID(ctx, r.schedule.AnimalID)
+}
+
+type movementResolver struct {
+	movement model.Movement
+}
+
+func (r *movementResolver) ID() graphql.ID        { return graphqlID(r.movement.ID) }
+func (r *movementResolver) MovedAt() graphql.Time { return graphql.Time{Time: r.movement.MovedAt} }
+func (r *movementResolver) Reason() string        { return r.movement.Reason }
+func (r *movementResolver) Actor() string         { return r.movement.Actor }
+
+// From - null при поступлении в зоопарк или если вольер уже удалён
+func (r *movementResolver) From(ctx context.Context) (*enclosureResolver, error) {
+	if r.movement.FromEnclosureID == uuid.Nil {
+		return nil, nil
+	}
+	return enclosureByID(ctx, r.movement.FromEnclosureID)
+}
+
+// To - null при выбытии из зоопарка или если вольер уже удалён
+func (r *movementResolver) To(ctx context.Context) (*enclosureResolver, error) {
+	if r.movement.ToEnclosureID == uuid.Nil {
+		return nil, nil
+	}
+	return enclosureByID(ctx, r.movement.ToEnclosureID)
+}
+
+type statisticsResolver struct {
+	animalCount      int32
+	enclosureCount   int32
+	animalsBySpecies []*speciesHealthCountResolver
+	occupancy        []*occupancyResolver
+	feedings         services.FeedingCounts
+	transfersPerDay  []*dailyCountResolver
+}
+
+func (r *statisticsResolver) AnimalCount() int32    { return r.animalCount }
+func (r *statisticsResolver) EnclosureCount() int32 { return r.enclosureCount }
+func (r *statisticsResolver) UpcomingFeedings() int32 {
+	return int32(r.feedings.Upcoming)
+}
+func (r *statisticsResolver) MissedFeedings() int32 { return int32(r.feedings.Missed) }
+
+func (r *statisticsResolver) AnimalsBySpecies() []*speciesHealthCountResolver {
+	return r.animalsBySpecies
+}
+func (r *statisticsResolver) Occupancy() []*occupancyResolver        { return r.occupancy }
+func (r *statisticsResolver) TransfersPerDay() []*dailyCountResolver { return r.transfersPerDay }
+
+type speciesHealthCountResolver struct {
+	count services.SpeciesHealthCount
+}
+
+func (r *speciesHealthCountResolver) Species() string      { return r.count.Species }
+func (r *speciesHealthCountResolver) HealthStatus() string { return enumValue(r.count.HealthStatus) }
+func (r *speciesHealthCountResolver) Count() int32         { return int32(r.count.Count) }
+
+type occupancyResolver struct {
+	occupancy services.EnclosureOccupancy
+}
+
+func (r *occupancyResolver) CurrentCount() int32 { return int32(r.occupancy.CurrentCount) }
+func (r *occupancyResolver) MaxCapacity() int32  { return int32(r.occupancy.MaxCapacity) }
+func (r *occupancyResolver) Ratio() float64      { return r.occupancy.Ratio }
+
+func (r *occupancyResolver) Enclosure(ctx context.Context) (*enclosureResolver, error) {
+	return enclosureByID(ctx, r.occupancy.EnclosureID)
+}
+
+type dailyCountResolver struct {
+	count services.DailyCount
+}
+
+func (r *dailyCountResolver) Date() string { return r.count.Date }
+func (r *dailyCountResolver) Count() int32 { return int32(r.count.Count) }
+
+type transferMoveResultResolver struct {
+	move services.TransferMoveResult
+}
+
+func (r *transferMoveResultResolver) Ok() bool { return r.move.OK }
+
+func (r *transferMoveResultResolver) Error() *string {
+	if r.move.Error == "" {
+		return nil
+	}
+	return &r.move.Error
+}
+
+func (r *transferMoveResultResolver) Animal(ctx context.Context) (*animalResolver, error) {
+	return animalByID(ctx, r.move.AnimalID)
+}
+
+func (r *transferMoveResultResolver) ToEnclosure(ctx context.Context) (*enclosureResolver, error) {
+	return enclosureByID(ctx, r.move.ToEnclosureID)
+}
+
+type transferBatchResolver struct {
+	result services.BatchTransferResult
+}
+
+func (r *transferBatchResolver) Valid() bool   { return r.result.Valid }
+func (r *transferBatchResolver) Applied() bool { return r.result.Applied }
+
+func (r *transferBatchResolver) Moves() []*transferMoveResultResolver {
+	result := make([]*transferMoveResultResolver, 0, len(r.result.Moves))
+	for _, move := range r.result.Moves {
+		result = append(result, &transferMoveResultResolver{move})
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type animalServer struct {
+	zoopb.UnimplementedAnimalServiceServer
+	repo      RP.IAnimalRepository
+	service   *services.AnimalService
+	movements *services.MovementHistoryService
+}
+
+func (s *animalServer) ListAnimals(ctx context.Context, req *zoopb.ListAnimalsRequest) (*zoopb.ListAnimalsResponse, error) {
+	enclosureID, err := parseOptionalID(req.GetEnclosureId(), "enclosure_id")
+	if err != nil {
+		return nil, err
+	}
+	animals, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &zoopb.ListAnimalsResponse{}
+	for _, animal := range model.PresentAnimals(animals) {
+		if enclosureID != uuid.Nil && animal.EnclosureID != enclosureID {
+			continue
+		}
+		if req.GetSpecies() != "" && animal.Species.Name != req.GetSpecies() {
+			continue
+		}
+		if req.GetHealthStatus() != zoopb.HealthStatus_HEALTH_STATUS_UNSPECIFIED && healthStatuses[animal.HealthStatus] != req.GetHealthStatus() {
+			continue
+		}
+		response.Animals = append(response.Animals, toAnimal(animal))
+	}
+	return response, nil
+}
+
+func (s *animalServer) GetAnimal(ctx context.Context, req *zoopb.GetAnimalRequest) (*zoopb.Animal, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	animal, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return toAnimal(*animal), nil
+}
+
+func (s *animalServer) CreateAnimal(ctx context.Context, req *zoopb.CreateAnimalRequest) (*zoopb.Animal, error) {
+	data, err := fromAnimal(req.GetAnimal())
+	if err != nil {
+		return nil, err
+	}
+	animal, err := s.service.AddAnimal(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return toAnimal(*animal), nil
+}
+
+func (s *animalServer) UpdateAnimal(ctx context.Context, req *zoopb.UpdateAnimalRequest) (*zoopb.Animal, error) {
+	id, err := parseID(req.GetAnimal().GetId(), "animal.id")
+	if err != nil {
+		return nil, err
+	}
+	data, err := fromAnimal(req.GetAnimal())
+	if err != nil {
+		return nil, err
+	}
+	animal, err := s.service.UpdateAnimal(ctx, id, data, int(req.GetExpectedVersion()))
+	if err != nil {
+		return nil, err
+	}
+	return toAnimal(*animal), nil
+}
+
+func (s *animalServer) DeleteAnimal(ctx context.Context, req *zoopb.DeleteAnimalRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteAnimal(ctx, id, int(req.GetExpectedVersion())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *animalServer) ListMovements(ctx context.Context, req *zoopb.ListMovementsRequest) (*zoopb.ListMovementsResponse, error) {
+	id, err := parseID(req.GetAnimalId(), "animal_id")
+	if err != nil {
+		return nil, err
+	}
+	movements, err := s.movements.GetAnimalMovements(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &zoopb.ListMovementsResponse{}
+	for _, movement := range movements {
+		response.Movements = append(response.Movements, toMovement(movement))
+	}
+	return response, nil
+}
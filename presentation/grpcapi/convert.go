@@ -0,0 +1,217 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var animalTypes = map[model.AnimalType]zoopb.AnimalType{
+	model.Predator:  zoopb.AnimalType_ANIMAL_TYPE_PREDATOR,
+	model.Herbivore: zoopb.AnimalType_ANIMAL_TYPE_HERBIVORE,
+	model.Omnivore:  zoopb.AnimalType_ANIMAL_TYPE_OMNIVORE,
+	model.Aquatic:   zoopb.AnimalType_ANIMAL_TYPE_AQUATIC,
+	model.Avian:     zoopb.AnimalType_ANIMAL_TYPE_AVIAN,
+}
+
+var foodTypes = map[model.FoodType]zoopb.FoodType{
+	model.Meat:      zoopb.FoodType_FOOD_TYPE_MEAT,
+	model.Grass:     zoopb.FoodType_FOOD_TYPE_GRASS,
+	model.Fish:      zoopb.FoodType_FOOD_TYPE_FISH,
+	model.Fruit:     zoopb.FoodType_FOOD_TYPE_FRUIT,
+	model.Vegetable: zoopb.FoodType_FOOD_TYPE_VEGETABLE,
+}
+
+var healthStatuses = map[model.HealthStatus]zoopb.HealthStatus{
+	model.Healthy: zoopb.HealthStatus_HEALTH_STATUS_HEALTHY,
+	model.Sick:    zoopb.HealthStatus_HEALTH_STATUS_SICK,
+}
+
+var genders = map[model.Gender]zoopb.Gender{
+	model.Male:   zoopb.Gender_GENDER_MALE,
+	model.Female: zoopb.Gender_GENDER_FEMALE,
+}
+
+// fromEnum - обратное сопоставление; UNSPECIFIED и неизвестные значения - ошибка
+func fromEnum[M comparable, P comparable](values map[M]P, value P, name string) (M, error) {
+	for domain, proto := range values {
+		if proto == value {
+			return domain, nil
+		}
+	}
+	var zero M
+	return zero, fmt.Errorf("%w: не задан или неизвестен %s", model.ErrValidation, name)
+}
+
+func parseID(value string, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: некорректный %s", model.ErrValidation, name)
+	}
+	return id, nil
+}
+
+// parseOptionalID - пустая строка означает uuid.Nil
+func parseOptionalID(value string, name string) (uuid.UUID, error) {
+	if value == "" {
+		return uuid.Nil, nil
+	}
+	return parseID(value, name)
+}
+
+func optionalIDString(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
+
+func parseTime(value *timestamppb.Timestamp, name string) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, fmt.Errorf("%w: не задано %s", model.ErrValidation, name)
+	}
+	return value.AsTime(), nil
+}
+
+func toAnimal(animal model.Animal) *zoopb.Animal {
+	return &zoopb.Animal{
+		Id:   animal.ID.String(),
+		Name: animal.Name,
+		Species: &zoopb.Species{
+			AnimalType: animalTypes[animal.Species.AnimalType],
+			Name:       animal.Species.Name,
+		},
+		BirthDate:    timestamppb.New(animal.BirthDate),
+		EnclosureId:  optionalIDString(animal.EnclosureID),
+		HealthStatus: healthStatuses[animal.HealthStatus],
+		Gender:       genders[animal.Gender],
+		FavoriteFood: &zoopb.Food{
+			FoodType: foodTypes[animal.FavoriteFood.FoodType],
+			Name:     animal.FavoriteFood.Name,
+		},
+		Version: int32(animal.Version),
+	}
+}
+
+// fromAnimal - данные животного из запроса; ID и версию задаёт вызывающий
+func fromAnimal(animal *zoopb.Animal) (model.Animal, error) {
+	if animal == nil {
+		return model.Animal{}, fmt.Errorf("%w: не задано животное", model.ErrValidation)
+	}
+	var result model.Animal
+	var err error
+	result.Name = animal.GetName()
+	result.Species.Name = animal.GetSpecies().GetName()
+	if result.Species.AnimalType, err = fromEnum(animalTypes, animal.GetSpecies().GetAnimalType(), "species.animal_type"); err != nil {
+		return result, err
+	}
+	if result.BirthDate, err = parseTime(animal.GetBirthDate(), "birth_date"); err != nil {
+		return result, err
+	}
+	if result.EnclosureID, err = parseOptionalID(animal.GetEnclosureId(), "enclosure_id"); err != nil {
+		return result, err
+	}
+	if result.HealthStatus, err = fromEnum(healthStatuses, animal.GetHealthStatus(), "health_status"); err != nil {
+		return result, err
+	}
+	if result.Gender, err = fromEnum(genders, animal.GetGender(), "gender"); err != nil {
+		return result, err
+	}
+	result.FavoriteFood.Name = animal.GetFavoriteFood().GetName()
+	if result.FavoriteFood.FoodType, err = fromEnum(foodTypes, animal.GetFavoriteFood().GetFoodType(), "favorite_food.food_type"); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func toEnclosure(enclosure model.Enclosure) *zoopb.Enclosure {
+	return &zoopb.Enclosure{
+		Id:           enclosure.ID.String(),
+		Name:         enclosure.Name,
+		Type:         animalTypes[enclosure.Type],
+		Size:         toSize(enclosure.Size),
+		CurrentCount: int32(enclosure.CurrentCount),
+		MaxCapacity:  int32(enclosure.MaxCapacity),
+		AnimalIds:    idStrings(enclosure.AnimalsID),
+		Version:      int32(enclosure.Version),
+	}
+}
+
+func toSize(size model.Size) *zoopb.Size {
+	return &zoopb.Size{Length: int32(size.Lenght), Width: int32(size.Width), Height: int32(size.Height)}
+}
+
+func fromSize(size *zoopb.Size) model.Size {
+	return model.Size{Lenght: int(size.GetLength()), Width: int(size.GetWidth()), Height: int(size.GetHeight())}
+}
+
+func toSchedule(schedule model.FeedingSchedule) *zoopb.FeedingSchedule {
+	result := &zoopb.FeedingSchedule{
+		Id:          schedule.ID.String(),
+		AnimalId:    schedule.AnimalID.String(),
+		FeedingTime: timestamppb.New(schedule.FeedingTime),
+		FoodType:    foodTypes[schedule.FoodType],
+		Version:     int32(schedule.Version),
+	}
+	if schedule.DoneAt != nil {
+		result.DoneAt = timestamppb.New(*schedule.DoneAt)
+	}
+	return result
+}
+
+func toMovement(movement model.Movement) *zoopb.Movement {
+	return &zoopb.Movement{
+		Id:              movement.ID.String(),
+		AnimalId:        movement.AnimalID.String(),
+		FromEnclosureId: optionalIDString(movement.FromEnclosureID),
+		ToEnclosureId:   optionalIDString(movement.ToEnclosureID),
+		MovedAt:         timestamppb.New(movement.MovedAt),
+		Reason:          movement.Reason,
+		Actor:           movement.Actor,
+	}
+}
+
+func toTransferBatch(result *services.BatchTransferResult) *zoopb.TransferBatchResponse {
+	response := &zoopb.TransferBatchResponse{Valid: result.Valid, Applied: result.Applied}
+	for _, move := range result.Moves {
+		response.Moves = append(response.Moves, &zoopb.TransferMoveResult{
+			Move: &zoopb.TransferMove{
+				AnimalId:      move.AnimalID.String(),
+				ToEnclosureId: move.ToEnclosureID.String(),
+			},
+			Ok:    move.OK,
+			Error: move.Error,
+		})
+	}
+	return response
+}
+
+func toEvent(event model.ZooEvent) (*zoopb.Event, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &zoopb.Event{
+		Id:           event.ID,
+		Type:         string(event.Type),
+		At:           timestamppb.New(event.At),
+		Actor:        event.Actor,
+		AnimalId:     optionalIDString(event.AnimalID),
+		EnclosureIds: idStrings(event.EnclosureIDs),
+		Data:         data,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type enclosureServer struct {
+	zoopb.UnimplementedEnclosureServiceServer
+	repo    RP.IEnclosureRepository
+	service *services.EnclosureService
+}
+
+func (s *enclosureServer) ListEnclosures(ctx context.Context, req *zoopb.ListEnclosuresRequest) (*zoopb.ListEnclosuresResponse, error) {
+	var enclosures []model.Enclosure
+	var err error
+	if req.GetWithFreeSpace() {
+		enclosures, err = s.repo.FindWithAvailableSpace(1)
+	} else {
+		enclosures, err = s.repo.FindAll()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &zoopb.ListEnclosuresResponse{}
+	for _, enclosure := range enclosures {
+		if req.GetType() != zoopb.AnimalType_ANIMAL_TYPE_UNSPECIFIED && animalTypes[enclosure.Type] != req.GetType() {
+			continue
+		}
+		response.Enclosures = append(response.Enclosures, toEnclosure(enclosure))
+	}
+	return response, nil
+}
+
+func (s *enclosureServer) GetEnclosure(ctx context.Context, req *zoopb.GetEnclosureRequest) (*zoopb.Enclosure, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	enclosure, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return toEnclosure(*enclosure), nil
+}
+
+func (s *enclosureServer) CreateEnclosure(ctx context.Context, req *zoopb.CreateEnclosureRequest) (*zoopb.Enclosure, error) {
+	enclosureType, err := fromEnum(animalTypes, req.GetType(), "type")
+	if err != nil {
+		return nil, err
+	}
+	enclosure, err := s.service.AddEnclosure(ctx, req.GetName(), enclosureType, fromSize(req.GetSize()), int(req.GetMaxCapacity()))
+	if err != nil {
+		return nil, err
+	}
+	return toEnclosure(*enclosure), nil
+}
+
+func (s *enclosureServer) UpdateEnclosure(ctx context.Context, req *zoopb.UpdateEnclosureRequest) (*zoopb.Enclosure, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	enclosureType, err := fromEnum(animalTypes, req.GetType(), "type")
+	if err != nil {
+		return nil, err
+	}
+	enclosure, err := s.service.UpdateEnclosure(ctx, id, req.GetName(), enclosureType, fromSize(req.GetSize()), int(req.GetMaxCapacity()), int(req.GetExpectedVersion()))
+	if err != nil {
+		return nil, err
+	}
+	return toEnclosure(*enclosure), nil
+}
+
+func (s *enclosureServer) DeleteEnclosure(ctx context.Context, req *zoopb.DeleteEnclosureRequest) (*emptypb.Empty, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteEnclosure(ctx, id, int(req.GetExpectedVersion())); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
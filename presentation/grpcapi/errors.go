@@ -0,0 +1,57 @@
+package grpcapi
+
+import (
+	"errors"
+	"kpo-mini-dz2/domain/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCode - сопоставляет доменную ошибку с кодом gRPC, как errorStatus в controllers
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, model.ErrAnimalNotFound),
+		errors.Is(err, model.ErrEnclosureNotFound),
+		errors.Is(err, model.ErrScheduleNotFound),
+		errors.Is(err, model.ErrTransferNotFound),
+		errors.Is(err, model.ErrUserNotFound),
+		errors.Is(err, model.ErrWebhookNotFound),
+		errors.Is(err, model.ErrDeliveryNotFound),
+		errors.Is(err, model.ErrMedicalCaseNotFound),
+		errors.Is(err, model.ErrCarePlanNotFound):
+		return codes.NotFound
+	case errors.Is(err, model.ErrEnclosureFull),
+		errors.Is(err, model.ErrIncompatibleEnclosure),
+		errors.Is(err, model.ErrInvalidTransition),
+		errors.Is(err, model.ErrCircularParentage),
+		errors.Is(err, model.ErrFeedingAlreadyDone),
+		errors.Is(err, model.ErrAuditChainBroken),
+		errors.Is(err, model.ErrUserExists),
+		errors.Is(err, model.ErrEnclosureNameTaken),
+		errors.Is(err, model.ErrCarePlanExists):
+		return codes.FailedPrecondition
+	case errors.Is(err, model.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, model.ErrUnauthorized),
+		errors.Is(err, model.ErrInvalidCredentials):
+		return codes.Unauthenticated
+	case errors.Is(err, model.ErrVersionConflict):
+		return codes.Aborted
+	case errors.Is(err, model.ErrValidation):
+		return codes.InvalidArgument
+	default:
+		return codes.Internal
+	}
+}
+
+// toStatus - ошибка для ответа клиенту; уже готовый статус не трогаем
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(errorCode(err), err.Error())
+}
@@ -0,0 +1,76 @@
+package grpcapi
+
+import (
+	"fmt"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// truncatedEventType - как событие stream.truncated в потоке SSE
+const truncatedEventType = "stream.truncated"
+
+type eventServer struct {
+	zoopb.UnimplementedEventServiceServer
+	bus     *services.EventBus
+	closing <-chan struct{}
+}
+
+func (s *eventServer) Subscribe(req *zoopb.SubscribeRequest, stream grpc.ServerStreamingServer[zoopb.Event]) error {
+	var filter model.EventFilter
+	for _, name := range req.GetTypes() {
+		eventType := model.EventType(name)
+		if !eventType.IsValid() {
+			return fmt.Errorf("%w: неизвестный тип события %q", model.ErrValidation, name)
+		}
+		filter.Types = append(filter.Types, eventType)
+	}
+	enclosureID, err := parseOptionalID(req.GetEnclosureId(), "enclosure_id")
+	if err != nil {
+		return err
+	}
+	filter.EnclosureID = enclosureID
+
+	subscription, missed, truncated := s.bus.Subscribe(filter, req.GetLastEventId())
+	defer subscription.Close()
+
+	if truncated {
+		if err := stream.Send(&zoopb.Event{Type: truncatedEventType}); err != nil {
+			return err
+		}
+	}
+	for _, event := range missed {
+		if err := sendEvent(stream, event); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.closing:
+			return status.Error(codes.Unavailable, "сервер останавливается")
+		case event, ok := <-subscription.Events():
+			// Клиент не успевал читать - он переподключится с last_event_id
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "клиент не успевает читать события")
+			}
+			if err := sendEvent(stream, event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func sendEvent(stream grpc.ServerStreamingServer[zoopb.Event], event model.ZooEvent) error {
+	message, err := toEvent(event)
+	if err != nil {
+		return err
+	}
+	return stream.Send(message)
+}
@@ -0,0 +1,98 @@
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type feedingServer struct {
+	zoopb.UnimplementedFeedingServiceServer
+	service *services.FeedingService
+}
+
+func (s *feedingServer) ListSchedules(ctx context.Context, req *zoopb.ListSchedulesRequest) (*zoopb.ListSchedulesResponse, error) {
+	animalID, err := parseID(req.GetAnimalId(), "animal_id")
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.service.GetAnimalSchedules(animalID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &zoopb.ListSchedulesResponse{}
+	for _, schedule := range schedules {
+		response.Schedules = append(response.Schedules, toSchedule(schedule))
+	}
+	return response, nil
+}
+
+func (s *feedingServer) AddSchedule(ctx context.Context, req *zoopb.AddScheduleRequest) (*zoopb.FeedingSchedule, error) {
+	animalID, err := parseID(req.GetAnimalId(), "animal_id")
+	if err != nil {
+		return nil, err
+	}
+	feedingTime, err := parseTime(req.GetFeedingTime(), "feeding_time")
+	if err != nil {
+		return nil, err
+	}
+	foodType, err := fromEnum(foodTypes, req.GetFoodType(), "food_type")
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.service.AddFeedingSchedule(ctx, animalID, feedingTime, foodType)
+	if err != nil {
+		return nil, err
+	}
+	return toSchedule(*schedule), nil
+}
+
+func (s *feedingServer) UpdateSchedule(ctx context.Context, req *zoopb.UpdateScheduleRequest) (*zoopb.FeedingSchedule, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	feedingTime, err := parseTime(req.GetFeedingTime(), "feeding_time")
+	if err != nil {
+		return nil, err
+	}
+	foodType, err := fromEnum(foodTypes, req.GetFoodType(), "food_type")
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.service.UpdateFeedingSchedule(ctx, id, feedingTime, foodType, int(req.GetExpectedVersion()))
+	if err != nil {
+		return nil, err
+	}
+	return toSchedule(*schedule), nil
+}
+
+func (s *feedingServer) MarkDone(ctx context.Context, req *zoopb.MarkDoneRequest) (*zoopb.FeedingSchedule, error) {
+	id, err := parseID(req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.service.MarkFeedingDone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toSchedule(*schedule), nil
+}
+
+func (s *feedingServer) RemoveSchedule(ctx context.Context, req *zoopb.RemoveScheduleRequest) (*emptypb.Empty, error) {
+	animalID, err := parseID(req.GetAnimalId(), "animal_id")
+	if err != nil {
+		return nil, err
+	}
+	feedingTime, err := parseTime(req.GetFeedingTime(), "feeding_time")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.RemoveFeedingSchedule(ctx, animalID, feedingTime); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
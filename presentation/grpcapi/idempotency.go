@@ -0,0 +1,165 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+	"kpo-mini-dz2/presentation/middleware"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	idempotencyKeyKey     = "idempotency-key"
+	idempotentReplayedKey = "idempotent-replayed"
+)
+
+// idempotentMethods - изменяющие методы; повтор с теми же метаданными
+// idempotency-key получает первый ответ, как изменяющие запросы REST
+var idempotentMethods = map[string]bool{
+	zoopb.AnimalService_CreateAnimal_FullMethodName:       true,
+	zoopb.AnimalService_UpdateAnimal_FullMethodName:       true,
+	zoopb.AnimalService_DeleteAnimal_FullMethodName:       true,
+	zoopb.EnclosureService_CreateEnclosure_FullMethodName: true,
+	zoopb.EnclosureService_UpdateEnclosure_FullMethodName: true,
+	zoopb.EnclosureService_DeleteEnclosure_FullMethodName: true,
+	zoopb.FeedingService_AddSchedule_FullMethodName:       true,
+	zoopb.FeedingService_UpdateSchedule_FullMethodName:    true,
+	zoopb.FeedingService_MarkDone_FullMethodName:          true,
+	zoopb.FeedingService_RemoveSchedule_FullMethodName:    true,
+	zoopb.TransferService_TransferAnimal_FullMethodName:   true,
+	zoopb.TransferService_TransferBatch_FullMethodName:    true,
+}
+
+// idempotent - вызов с ключом из того же хранилища, что у REST и GraphQL.
+// Ответ сохраняется как сообщение protobuf, ошибка - как код и текст статуса;
+// ошибки сервера не сохраняются, чтобы вызов можно было повторить.
+func (i *interceptor) idempotent(ctx context.Context, req any, method string, handler grpc.UnaryHandler) (any, error) {
+	key := firstValue(ctx, idempotencyKeyKey)
+	message, ok := req.(proto.Message)
+	if key == "" || i.idempotency == nil || !idempotentMethods[method] || !ok {
+		return handler(ctx, req)
+	}
+
+	fingerprint, err := callFingerprint(method, message)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	record, existing, err := i.idempotency.Reserve(requestcontext.ActorFrom(ctx), key, fingerprint)
+	if errors.Is(err, middleware.ErrIdempotencyKeyTooLong) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return replayCall(ctx, method, *existing, fingerprint)
+	}
+
+	completed := false
+	defer func() {
+		// Паника или ошибка сервера - ключ освобождается
+		if !completed {
+			i.idempotency.Release(ctx, record.Key)
+		}
+	}()
+
+	resp, err := handler(ctx, req)
+	err = toStatus(err)
+	code := status.Code(err)
+	if isServerError(code) {
+		return resp, err
+	}
+	record.StatusCode = int(code)
+	record.Body = []byte(status.Convert(err).Message())
+	if err == nil {
+		body, marshalErr := proto.Marshal(resp.(proto.Message))
+		if marshalErr != nil {
+			// Ответ не сохранить - ключ освобождается, сам ответ клиент получит
+			return resp, nil
+		}
+		record.Body = body
+	}
+	i.idempotency.Complete(ctx, record)
+	completed = true
+	return resp, err
+}
+
+// replayCall - ответ на вызов, ключ которого уже занят, как middleware.Replay
+func replayCall(ctx context.Context, method string, record model.IdempotencyRecord, fingerprint string) (any, error) {
+	switch {
+	case record.Fingerprint != fingerprint:
+		return nil, status.Error(codes.InvalidArgument, "idempotency-key уже использован для другого запроса")
+	case !record.Completed:
+		return nil, status.Error(codes.Aborted, "запрос с этим idempotency-key ещё выполняется")
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedKey, "true"))
+	if code := codes.Code(record.StatusCode); code != codes.OK {
+		return nil, status.Error(code, string(record.Body))
+	}
+	resp, err := newResponse(method)
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(record.Body, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// newResponse - пустое сообщение ответа метода по описанию из zoo.proto
+func newResponse(method string) (proto.Message, error) {
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok {
+		return nil, fmt.Errorf("неизвестный метод %q", method)
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, err
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok || serviceDescriptor.Methods().ByName(protoreflect.Name(name)) == nil {
+		return nil, fmt.Errorf("неизвестный метод %q", method)
+	}
+	output := serviceDescriptor.Methods().ByName(protoreflect.Name(name)).Output().FullName()
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(output)
+	if err != nil {
+		return nil, err
+	}
+	return messageType.New().Interface(), nil
+}
+
+// callFingerprint - метод и сообщение запроса; Deterministic - одинаковые
+// запросы дают одинаковые байты
+func callFingerprint(method string, message proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write([]byte("grpc\n" + method + "\n"))
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isServerError - коды, которые в REST были бы ответами 5xx
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return true
+	}
+	return false
+}
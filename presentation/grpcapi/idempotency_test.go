@@ -0,0 +1,105 @@
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/infrastructure/repositories"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+	"kpo-mini-dz2/presentation/middleware"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestIdempotentCallReplaysFirstResponse(t *testing.T) {
+	i := &interceptor{idempotency: middleware.NewIdempotencyGuard(repositories.NewInMemoryIdempotencyRepository(), time.Hour)}
+	actor := requestcontext.Actor{ID: uuid.New(), Name: "curator1", Role: model.RoleCurator}
+	ctx := requestcontext.WithActor(metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyKey, "key-1")), actor)
+
+	calls := 0
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls++
+		if req.(*zoopb.CreateEnclosureRequest).MaxCapacity == 0 {
+			return nil, model.ErrValidation
+		}
+		return &zoopb.Enclosure{Id: uuid.NewString(), MaxCapacity: req.(*zoopb.CreateEnclosureRequest).MaxCapacity}, nil
+	}
+	call := func(ctx context.Context, req *zoopb.CreateEnclosureRequest) (any, error) {
+		return i.idempotent(ctx, req, zoopb.EnclosureService_CreateEnclosure_FullMethodName, handler)
+	}
+	req := &zoopb.CreateEnclosureRequest{Type: zoopb.AnimalType_ANIMAL_TYPE_PREDATOR, MaxCapacity: 4, Name: "North"}
+
+	first, err := call(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := call(ctx, proto.Clone(req).(*zoopb.CreateEnclosureRequest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || !proto.Equal(first.(proto.Message), replayed.(proto.Message)) {
+		t.Fatalf("replay = %v after %d calls, want %v after 1", replayed, calls, first)
+	}
+
+	req.Name = "South"
+	if _, err := call(ctx, req); status.Code(err) != codes.InvalidArgument || calls != 1 {
+		t.Fatalf("reused key error = %v after %d calls, want %s", err, calls, codes.InvalidArgument)
+	}
+
+	// Ошибка клиента тоже сохраняется и повторяется с тем же кодом
+	ctx = requestcontext.WithActor(metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyKey, "key-2")), actor)
+	invalid := &zoopb.CreateEnclosureRequest{Type: zoopb.AnimalType_ANIMAL_TYPE_PREDATOR}
+	for range 2 {
+		if _, err := call(ctx, invalid); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("invalid request error = %v, want %s", err, codes.InvalidArgument)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("handler calls = %d, want 2", calls)
+	}
+}
+
+func TestIdempotentCallSkipsReadsAndServerErrors(t *testing.T) {
+	i := &interceptor{idempotency: middleware.NewIdempotencyGuard(repositories.NewInMemoryIdempotencyRepository(), time.Hour)}
+	actor := requestcontext.Actor{ID: uuid.New(), Name: "curator1", Role: model.RoleCurator}
+	ctx := requestcontext.WithActor(metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyKeyKey, "key-1")), actor)
+
+	calls := 0
+	var handler grpc.UnaryHandler = func(ctx context.Context, req any) (any, error) {
+		calls++
+		return &zoopb.Enclosure{}, nil
+	}
+	for range 2 {
+		if _, err := i.idempotent(ctx, &zoopb.GetEnclosureRequest{Id: "1"}, zoopb.EnclosureService_GetEnclosure_FullMethodName, handler); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("read calls = %d, want 2: reads are not idempotent calls", calls)
+	}
+
+	failing := true
+	handler = func(ctx context.Context, req any) (any, error) {
+		calls++
+		if failing {
+			return nil, status.Error(codes.Unavailable, "хранилище недоступно")
+		}
+		return &emptypb.Empty{}, nil
+	}
+	method := zoopb.EnclosureService_DeleteEnclosure_FullMethodName
+	if _, err := i.idempotent(ctx, &zoopb.DeleteEnclosureRequest{Id: "1"}, method, handler); status.Code(err) != codes.Unavailable {
+		t.Fatalf("first call error = %v, want %s", err, codes.Unavailable)
+	}
+	failing = false
+	if _, err := i.idempotent(ctx, &zoopb.DeleteEnclosureRequest{Id: "1"}, method, handler); err != nil || calls != 4 {
+		t.Fatalf("retry after a server error = %v after %d calls, want success after 4", err, calls)
+	}
+}
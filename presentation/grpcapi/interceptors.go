@@ -0,0 +1,180 @@
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+	"kpo-mini-dz2/presentation/middleware"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDKey = "x-request-id"
+
+// maxRequestIDLength - как в middleware.RequestID
+const maxRequestIDLength = 128
+
+// methodPermissions - права на методы, как у маршрутов REST; метод, которого
+// здесь нет, не вызывается никем
+var methodPermissions = map[string][]model.Permission{
+	zoopb.AnimalService_ListAnimals_FullMethodName:   {model.PermRead},
+	zoopb.AnimalService_GetAnimal_FullMethodName:     {model.PermRead},
+	zoopb.AnimalService_CreateAnimal_FullMethodName:  {model.PermAdmitAnimals},
+	zoopb.AnimalService_UpdateAnimal_FullMethodName:  {model.PermManageAnimals},
+	zoopb.AnimalService_DeleteAnimal_FullMethodName:  {model.PermDeleteAnimals},
+	zoopb.AnimalService_ListMovements_FullMethodName: {model.PermRead},
+
+	zoopb.EnclosureService_ListEnclosures_FullMethodName:  {model.PermRead},
+	zoopb.EnclosureService_GetEnclosure_FullMethodName:    {model.PermRead},
+	zoopb.EnclosureService_CreateEnclosure_FullMethodName: {model.PermManageEnclosures},
+	zoopb.EnclosureService_UpdateEnclosure_FullMethodName: {model.PermManageEnclosures},
+	zoopb.EnclosureService_DeleteEnclosure_FullMethodName: {model.PermDeleteEnclosures},
+
+	zoopb.FeedingService_ListSchedules_FullMethodName:  {model.PermRead},
+	zoopb.FeedingService_AddSchedule_FullMethodName:    {model.PermManageFeeding},
+	zoopb.FeedingService_UpdateSchedule_FullMethodName: {model.PermManageFeeding},
+	zoopb.FeedingService_MarkDone_FullMethodName:       {model.PermManageFeeding},
+	zoopb.FeedingService_RemoveSchedule_FullMethodName: {model.PermManageFeeding},
+
+	zoopb.TransferService_TransferAnimal_FullMethodName: {model.PermMoveAnimals},
+	zoopb.TransferService_TransferBatch_FullMethodName:  {model.PermMoveAnimals},
+
+	zoopb.StatisticsService_GetStatistics_FullMethodName: {model.PermRead},
+
+	zoopb.EventService_Subscribe_FullMethodName: {model.PermRead},
+}
+
+// interceptor - то же, что цепочка middleware REST: ID запроса, пользователь
+// по токену, проверка прав, ключ идемпотентности, восстановление после паники
+// и запись в лог
+type interceptor struct {
+	authenticator middleware.Authenticator
+	idempotency   *middleware.IdempotencyGuard
+}
+
+func (i *interceptor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	ctx = withRequestID(ctx)
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "паника в обработчике gRPC", "method", info.FullMethod, "panic", r, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "внутренняя ошибка сервера")
+		}
+		logCall(ctx, info.FullMethod, err, start)
+	}()
+
+	if ctx, err = i.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	resp, err = i.idempotent(ctx, req, info.FullMethod, handler)
+	return resp, toStatus(err)
+}
+
+func (i *interceptor) stream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	ctx := withRequestID(stream.Context())
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "паника в обработчике gRPC", "method", info.FullMethod, "panic", r, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "внутренняя ошибка сервера")
+		}
+		logCall(ctx, info.FullMethod, err, start)
+	}()
+
+	if ctx, err = i.authorize(ctx, info.FullMethod); err != nil {
+		return err
+	}
+	return toStatus(handler(srv, &contextStream{ServerStream: stream, ctx: ctx}))
+}
+
+// authorize - проверяет токен из метаданных authorization и права на метод
+func (i *interceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	permissions, ok := methodPermissions[method]
+	if !ok {
+		return ctx, status.Error(codes.PermissionDenied, model.ErrForbidden.Error())
+	}
+
+	header := firstValue(ctx, "authorization")
+	if header == "" {
+		return ctx, status.Error(codes.Unauthenticated, model.ErrUnauthorized.Error())
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "ожидаются метаданные authorization: Bearer <token>")
+	}
+	actor, err := i.authenticator.Authenticate(token)
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = requestcontext.WithActor(ctx, actor)
+
+	for _, permission := range permissions {
+		if actor.Role.Can(permission) {
+			return ctx, nil
+		}
+	}
+	return ctx, status.Error(codes.PermissionDenied, model.ErrForbidden.Error())
+}
+
+// withRequestID - ID запроса из метаданных x-request-id или новый; возвращается
+// клиенту в заголовке ответа
+func withRequestID(ctx context.Context) context.Context {
+	requestID := firstValue(ctx, requestIDKey)
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+	return requestcontext.WithRequestID(ctx, requestID)
+}
+
+func firstValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// logCall - одна запись slog на вызов, как middleware.Logger
+func logCall(ctx context.Context, method string, err error, start time.Time) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	remote := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remote = p.Addr.String()
+	}
+	slog.LogAttrs(ctx, level, "grpc request",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("remote", remote),
+	)
+}
+
+// contextStream - поток с context, в который положены пользователь и ID запроса
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
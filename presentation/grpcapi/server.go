@@ -0,0 +1,79 @@
+// Package grpcapi - gRPC API зоопарка поверх тех же прикладных сервисов, что и REST;
+// описание сервисов - в zoopb/zoo.proto
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+	"kpo-mini-dz2/presentation/middleware"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// Services - зависимости gRPC API; чтение, как и в REST, идёт прямо в репозитории
+type Services struct {
+	AnimalRepo    RP.IAnimalRepository
+	EnclosureRepo RP.IEnclosureRepository
+	Animals       *services.AnimalService
+	Enclosures    *services.EnclosureService
+	Feeding       *services.FeedingService
+	Transfers     *services.AnimalTransferService
+	Movements     *services.MovementHistoryService
+	Statistics    *services.ZooStatisticsService
+	Events        *services.EventBus
+	// Idempotency - ключи Idempotency-Key, общие с REST и GraphQL
+	Idempotency *middleware.IdempotencyGuard
+}
+
+type Server struct {
+	server    *grpc.Server
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func NewServer(deps Services, authenticator middleware.Authenticator) *Server {
+	interceptor := &interceptor{authenticator: authenticator, idempotency: deps.Idempotency}
+	s := &Server{
+		server: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(interceptor.unary),
+			grpc.ChainStreamInterceptor(interceptor.stream),
+		),
+		closing: make(chan struct{}),
+	}
+
+	zoopb.RegisterAnimalServiceServer(s.server, &animalServer{repo: deps.AnimalRepo, service: deps.Animals, movements: deps.Movements})
+	zoopb.RegisterEnclosureServiceServer(s.server, &enclosureServer{repo: deps.EnclosureRepo, service: deps.Enclosures})
+	zoopb.RegisterFeedingServiceServer(s.server, &feedingServer{service: deps.Feeding})
+	zoopb.RegisterTransferServiceServer(s.server, &transferServer{service: deps.Transfers})
+	zoopb.RegisterStatisticsServiceServer(s.server, &statisticsServer{service: deps.Statistics})
+	zoopb.RegisterEventServiceServer(s.server, &eventServer{bus: deps.Events, closing: s.closing})
+	return s
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+// Shutdown - закрывает потоки событий и дожидается текущих вызовов;
+// если ctx истёк раньше, обрывает их
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.closeOnce.Do(func() { close(s.closing) })
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		<-stopped
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,54 @@
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+	"time"
+)
+
+const defaultTransferDays = 7
+
+type statisticsServer struct {
+	zoopb.UnimplementedStatisticsServiceServer
+	service *services.ZooStatisticsService
+}
+
+func (s *statisticsServer) GetStatistics(ctx context.Context, req *zoopb.GetStatisticsRequest) (*zoopb.Statistics, error) {
+	days := int(req.GetTransferDays())
+	if days == 0 {
+		days = defaultTransferDays
+	}
+
+	statistics, err := s.service.GetStatistics(time.Now(), days)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &zoopb.Statistics{
+		AnimalCount:      int32(statistics.AnimalCount),
+		EnclosureCount:   int32(statistics.EnclosureCount),
+		UpcomingFeedings: int32(statistics.Feedings.Upcoming),
+		MissedFeedings:   int32(statistics.Feedings.Missed),
+	}
+	for _, count := range statistics.AnimalsBySpecies {
+		response.AnimalsBySpecies = append(response.AnimalsBySpecies, &zoopb.SpeciesHealthCount{
+			Species:      count.Species,
+			HealthStatus: healthStatuses[count.HealthStatus],
+			Count:        int32(count.Count),
+		})
+	}
+	for _, enclosure := range statistics.Occupancy {
+		response.Occupancy = append(response.Occupancy, &zoopb.EnclosureOccupancy{
+			EnclosureId:  enclosure.EnclosureID.String(),
+			Type:         animalTypes[enclosure.Type],
+			CurrentCount: int32(enclosure.CurrentCount),
+			MaxCapacity:  int32(enclosure.MaxCapacity),
+			Ratio:        enclosure.Ratio,
+		})
+	}
+	for _, day := range statistics.TransfersPerDay {
+		response.TransfersPerDay = append(response.TransfersPerDay, &zoopb.DailyCount{Date: day.Date, Count: int32(day.Count)})
+	}
+	return response, nil
+}
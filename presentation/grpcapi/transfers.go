@@ -0,0 +1,50 @@
+package grpcapi
+
+import (
+	"context"
+	"kpo-mini-dz2/application/services"
+	"kpo-mini-dz2/presentation/grpcapi/zoopb"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type transferServer struct {
+	zoopb.UnimplementedTransferServiceServer
+	service *services.AnimalTransferService
+}
+
+func (s *transferServer) TransferAnimal(ctx context.Context, req *zoopb.TransferAnimalRequest) (*emptypb.Empty, error) {
+	animalID, err := parseID(req.GetAnimalId(), "animal_id")
+	if err != nil {
+		return nil, err
+	}
+	toEnclosureID, err := parseID(req.GetToEnclosureId(), "to_enclosure_id")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.TransferAnimal(ctx, animalID, toEnclosureID, req.GetReason()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *transferServer) TransferBatch(ctx context.Context, req *zoopb.TransferBatchRequest) (*zoopb.TransferBatchResponse, error) {
+	moves := make([]services.TransferMove, 0, len(req.GetMoves()))
+	for _, move := range req.GetMoves() {
+		animalID, err := parseID(move.GetAnimalId(), "moves.animal_id")
+		if err != nil {
+			return nil, err
+		}
+		toEnclosureID, err := parseID(move.GetToEnclosureId(), "moves.to_enclosure_id")
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, services.TransferMove{AnimalID: animalID, ToEnclosureID: toEnclosureID})
+	}
+
+	result, err := s.service.TransferBatch(ctx, moves, req.GetApply(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return toTransferBatch(result), nil
+}
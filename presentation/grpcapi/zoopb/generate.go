@@ -0,0 +1,4 @@
+// Package zoopb - код, сгенерированный из zoo.proto (protoc-gen-go, protoc-gen-go-grpc)
+package zoopb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative zoo.proto
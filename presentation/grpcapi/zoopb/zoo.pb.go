@@ -0,0 +1,3010 @@
+// gRPC API зоопарка. Те же прикладные сервисы, что и у REST API,
+// на отдельном порту (grpc.listenAddr). Токен передаётся в метаданных
+// "authorization: Bearer <token>", ID запроса - в "x-request-id".
+//
+// После правки файла перегенерировать код:
+//   go generate ./presentation/grpcapi/...
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: zoo.proto
+
+package zoopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AnimalType int32
+
+const (
+	AnimalType_ANIMAL_TYPE_UNSPECIFIED AnimalType = 0
+	AnimalType_ANIMAL_TYPE_PREDATOR    AnimalType = 1
+	AnimalType_ANIMAL_TYPE_HERBIVORE   AnimalType = 2
+	AnimalType_ANIMAL_TYPE_OMNIVORE    AnimalType = 3
+	AnimalType_ANIMAL_TYPE_AQUATIC     AnimalType = 4
+	AnimalType_ANIMAL_TYPE_AVIAN       AnimalType = 5
+)
+
+// Enum value maps for AnimalType.
+var (
+	AnimalType_name = map[int32]string{
+		0: "ANIMAL_TYPE_UNSPECIFIED",
+		1: "ANIMAL_TYPE_PREDATOR",
+		2: "ANIMAL_TYPE_HERBIVORE",
+		3: "ANIMAL_TYPE_OMNIVORE",
+		4: "ANIMAL_TYPE_AQUATIC",
+		5: "ANIMAL_TYPE_AVIAN",
+	}
+	AnimalType_value = map[string]int32{
+		"ANIMAL_TYPE_UNSPECIFIED": 0,
+		"ANIMAL_TYPE_PREDATOR":    1,
+		"ANIMAL_TYPE_HERBIVORE":   2,
+		"ANIMAL_TYPE_OMNIVORE":    3,
+		"ANIMAL_TYPE_AQUATIC":     4,
+		"ANIMAL_TYPE_AVIAN":       5,
+	}
+)
+
+func (x AnimalType) Enum() *AnimalType {
+	p := new(AnimalType)
+	*p = x
+	return p
+}
+
+func (x AnimalType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnimalType) Descriptor() protoreflect.EnumDescriptor {
+	return file_zoo_proto_enumTypes[0].Descriptor()
+}
+
+func (AnimalType) Type() protoreflect.EnumType {
+	return &file_zoo_proto_enumTypes[0]
+}
+
+func (x AnimalType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnimalType.Descriptor instead.
+func (AnimalType) EnumDescriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{0}
+}
+
+type FoodType int32
+
+const (
+	FoodType_FOOD_TYPE_UNSPECIFIED FoodType = 0
+	FoodType_FOOD_TYPE_MEAT        FoodType = 1
+	FoodType_FOOD_TYPE_GRASS       FoodType = 2
+	FoodType_FOOD_TYPE_FISH        FoodType = 3
+	FoodType_FOOD_TYPE_FRUIT       FoodType = 4
+	FoodType_FOOD_TYPE_VEGETABLE   FoodType = 5
+)
+
+// Enum value maps for FoodType.
+var (
+	FoodType_name = map[int32]string{
+		0: "FOOD_TYPE_UNSPECIFIED",
+		1: "FOOD_TYPE_MEAT",
+		2: "FOOD_TYPE_GRASS",
+		3: "FOOD_TYPE_FISH",
+		4: "FOOD_TYPE_FRUIT",
+		5: "FOOD_TYPE_VEGETABLE",
+	}
+	FoodType_value = map[string]int32{
+		"FOOD_TYPE_UNSPECIFIED": 0,
+		"FOOD_TYPE_MEAT":        1,
+		"FOOD_TYPE_GRASS":       2,
+		"FOOD_TYPE_FISH":        3,
+		"FOOD_TYPE_FRUIT":       4,
+		"FOOD_TYPE_VEGETABLE":   5,
+	}
+)
+
+func (x FoodType) Enum() *FoodType {
+	p := new(FoodType)
+	*p = x
+	return p
+}
+
+func (x FoodType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FoodType) Descriptor() protoreflect.EnumDescriptor {
+	return file_zoo_proto_enumTypes[1].Descriptor()
+}
+
+func (FoodType) Type() protoreflect.EnumType {
+	return &file_zoo_proto_enumTypes[1]
+}
+
+func (x FoodType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FoodType.Descriptor instead.
+func (FoodType) EnumDescriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{1}
+}
+
+type HealthStatus int32
+
+const (
+	HealthStatus_HEALTH_STATUS_UNSPECIFIED HealthStatus = 0
+	HealthStatus_HEALTH_STATUS_HEALTHY     HealthStatus = 1
+	HealthStatus_HEALTH_STATUS_SICK        HealthStatus = 2
+)
+
+// Enum value maps for HealthStatus.
+var (
+	HealthStatus_name = map[int32]string{
+		0: "HEALTH_STATUS_UNSPECIFIED",
+		1: "HEALTH_STATUS_HEALTHY",
+		2: "HEALTH_STATUS_SICK",
+	}
+	HealthStatus_value = map[string]int32{
+		"HEALTH_STATUS_UNSPECIFIED": 0,
+		"HEALTH_STATUS_HEALTHY":     1,
+		"HEALTH_STATUS_SICK":        2,
+	}
+)
+
+func (x HealthStatus) Enum() *HealthStatus {
+	p := new(HealthStatus)
+	*p = x
+	return p
+}
+
+func (x HealthStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HealthStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_zoo_proto_enumTypes[2].Descriptor()
+}
+
+func (HealthStatus) Type() protoreflect.EnumType {
+	return &file_zoo_proto_enumTypes[2]
+}
+
+func (x HealthStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HealthStatus.Descriptor instead.
+func (HealthStatus) EnumDescriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{2}
+}
+
+type Gender int32
+
+const (
+	Gender_GENDER_UNSPECIFIED Gender = 0
+	Gender_GENDER_MALE        Gender = 1
+	Gender_GENDER_FEMALE      Gender = 2
+)
+
+// Enum value maps for Gender.
+var (
+	Gender_name = map[int32]string{
+		0: "GENDER_UNSPECIFIED",
+		1: "GENDER_MALE",
+		2: "GENDER_FEMALE",
+	}
+	Gender_value = map[string]int32{
+		"GENDER_UNSPECIFIED": 0,
+		"GENDER_MALE":        1,
+		"GENDER_FEMALE":      2,
+	}
+)
+
+func (x Gender) Enum() *Gender {
+	p := new(Gender)
+	*p = x
+	return p
+}
+
+func (x Gender) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Gender) Descriptor() protoreflect.EnumDescriptor {
+	return file_zoo_proto_enumTypes[3].Descriptor()
+}
+
+func (Gender) Type() protoreflect.EnumType {
+	return &file_zoo_proto_enumTypes[3]
+}
+
+func (x Gender) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Gender.Descriptor instead.
+func (Gender) EnumDescriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{3}
+}
+
+type Species struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnimalType    AnimalType             `protobuf:"varint,1,opt,name=animal_type,json=animalType,proto3,enum=zoo.v1.AnimalType" json:"animal_type,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Species) Reset() {
+	*x = Species{}
+	mi := &file_zoo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Species) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Species) ProtoMessage() {}
+
+func (x *Species) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Species.ProtoReflect.Descriptor instead.
+func (*Species) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Species) GetAnimalType() AnimalType {
+	if x != nil {
+		return x.AnimalType
+	}
+	return AnimalType_ANIMAL_TYPE_UNSPECIFIED
+}
+
+func (x *Species) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Food struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FoodType      FoodType               `protobuf:"varint,1,opt,name=food_type,json=foodType,proto3,enum=zoo.v1.FoodType" json:"food_type,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Food) Reset() {
+	*x = Food{}
+	mi := &file_zoo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Food) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Food) ProtoMessage() {}
+
+func (x *Food) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Food.ProtoReflect.Descriptor instead.
+func (*Food) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Food) GetFoodType() FoodType {
+	if x != nil {
+		return x.FoodType
+	}
+	return FoodType_FOOD_TYPE_UNSPECIFIED
+}
+
+func (x *Food) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Size struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        int32                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Size) Reset() {
+	*x = Size{}
+	mi := &file_zoo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Size) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Size) ProtoMessage() {}
+
+func (x *Size) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Size.ProtoReflect.Descriptor instead.
+func (*Size) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Size) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Size) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Size) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// Animal - model.Animal
+type Animal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Species       *Species               `protobuf:"bytes,3,opt,name=species,proto3" json:"species,omitempty"`
+	BirthDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=birth_date,json=birthDate,proto3" json:"birth_date,omitempty"`
+	EnclosureId   string                 `protobuf:"bytes,5,opt,name=enclosure_id,json=enclosureId,proto3" json:"enclosure_id,omitempty"`
+	HealthStatus  HealthStatus           `protobuf:"varint,6,opt,name=health_status,json=healthStatus,proto3,enum=zoo.v1.HealthStatus" json:"health_status,omitempty"`
+	Gender        Gender                 `protobuf:"varint,7,opt,name=gender,proto3,enum=zoo.v1.Gender" json:"gender,omitempty"`
+	FavoriteFood  *Food                  `protobuf:"bytes,8,opt,name=favorite_food,json=favoriteFood,proto3" json:"favorite_food,omitempty"`
+	Version       int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Animal) Reset() {
+	*x = Animal{}
+	mi := &file_zoo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Animal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Animal) ProtoMessage() {}
+
+func (x *Animal) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Animal.ProtoReflect.Descriptor instead.
+func (*Animal) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Animal) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Animal) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Animal) GetSpecies() *Species {
+	if x != nil {
+		return x.Species
+	}
+	return nil
+}
+
+func (x *Animal) GetBirthDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BirthDate
+	}
+	return nil
+}
+
+func (x *Animal) GetEnclosureId() string {
+	if x != nil {
+		return x.EnclosureId
+	}
+	return ""
+}
+
+func (x *Animal) GetHealthStatus() HealthStatus {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return HealthStatus_HEALTH_STATUS_UNSPECIFIED
+}
+
+func (x *Animal) GetGender() Gender {
+	if x != nil {
+		return x.Gender
+	}
+	return Gender_GENDER_UNSPECIFIED
+}
+
+func (x *Animal) GetFavoriteFood() *Food {
+	if x != nil {
+		return x.FavoriteFood
+	}
+	return nil
+}
+
+func (x *Animal) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Enclosure - model.Enclosure
+type Enclosure struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type         AnimalType             `protobuf:"varint,2,opt,name=type,proto3,enum=zoo.v1.AnimalType" json:"type,omitempty"`
+	Size         *Size                  `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	CurrentCount int32                  `protobuf:"varint,4,opt,name=current_count,json=currentCount,proto3" json:"current_count,omitempty"`
+	MaxCapacity  int32                  `protobuf:"varint,5,opt,name=max_capacity,json=maxCapacity,proto3" json:"max_capacity,omitempty"`
+	AnimalIds    []string               `protobuf:"bytes,6,rep,name=animal_ids,json=animalIds,proto3" json:"animal_ids,omitempty"`
+	Version      int32                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	// Необязательное уникальное название
+	Name          string `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Enclosure) Reset() {
+	*x = Enclosure{}
+	mi := &file_zoo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Enclosure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Enclosure) ProtoMessage() {}
+
+func (x *Enclosure) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Enclosure.ProtoReflect.Descriptor instead.
+func (*Enclosure) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Enclosure) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Enclosure) GetType() AnimalType {
+	if x != nil {
+		return x.Type
+	}
+	return AnimalType_ANIMAL_TYPE_UNSPECIFIED
+}
+
+func (x *Enclosure) GetSize() *Size {
+	if x != nil {
+		return x.Size
+	}
+	return nil
+}
+
+func (x *Enclosure) GetCurrentCount() int32 {
+	if x != nil {
+		return x.CurrentCount
+	}
+	return 0
+}
+
+func (x *Enclosure) GetMaxCapacity() int32 {
+	if x != nil {
+		return x.MaxCapacity
+	}
+	return 0
+}
+
+func (x *Enclosure) GetAnimalIds() []string {
+	if x != nil {
+		return x.AnimalIds
+	}
+	return nil
+}
+
+func (x *Enclosure) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Enclosure) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// FeedingSchedule - model.FeedingSchedule
+type FeedingSchedule struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AnimalId    string                 `protobuf:"bytes,2,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	FeedingTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=feeding_time,json=feedingTime,proto3" json:"feeding_time,omitempty"`
+	FoodType    FoodType               `protobuf:"varint,4,opt,name=food_type,json=foodType,proto3,enum=zoo.v1.FoodType" json:"food_type,omitempty"`
+	// Не задано, пока кормление не выполнено
+	DoneAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=done_at,json=doneAt,proto3" json:"done_at,omitempty"`
+	Version       int32                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedingSchedule) Reset() {
+	*x = FeedingSchedule{}
+	mi := &file_zoo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedingSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedingSchedule) ProtoMessage() {}
+
+func (x *FeedingSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedingSchedule.ProtoReflect.Descriptor instead.
+func (*FeedingSchedule) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FeedingSchedule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FeedingSchedule) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+func (x *FeedingSchedule) GetFeedingTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FeedingTime
+	}
+	return nil
+}
+
+func (x *FeedingSchedule) GetFoodType() FoodType {
+	if x != nil {
+		return x.FoodType
+	}
+	return FoodType_FOOD_TYPE_UNSPECIFIED
+}
+
+func (x *FeedingSchedule) GetDoneAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DoneAt
+	}
+	return nil
+}
+
+func (x *FeedingSchedule) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Movement - model.Movement; пустой from_enclosure_id означает поступление, to_enclosure_id - выбытие
+type Movement struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AnimalId        string                 `protobuf:"bytes,2,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	FromEnclosureId string                 `protobuf:"bytes,3,opt,name=from_enclosure_id,json=fromEnclosureId,proto3" json:"from_enclosure_id,omitempty"`
+	ToEnclosureId   string                 `protobuf:"bytes,4,opt,name=to_enclosure_id,json=toEnclosureId,proto3" json:"to_enclosure_id,omitempty"`
+	MovedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=moved_at,json=movedAt,proto3" json:"moved_at,omitempty"`
+	Reason          string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor           string                 `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Movement) Reset() {
+	*x = Movement{}
+	mi := &file_zoo_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Movement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Movement) ProtoMessage() {}
+
+func (x *Movement) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Movement.ProtoReflect.Descriptor instead.
+func (*Movement) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Movement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Movement) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+func (x *Movement) GetFromEnclosureId() string {
+	if x != nil {
+		return x.FromEnclosureId
+	}
+	return ""
+}
+
+func (x *Movement) GetToEnclosureId() string {
+	if x != nil {
+		return x.ToEnclosureId
+	}
+	return ""
+}
+
+func (x *Movement) GetMovedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MovedAt
+	}
+	return nil
+}
+
+func (x *Movement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Movement) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// Пустые поля не ограничивают выборку
+type ListAnimalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnclosureId   string                 `protobuf:"bytes,1,opt,name=enclosure_id,json=enclosureId,proto3" json:"enclosure_id,omitempty"`
+	Species       string                 `protobuf:"bytes,2,opt,name=species,proto3" json:"species,omitempty"`
+	HealthStatus  HealthStatus           `protobuf:"varint,3,opt,name=health_status,json=healthStatus,proto3,enum=zoo.v1.HealthStatus" json:"health_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAnimalsRequest) Reset() {
+	*x = ListAnimalsRequest{}
+	mi := &file_zoo_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAnimalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnimalsRequest) ProtoMessage() {}
+
+func (x *ListAnimalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnimalsRequest.ProtoReflect.Descriptor instead.
+func (*ListAnimalsRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAnimalsRequest) GetEnclosureId() string {
+	if x != nil {
+		return x.EnclosureId
+	}
+	return ""
+}
+
+func (x *ListAnimalsRequest) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *ListAnimalsRequest) GetHealthStatus() HealthStatus {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return HealthStatus_HEALTH_STATUS_UNSPECIFIED
+}
+
+type ListAnimalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Animals       []*Animal              `protobuf:"bytes,1,rep,name=animals,proto3" json:"animals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAnimalsResponse) Reset() {
+	*x = ListAnimalsResponse{}
+	mi := &file_zoo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAnimalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnimalsResponse) ProtoMessage() {}
+
+func (x *ListAnimalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnimalsResponse.ProtoReflect.Descriptor instead.
+func (*ListAnimalsResponse) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAnimalsResponse) GetAnimals() []*Animal {
+	if x != nil {
+		return x.Animals
+	}
+	return nil
+}
+
+type GetAnimalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnimalRequest) Reset() {
+	*x = GetAnimalRequest{}
+	mi := &file_zoo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnimalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnimalRequest) ProtoMessage() {}
+
+func (x *GetAnimalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnimalRequest.ProtoReflect.Descriptor instead.
+func (*GetAnimalRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAnimalRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// id и version в animal игнорируются
+type CreateAnimalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Animal        *Animal                `protobuf:"bytes,1,opt,name=animal,proto3" json:"animal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAnimalRequest) Reset() {
+	*x = CreateAnimalRequest{}
+	mi := &file_zoo_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAnimalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAnimalRequest) ProtoMessage() {}
+
+func (x *CreateAnimalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAnimalRequest.ProtoReflect.Descriptor instead.
+func (*CreateAnimalRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateAnimalRequest) GetAnimal() *Animal {
+	if x != nil {
+		return x.Animal
+	}
+	return nil
+}
+
+// Поля животного заменяются целиком; enclosure_id меняется только через TransferService
+type UpdateAnimalRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Animal          *Animal                `protobuf:"bytes,1,opt,name=animal,proto3" json:"animal,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateAnimalRequest) Reset() {
+	*x = UpdateAnimalRequest{}
+	mi := &file_zoo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAnimalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAnimalRequest) ProtoMessage() {}
+
+func (x *UpdateAnimalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAnimalRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAnimalRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateAnimalRequest) GetAnimal() *Animal {
+	if x != nil {
+		return x.Animal
+	}
+	return nil
+}
+
+func (x *UpdateAnimalRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteAnimalRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteAnimalRequest) Reset() {
+	*x = DeleteAnimalRequest{}
+	mi := &file_zoo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAnimalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAnimalRequest) ProtoMessage() {}
+
+func (x *DeleteAnimalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAnimalRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAnimalRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteAnimalRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteAnimalRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ListMovementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnimalId      string                 `protobuf:"bytes,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsRequest) Reset() {
+	*x = ListMovementsRequest{}
+	mi := &file_zoo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsRequest) ProtoMessage() {}
+
+func (x *ListMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListMovementsRequest) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+type ListMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*Movement            `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMovementsResponse) Reset() {
+	*x = ListMovementsResponse{}
+	mi := &file_zoo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsResponse) ProtoMessage() {}
+
+func (x *ListMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListMovementsResponse) GetMovements() []*Movement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+type ListEnclosuresRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  AnimalType             `protobuf:"varint,1,opt,name=type,proto3,enum=zoo.v1.AnimalType" json:"type,omitempty"`
+	// Только вольеры со свободными местами
+	WithFreeSpace bool `protobuf:"varint,2,opt,name=with_free_space,json=withFreeSpace,proto3" json:"with_free_space,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnclosuresRequest) Reset() {
+	*x = ListEnclosuresRequest{}
+	mi := &file_zoo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnclosuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnclosuresRequest) ProtoMessage() {}
+
+func (x *ListEnclosuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnclosuresRequest.ProtoReflect.Descriptor instead.
+func (*ListEnclosuresRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListEnclosuresRequest) GetType() AnimalType {
+	if x != nil {
+		return x.Type
+	}
+	return AnimalType_ANIMAL_TYPE_UNSPECIFIED
+}
+
+func (x *ListEnclosuresRequest) GetWithFreeSpace() bool {
+	if x != nil {
+		return x.WithFreeSpace
+	}
+	return false
+}
+
+type ListEnclosuresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enclosures    []*Enclosure           `protobuf:"bytes,1,rep,name=enclosures,proto3" json:"enclosures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnclosuresResponse) Reset() {
+	*x = ListEnclosuresResponse{}
+	mi := &file_zoo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnclosuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnclosuresResponse) ProtoMessage() {}
+
+func (x *ListEnclosuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnclosuresResponse.ProtoReflect.Descriptor instead.
+func (*ListEnclosuresResponse) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListEnclosuresResponse) GetEnclosures() []*Enclosure {
+	if x != nil {
+		return x.Enclosures
+	}
+	return nil
+}
+
+type GetEnclosureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEnclosureRequest) Reset() {
+	*x = GetEnclosureRequest{}
+	mi := &file_zoo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEnclosureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnclosureRequest) ProtoMessage() {}
+
+func (x *GetEnclosureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEnclosureRequest.ProtoReflect.Descriptor instead.
+func (*GetEnclosureRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetEnclosureRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateEnclosureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          AnimalType             `protobuf:"varint,1,opt,name=type,proto3,enum=zoo.v1.AnimalType" json:"type,omitempty"`
+	Size          *Size                  `protobuf:"bytes,2,opt,name=size,proto3" json:"size,omitempty"`
+	MaxCapacity   int32                  `protobuf:"varint,3,opt,name=max_capacity,json=maxCapacity,proto3" json:"max_capacity,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEnclosureRequest) Reset() {
+	*x = CreateEnclosureRequest{}
+	mi := &file_zoo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEnclosureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEnclosureRequest) ProtoMessage() {}
+
+func (x *CreateEnclosureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEnclosureRequest.ProtoReflect.Descriptor instead.
+func (*CreateEnclosureRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateEnclosureRequest) GetType() AnimalType {
+	if x != nil {
+		return x.Type
+	}
+	return AnimalType_ANIMAL_TYPE_UNSPECIFIED
+}
+
+func (x *CreateEnclosureRequest) GetSize() *Size {
+	if x != nil {
+		return x.Size
+	}
+	return nil
+}
+
+func (x *CreateEnclosureRequest) GetMaxCapacity() int32 {
+	if x != nil {
+		return x.MaxCapacity
+	}
+	return 0
+}
+
+func (x *CreateEnclosureRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateEnclosureRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            AnimalType             `protobuf:"varint,2,opt,name=type,proto3,enum=zoo.v1.AnimalType" json:"type,omitempty"`
+	Size            *Size                  `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	MaxCapacity     int32                  `protobuf:"varint,4,opt,name=max_capacity,json=maxCapacity,proto3" json:"max_capacity,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	Name            string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateEnclosureRequest) Reset() {
+	*x = UpdateEnclosureRequest{}
+	mi := &file_zoo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEnclosureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEnclosureRequest) ProtoMessage() {}
+
+func (x *UpdateEnclosureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEnclosureRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEnclosureRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateEnclosureRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEnclosureRequest) GetType() AnimalType {
+	if x != nil {
+		return x.Type
+	}
+	return AnimalType_ANIMAL_TYPE_UNSPECIFIED
+}
+
+func (x *UpdateEnclosureRequest) GetSize() *Size {
+	if x != nil {
+		return x.Size
+	}
+	return nil
+}
+
+func (x *UpdateEnclosureRequest) GetMaxCapacity() int32 {
+	if x != nil {
+		return x.MaxCapacity
+	}
+	return 0
+}
+
+func (x *UpdateEnclosureRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateEnclosureRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteEnclosureRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteEnclosureRequest) Reset() {
+	*x = DeleteEnclosureRequest{}
+	mi := &file_zoo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEnclosureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEnclosureRequest) ProtoMessage() {}
+
+func (x *DeleteEnclosureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEnclosureRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEnclosureRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteEnclosureRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteEnclosureRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnimalId      string                 `protobuf:"bytes,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_zoo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListSchedulesRequest) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*FeedingSchedule     `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_zoo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*FeedingSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type AddScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnimalId      string                 `protobuf:"bytes,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	FeedingTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=feeding_time,json=feedingTime,proto3" json:"feeding_time,omitempty"`
+	FoodType      FoodType               `protobuf:"varint,3,opt,name=food_type,json=foodType,proto3,enum=zoo.v1.FoodType" json:"food_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddScheduleRequest) Reset() {
+	*x = AddScheduleRequest{}
+	mi := &file_zoo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddScheduleRequest) ProtoMessage() {}
+
+func (x *AddScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddScheduleRequest.ProtoReflect.Descriptor instead.
+func (*AddScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddScheduleRequest) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+func (x *AddScheduleRequest) GetFeedingTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FeedingTime
+	}
+	return nil
+}
+
+func (x *AddScheduleRequest) GetFoodType() FoodType {
+	if x != nil {
+		return x.FoodType
+	}
+	return FoodType_FOOD_TYPE_UNSPECIFIED
+}
+
+type UpdateScheduleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FeedingTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=feeding_time,json=feedingTime,proto3" json:"feeding_time,omitempty"`
+	FoodType        FoodType               `protobuf:"varint,3,opt,name=food_type,json=foodType,proto3,enum=zoo.v1.FoodType" json:"food_type,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateScheduleRequest) Reset() {
+	*x = UpdateScheduleRequest{}
+	mi := &file_zoo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetFeedingTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FeedingTime
+	}
+	return nil
+}
+
+func (x *UpdateScheduleRequest) GetFoodType() FoodType {
+	if x != nil {
+		return x.FoodType
+	}
+	return FoodType_FOOD_TYPE_UNSPECIFIED
+}
+
+func (x *UpdateScheduleRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type MarkDoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkDoneRequest) Reset() {
+	*x = MarkDoneRequest{}
+	mi := &file_zoo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkDoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkDoneRequest) ProtoMessage() {}
+
+func (x *MarkDoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkDoneRequest.ProtoReflect.Descriptor instead.
+func (*MarkDoneRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MarkDoneRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnimalId      string                 `protobuf:"bytes,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	FeedingTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=feeding_time,json=feedingTime,proto3" json:"feeding_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveScheduleRequest) Reset() {
+	*x = RemoveScheduleRequest{}
+	mi := &file_zoo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveScheduleRequest) ProtoMessage() {}
+
+func (x *RemoveScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveScheduleRequest.ProtoReflect.Descriptor instead.
+func (*RemoveScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveScheduleRequest) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+func (x *RemoveScheduleRequest) GetFeedingTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FeedingTime
+	}
+	return nil
+}
+
+type TransferAnimalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnimalId      string                 `protobuf:"bytes,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	ToEnclosureId string                 `protobuf:"bytes,2,opt,name=to_enclosure_id,json=toEnclosureId,proto3" json:"to_enclosure_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferAnimalRequest) Reset() {
+	*x = TransferAnimalRequest{}
+	mi := &file_zoo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferAnimalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferAnimalRequest) ProtoMessage() {}
+
+func (x *TransferAnimalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferAnimalRequest.ProtoReflect.Descriptor instead.
+func (*TransferAnimalRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TransferAnimalRequest) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+func (x *TransferAnimalRequest) GetToEnclosureId() string {
+	if x != nil {
+		return x.ToEnclosureId
+	}
+	return ""
+}
+
+func (x *TransferAnimalRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TransferMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnimalId      string                 `protobuf:"bytes,1,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	ToEnclosureId string                 `protobuf:"bytes,2,opt,name=to_enclosure_id,json=toEnclosureId,proto3" json:"to_enclosure_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferMove) Reset() {
+	*x = TransferMove{}
+	mi := &file_zoo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferMove) ProtoMessage() {}
+
+func (x *TransferMove) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferMove.ProtoReflect.Descriptor instead.
+func (*TransferMove) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TransferMove) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+func (x *TransferMove) GetToEnclosureId() string {
+	if x != nil {
+		return x.ToEnclosureId
+	}
+	return ""
+}
+
+type TransferBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Moves         []*TransferMove        `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+	Apply         bool                   `protobuf:"varint,2,opt,name=apply,proto3" json:"apply,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferBatchRequest) Reset() {
+	*x = TransferBatchRequest{}
+	mi := &file_zoo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchRequest) ProtoMessage() {}
+
+func (x *TransferBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchRequest.ProtoReflect.Descriptor instead.
+func (*TransferBatchRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TransferBatchRequest) GetMoves() []*TransferMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *TransferBatchRequest) GetApply() bool {
+	if x != nil {
+		return x.Apply
+	}
+	return false
+}
+
+func (x *TransferBatchRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TransferMoveResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Move          *TransferMove          `protobuf:"bytes,1,opt,name=move,proto3" json:"move,omitempty"`
+	Ok            bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferMoveResult) Reset() {
+	*x = TransferMoveResult{}
+	mi := &file_zoo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferMoveResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferMoveResult) ProtoMessage() {}
+
+func (x *TransferMoveResult) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferMoveResult.ProtoReflect.Descriptor instead.
+func (*TransferMoveResult) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TransferMoveResult) GetMove() *TransferMove {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+func (x *TransferMoveResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *TransferMoveResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type TransferBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Moves         []*TransferMoveResult  `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+	Valid         bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Applied       bool                   `protobuf:"varint,3,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferBatchResponse) Reset() {
+	*x = TransferBatchResponse{}
+	mi := &file_zoo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchResponse) ProtoMessage() {}
+
+func (x *TransferBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchResponse.ProtoReflect.Descriptor instead.
+func (*TransferBatchResponse) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TransferBatchResponse) GetMoves() []*TransferMoveResult {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *TransferBatchResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *TransferBatchResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type GetStatisticsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// За сколько дней считать перемещения; 0 - за неделю
+	TransferDays  int32 `protobuf:"varint,1,opt,name=transfer_days,json=transferDays,proto3" json:"transfer_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatisticsRequest) Reset() {
+	*x = GetStatisticsRequest{}
+	mi := &file_zoo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatisticsRequest) ProtoMessage() {}
+
+func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetStatisticsRequest) GetTransferDays() int32 {
+	if x != nil {
+		return x.TransferDays
+	}
+	return 0
+}
+
+type SpeciesHealthCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Species       string                 `protobuf:"bytes,1,opt,name=species,proto3" json:"species,omitempty"`
+	HealthStatus  HealthStatus           `protobuf:"varint,2,opt,name=health_status,json=healthStatus,proto3,enum=zoo.v1.HealthStatus" json:"health_status,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpeciesHealthCount) Reset() {
+	*x = SpeciesHealthCount{}
+	mi := &file_zoo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpeciesHealthCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpeciesHealthCount) ProtoMessage() {}
+
+func (x *SpeciesHealthCount) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpeciesHealthCount.ProtoReflect.Descriptor instead.
+func (*SpeciesHealthCount) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SpeciesHealthCount) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *SpeciesHealthCount) GetHealthStatus() HealthStatus {
+	if x != nil {
+		return x.HealthStatus
+	}
+	return HealthStatus_HEALTH_STATUS_UNSPECIFIED
+}
+
+func (x *SpeciesHealthCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type EnclosureOccupancy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EnclosureId   string                 `protobuf:"bytes,1,opt,name=enclosure_id,json=enclosureId,proto3" json:"enclosure_id,omitempty"`
+	Type          AnimalType             `protobuf:"varint,2,opt,name=type,proto3,enum=zoo.v1.AnimalType" json:"type,omitempty"`
+	CurrentCount  int32                  `protobuf:"varint,3,opt,name=current_count,json=currentCount,proto3" json:"current_count,omitempty"`
+	MaxCapacity   int32                  `protobuf:"varint,4,opt,name=max_capacity,json=maxCapacity,proto3" json:"max_capacity,omitempty"`
+	Ratio         float64                `protobuf:"fixed64,5,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnclosureOccupancy) Reset() {
+	*x = EnclosureOccupancy{}
+	mi := &file_zoo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnclosureOccupancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnclosureOccupancy) ProtoMessage() {}
+
+func (x *EnclosureOccupancy) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnclosureOccupancy.ProtoReflect.Descriptor instead.
+func (*EnclosureOccupancy) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *EnclosureOccupancy) GetEnclosureId() string {
+	if x != nil {
+		return x.EnclosureId
+	}
+	return ""
+}
+
+func (x *EnclosureOccupancy) GetType() AnimalType {
+	if x != nil {
+		return x.Type
+	}
+	return AnimalType_ANIMAL_TYPE_UNSPECIFIED
+}
+
+func (x *EnclosureOccupancy) GetCurrentCount() int32 {
+	if x != nil {
+		return x.CurrentCount
+	}
+	return 0
+}
+
+func (x *EnclosureOccupancy) GetMaxCapacity() int32 {
+	if x != nil {
+		return x.MaxCapacity
+	}
+	return 0
+}
+
+func (x *EnclosureOccupancy) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+type DailyCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyCount) Reset() {
+	*x = DailyCount{}
+	mi := &file_zoo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyCount) ProtoMessage() {}
+
+func (x *DailyCount) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyCount.ProtoReflect.Descriptor instead.
+func (*DailyCount) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DailyCount) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type Statistics struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AnimalCount      int32                  `protobuf:"varint,1,opt,name=animal_count,json=animalCount,proto3" json:"animal_count,omitempty"`
+	EnclosureCount   int32                  `protobuf:"varint,2,opt,name=enclosure_count,json=enclosureCount,proto3" json:"enclosure_count,omitempty"`
+	AnimalsBySpecies []*SpeciesHealthCount  `protobuf:"bytes,3,rep,name=animals_by_species,json=animalsBySpecies,proto3" json:"animals_by_species,omitempty"`
+	Occupancy        []*EnclosureOccupancy  `protobuf:"bytes,4,rep,name=occupancy,proto3" json:"occupancy,omitempty"`
+	UpcomingFeedings int32                  `protobuf:"varint,5,opt,name=upcoming_feedings,json=upcomingFeedings,proto3" json:"upcoming_feedings,omitempty"`
+	MissedFeedings   int32                  `protobuf:"varint,6,opt,name=missed_feedings,json=missedFeedings,proto3" json:"missed_feedings,omitempty"`
+	TransfersPerDay  []*DailyCount          `protobuf:"bytes,7,rep,name=transfers_per_day,json=transfersPerDay,proto3" json:"transfers_per_day,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Statistics) Reset() {
+	*x = Statistics{}
+	mi := &file_zoo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Statistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statistics) ProtoMessage() {}
+
+func (x *Statistics) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statistics.ProtoReflect.Descriptor instead.
+func (*Statistics) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Statistics) GetAnimalCount() int32 {
+	if x != nil {
+		return x.AnimalCount
+	}
+	return 0
+}
+
+func (x *Statistics) GetEnclosureCount() int32 {
+	if x != nil {
+		return x.EnclosureCount
+	}
+	return 0
+}
+
+func (x *Statistics) GetAnimalsBySpecies() []*SpeciesHealthCount {
+	if x != nil {
+		return x.AnimalsBySpecies
+	}
+	return nil
+}
+
+func (x *Statistics) GetOccupancy() []*EnclosureOccupancy {
+	if x != nil {
+		return x.Occupancy
+	}
+	return nil
+}
+
+func (x *Statistics) GetUpcomingFeedings() int32 {
+	if x != nil {
+		return x.UpcomingFeedings
+	}
+	return 0
+}
+
+func (x *Statistics) GetMissedFeedings() int32 {
+	if x != nil {
+		return x.MissedFeedings
+	}
+	return 0
+}
+
+func (x *Statistics) GetTransfersPerDay() []*DailyCount {
+	if x != nil {
+		return x.TransfersPerDay
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// animal.created, animal.deleted, animal.moved, animal.healthChanged, feeding.due, feeding.completed
+	Types       []string `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	EnclosureId string   `protobuf:"bytes,2,opt,name=enclosure_id,json=enclosureId,proto3" json:"enclosure_id,omitempty"`
+	// Продолжить после этого события из буфера последних событий
+	LastEventId   int64 `protobuf:"varint,3,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_zoo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubscribeRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetEnclosureId() string {
+	if x != nil {
+		return x.EnclosureId
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+// Event - model.ZooEvent. Если часть пропущенных событий уже вытеснена из буфера,
+// первым приходит событие с type = "stream.truncated" и id = 0.
+type Event struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type         string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	At           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	Actor        string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	AnimalId     string                 `protobuf:"bytes,5,opt,name=animal_id,json=animalId,proto3" json:"animal_id,omitempty"`
+	EnclosureIds []string               `protobuf:"bytes,6,rep,name=enclosure_ids,json=enclosureIds,proto3" json:"enclosure_ids,omitempty"`
+	// Данные события в JSON, как в потоке SSE
+	Data          []byte `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_zoo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_zoo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_zoo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *Event) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Event) GetAnimalId() string {
+	if x != nil {
+		return x.AnimalId
+	}
+	return ""
+}
+
+func (x *Event) GetEnclosureIds() []string {
+	if x != nil {
+		return x.EnclosureIds
+	}
+	return nil
+}
+
+func (x *Event) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_zoo_proto protoreflect.FileDescriptor
+
+const file_zoo_proto_rawDesc = "" +
+	"\n" +
+	"\tzoo.proto\x12\x06zoo.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"R\n" +
+	"\aSpecies\x123\n" +
+	"\vanimal_type\x18\x01 \x01(\x0e2\x12.zoo.v1.AnimalTypeR\n" +
+	"animalType\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"I\n" +
+	"\x04Food\x12-\n" +
+	"\tfood_type\x18\x01 \x01(\x0e2\x10.zoo.v1.FoodTypeR\bfoodType\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"L\n" +
+	"\x04Size\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x05R\x06length\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\"\xe5\x02\n" +
+	"\x06Animal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\aspecies\x18\x03 \x01(\v2\x0f.zoo.v1.SpeciesR\aspecies\x129\n" +
+	"\n" +
+	"birth_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tbirthDate\x12!\n" +
+	"\fenclosure_id\x18\x05 \x01(\tR\venclosureId\x129\n" +
+	"\rhealth_status\x18\x06 \x01(\x0e2\x14.zoo.v1.HealthStatusR\fhealthStatus\x12&\n" +
+	"\x06gender\x18\a \x01(\x0e2\x0e.zoo.v1.GenderR\x06gender\x121\n" +
+	"\rfavorite_food\x18\b \x01(\v2\f.zoo.v1.FoodR\ffavoriteFood\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\"\xfa\x01\n" +
+	"\tEnclosure\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x12.zoo.v1.AnimalTypeR\x04type\x12 \n" +
+	"\x04size\x18\x03 \x01(\v2\f.zoo.v1.SizeR\x04size\x12#\n" +
+	"\rcurrent_count\x18\x04 \x01(\x05R\fcurrentCount\x12!\n" +
+	"\fmax_capacity\x18\x05 \x01(\x05R\vmaxCapacity\x12\x1d\n" +
+	"\n" +
+	"animal_ids\x18\x06 \x03(\tR\tanimalIds\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\x12\x12\n" +
+	"\x04name\x18\b \x01(\tR\x04name\"\xfb\x01\n" +
+	"\x0fFeedingSchedule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tanimal_id\x18\x02 \x01(\tR\banimalId\x12=\n" +
+	"\ffeeding_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vfeedingTime\x12-\n" +
+	"\tfood_type\x18\x04 \x01(\x0e2\x10.zoo.v1.FoodTypeR\bfoodType\x123\n" +
+	"\adone_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06doneAt\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\"\xf0\x01\n" +
+	"\bMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tanimal_id\x18\x02 \x01(\tR\banimalId\x12*\n" +
+	"\x11from_enclosure_id\x18\x03 \x01(\tR\x0ffromEnclosureId\x12&\n" +
+	"\x0fto_enclosure_id\x18\x04 \x01(\tR\rtoEnclosureId\x125\n" +
+	"\bmoved_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\amovedAt\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\"\x8c\x01\n" +
+	"\x12ListAnimalsRequest\x12!\n" +
+	"\fenclosure_id\x18\x01 \x01(\tR\venclosureId\x12\x18\n" +
+	"\aspecies\x18\x02 \x01(\tR\aspecies\x129\n" +
+	"\rhealth_status\x18\x03 \x01(\x0e2\x14.zoo.v1.HealthStatusR\fhealthStatus\"?\n" +
+	"\x13ListAnimalsResponse\x12(\n" +
+	"\aanimals\x18\x01 \x03(\v2\x0e.zoo.v1.AnimalR\aanimals\"\"\n" +
+	"\x10GetAnimalRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x13CreateAnimalRequest\x12&\n" +
+	"\x06animal\x18\x01 \x01(\v2\x0e.zoo.v1.AnimalR\x06animal\"h\n" +
+	"\x13UpdateAnimalRequest\x12&\n" +
+	"\x06animal\x18\x01 \x01(\v2\x0e.zoo.v1.AnimalR\x06animal\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion\"P\n" +
+	"\x13DeleteAnimalRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion\"3\n" +
+	"\x14ListMovementsRequest\x12\x1b\n" +
+	"\tanimal_id\x18\x01 \x01(\tR\banimalId\"G\n" +
+	"\x15ListMovementsResponse\x12.\n" +
+	"\tmovements\x18\x01 \x03(\v2\x10.zoo.v1.MovementR\tmovements\"g\n" +
+	"\x15ListEnclosuresRequest\x12&\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x12.zoo.v1.AnimalTypeR\x04type\x12&\n" +
+	"\x0fwith_free_space\x18\x02 \x01(\bR\rwithFreeSpace\"K\n" +
+	"\x16ListEnclosuresResponse\x121\n" +
+	"\n" +
+	"enclosures\x18\x01 \x03(\v2\x11.zoo.v1.EnclosureR\n" +
+	"enclosures\"%\n" +
+	"\x13GetEnclosureRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x99\x01\n" +
+	"\x16CreateEnclosureRequest\x12&\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x12.zoo.v1.AnimalTypeR\x04type\x12 \n" +
+	"\x04size\x18\x02 \x01(\v2\f.zoo.v1.SizeR\x04size\x12!\n" +
+	"\fmax_capacity\x18\x03 \x01(\x05R\vmaxCapacity\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\"\xd4\x01\n" +
+	"\x16UpdateEnclosureRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x12.zoo.v1.AnimalTypeR\x04type\x12 \n" +
+	"\x04size\x18\x03 \x01(\v2\f.zoo.v1.SizeR\x04size\x12!\n" +
+	"\fmax_capacity\x18\x04 \x01(\x05R\vmaxCapacity\x12)\n" +
+	"\x10expected_version\x18\x05 \x01(\x05R\x0fexpectedVersion\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"S\n" +
+	"\x16DeleteEnclosureRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion\"3\n" +
+	"\x14ListSchedulesRequest\x12\x1b\n" +
+	"\tanimal_id\x18\x01 \x01(\tR\banimalId\"N\n" +
+	"\x15ListSchedulesResponse\x125\n" +
+	"\tschedules\x18\x01 \x03(\v2\x17.zoo.v1.FeedingScheduleR\tschedules\"\x9f\x01\n" +
+	"\x12AddScheduleRequest\x12\x1b\n" +
+	"\tanimal_id\x18\x01 \x01(\tR\banimalId\x12=\n" +
+	"\ffeeding_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vfeedingTime\x12-\n" +
+	"\tfood_type\x18\x03 \x01(\x0e2\x10.zoo.v1.FoodTypeR\bfoodType\"\xc0\x01\n" +
+	"\x15UpdateScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\ffeeding_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vfeedingTime\x12-\n" +
+	"\tfood_type\x18\x03 \x01(\x0e2\x10.zoo.v1.FoodTypeR\bfoodType\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\x05R\x0fexpectedVersion\"!\n" +
+	"\x0fMarkDoneRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"s\n" +
+	"\x15RemoveScheduleRequest\x12\x1b\n" +
+	"\tanimal_id\x18\x01 \x01(\tR\banimalId\x12=\n" +
+	"\ffeeding_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vfeedingTime\"t\n" +
+	"\x15TransferAnimalRequest\x12\x1b\n" +
+	"\tanimal_id\x18\x01 \x01(\tR\banimalId\x12&\n" +
+	"\x0fto_enclosure_id\x18\x02 \x01(\tR\rtoEnclosureId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"S\n" +
+	"\fTransferMove\x12\x1b\n" +
+	"\tanimal_id\x18\x01 \x01(\tR\banimalId\x12&\n" +
+	"\x0fto_enclosure_id\x18\x02 \x01(\tR\rtoEnclosureId\"p\n" +
+	"\x14TransferBatchRequest\x12*\n" +
+	"\x05moves\x18\x01 \x03(\v2\x14.zoo.v1.TransferMoveR\x05moves\x12\x14\n" +
+	"\x05apply\x18\x02 \x01(\bR\x05apply\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"d\n" +
+	"\x12TransferMoveResult\x12(\n" +
+	"\x04move\x18\x01 \x01(\v2\x14.zoo.v1.TransferMoveR\x04move\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"y\n" +
+	"\x15TransferBatchResponse\x120\n" +
+	"\x05moves\x18\x01 \x03(\v2\x1a.zoo.v1.TransferMoveResultR\x05moves\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12\x18\n" +
+	"\aapplied\x18\x03 \x01(\bR\aapplied\";\n" +
+	"\x14GetStatisticsRequest\x12#\n" +
+	"\rtransfer_days\x18\x01 \x01(\x05R\ftransferDays\"\x7f\n" +
+	"\x12SpeciesHealthCount\x12\x18\n" +
+	"\aspecies\x18\x01 \x01(\tR\aspecies\x129\n" +
+	"\rhealth_status\x18\x02 \x01(\x0e2\x14.zoo.v1.HealthStatusR\fhealthStatus\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\xbd\x01\n" +
+	"\x12EnclosureOccupancy\x12!\n" +
+	"\fenclosure_id\x18\x01 \x01(\tR\venclosureId\x12&\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x12.zoo.v1.AnimalTypeR\x04type\x12#\n" +
+	"\rcurrent_count\x18\x03 \x01(\x05R\fcurrentCount\x12!\n" +
+	"\fmax_capacity\x18\x04 \x01(\x05R\vmaxCapacity\x12\x14\n" +
+	"\x05ratio\x18\x05 \x01(\x01R\x05ratio\"6\n" +
+	"\n" +
+	"DailyCount\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xf2\x02\n" +
+	"\n" +
+	"Statistics\x12!\n" +
+	"\fanimal_count\x18\x01 \x01(\x05R\vanimalCount\x12'\n" +
+	"\x0fenclosure_count\x18\x02 \x01(\x05R\x0eenclosureCount\x12H\n" +
+	"\x12animals_by_species\x18\x03 \x03(\v2\x1a.zoo.v1.SpeciesHealthCountR\x10animalsBySpecies\x128\n" +
+	"\toccupancy\x18\x04 \x03(\v2\x1a.zoo.v1.EnclosureOccupancyR\toccupancy\x12+\n" +
+	"\x11upcoming_feedings\x18\x05 \x01(\x05R\x10upcomingFeedings\x12'\n" +
+	"\x0fmissed_feedings\x18\x06 \x01(\x05R\x0emissedFeedings\x12>\n" +
+	"\x11transfers_per_day\x18\a \x03(\v2\x12.zoo.v1.DailyCountR\x0ftransfersPerDay\"o\n" +
+	"\x10SubscribeRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12!\n" +
+	"\fenclosure_id\x18\x02 \x01(\tR\venclosureId\x12\"\n" +
+	"\rlast_event_id\x18\x03 \x01(\x03R\vlastEventId\"\xc3\x01\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12*\n" +
+	"\x02at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x1b\n" +
+	"\tanimal_id\x18\x05 \x01(\tR\banimalId\x12#\n" +
+	"\renclosure_ids\x18\x06 \x03(\tR\fenclosureIds\x12\x12\n" +
+	"\x04data\x18\a \x01(\fR\x04data*\xa8\x01\n" +
+	"\n" +
+	"AnimalType\x12\x1b\n" +
+	"\x17ANIMAL_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ANIMAL_TYPE_PREDATOR\x10\x01\x12\x19\n" +
+	"\x15ANIMAL_TYPE_HERBIVORE\x10\x02\x12\x18\n" +
+	"\x14ANIMAL_TYPE_OMNIVORE\x10\x03\x12\x17\n" +
+	"\x13ANIMAL_TYPE_AQUATIC\x10\x04\x12\x15\n" +
+	"\x11ANIMAL_TYPE_AVIAN\x10\x05*\x90\x01\n" +
+	"\bFoodType\x12\x19\n" +
+	"\x15FOOD_TYPE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eFOOD_TYPE_MEAT\x10\x01\x12\x13\n" +
+	"\x0fFOOD_TYPE_GRASS\x10\x02\x12\x12\n" +
+	"\x0eFOOD_TYPE_FISH\x10\x03\x12\x13\n" +
+	"\x0fFOOD_TYPE_FRUIT\x10\x04\x12\x17\n" +
+	"\x13FOOD_TYPE_VEGETABLE\x10\x05*`\n" +
+	"\fHealthStatus\x12\x1d\n" +
+	"\x19HEALTH_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15HEALTH_STATUS_HEALTHY\x10\x01\x12\x16\n" +
+	"\x12HEALTH_STATUS_SICK\x10\x02*D\n" +
+	"\x06Gender\x12\x16\n" +
+	"\x12GENDER_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vGENDER_MALE\x10\x01\x12\x11\n" +
+	"\rGENDER_FEMALE\x10\x022\x9b\x03\n" +
+	"\rAnimalService\x12F\n" +
+	"\vListAnimals\x12\x1a.zoo.v1.ListAnimalsRequest\x1a\x1b.zoo.v1.ListAnimalsResponse\x125\n" +
+	"\tGetAnimal\x12\x18.zoo.v1.GetAnimalRequest\x1a\x0e.zoo.v1.Animal\x12;\n" +
+	"\fCreateAnimal\x12\x1b.zoo.v1.CreateAnimalRequest\x1a\x0e.zoo.v1.Animal\x12;\n" +
+	"\fUpdateAnimal\x12\x1b.zoo.v1.UpdateAnimalRequest\x1a\x0e.zoo.v1.Animal\x12C\n" +
+	"\fDeleteAnimal\x12\x1b.zoo.v1.DeleteAnimalRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\rListMovements\x12\x1c.zoo.v1.ListMovementsRequest\x1a\x1d.zoo.v1.ListMovementsResponse2\xfa\x02\n" +
+	"\x10EnclosureService\x12O\n" +
+	"\x0eListEnclosures\x12\x1d.zoo.v1.ListEnclosuresRequest\x1a\x1e.zoo.v1.ListEnclosuresResponse\x12>\n" +
+	"\fGetEnclosure\x12\x1b.zoo.v1.GetEnclosureRequest\x1a\x11.zoo.v1.Enclosure\x12D\n" +
+	"\x0fCreateEnclosure\x12\x1e.zoo.v1.CreateEnclosureRequest\x1a\x11.zoo.v1.Enclosure\x12D\n" +
+	"\x0fUpdateEnclosure\x12\x1e.zoo.v1.UpdateEnclosureRequest\x1a\x11.zoo.v1.Enclosure\x12I\n" +
+	"\x0fDeleteEnclosure\x12\x1e.zoo.v1.DeleteEnclosureRequest\x1a\x16.google.protobuf.Empty2\xf3\x02\n" +
+	"\x0eFeedingService\x12L\n" +
+	"\rListSchedules\x12\x1c.zoo.v1.ListSchedulesRequest\x1a\x1d.zoo.v1.ListSchedulesResponse\x12B\n" +
+	"\vAddSchedule\x12\x1a.zoo.v1.AddScheduleRequest\x1a\x17.zoo.v1.FeedingSchedule\x12H\n" +
+	"\x0eUpdateSchedule\x12\x1d.zoo.v1.UpdateScheduleRequest\x1a\x17.zoo.v1.FeedingSchedule\x12<\n" +
+	"\bMarkDone\x12\x17.zoo.v1.MarkDoneRequest\x1a\x17.zoo.v1.FeedingSchedule\x12G\n" +
+	"\x0eRemoveSchedule\x12\x1d.zoo.v1.RemoveScheduleRequest\x1a\x16.google.protobuf.Empty2\xa8\x01\n" +
+	"\x0fTransferService\x12G\n" +
+	"\x0eTransferAnimal\x12\x1d.zoo.v1.TransferAnimalRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\rTransferBatch\x12\x1c.zoo.v1.TransferBatchRequest\x1a\x1d.zoo.v1.TransferBatchResponse2V\n" +
+	"\x11StatisticsService\x12A\n" +
+	"\rGetStatistics\x12\x1c.zoo.v1.GetStatisticsRequest\x1a\x12.zoo.v1.Statistics2F\n" +
+	"\fEventService\x126\n" +
+	"\tSubscribe\x12\x18.zoo.v1.SubscribeRequest\x1a\r.zoo.v1.Event0\x01B)Z'kpo-mini-dz2/presentation/grpcapi/zoopbb\x06proto3"
+
+var (
+	file_zoo_proto_rawDescOnce sync.Once
+	file_zoo_proto_rawDescData []byte
+)
+
+func file_zoo_proto_rawDescGZIP() []byte {
+	file_zoo_proto_rawDescOnce.Do(func() {
+		file_zoo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_zoo_proto_rawDesc), len(file_zoo_proto_rawDesc)))
+	})
+	return file_zoo_proto_rawDescData
+}
+
+var file_zoo_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_zoo_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_zoo_proto_goTypes = []any{
+	(AnimalType)(0),                // 0: zoo.v1.AnimalType
+	(FoodType)(0),                  // 1: zoo.v1.FoodType
+	(HealthStatus)(0),              // 2: zoo.v1.HealthStatus
+	(Gender)(0),                    // 3: zoo.v1.Gender
+	(*Species)(nil),                // 4: zoo.v1.Species
+	(*Food)(nil),                   // 5: zoo.v1.Food
+	(*Size)(nil),                   // 6: zoo.v1.Size
+	(*Animal)(nil),                 // 7: zoo.v1.Animal
+	(*Enclosure)(nil),              // 8: zoo.v1.Enclosure
+	(*FeedingSchedule)(nil),        // 9: zoo.v1.FeedingSchedule
+	(*Movement)(nil),               // 10: zoo.v1.Movement
+	(*ListAnimalsRequest)(nil),     // 11: zoo.v1.ListAnimalsRequest
+	(*ListAnimalsResponse)(nil),    // 12: zoo.v1.ListAnimalsResponse
+	(*GetAnimalRequest)(nil),       // 13: zoo.v1.GetAnimalRequest
+	(*CreateAnimalRequest)(nil),    // 14: zoo.v1.CreateAnimalRequest
+	(*UpdateAnimalRequest)(nil),    // 15: zoo.v1.UpdateAnimalRequest
+	(*DeleteAnimalRequest)(nil),    // 16: zoo.v1.DeleteAnimalRequest
+	(*ListMovementsRequest)(nil),   // 17: zoo.v1.ListMovementsRequest
+	(*ListMovementsResponse)(nil),  // 18: zoo.v1.ListMovementsResponse
+	(*ListEnclosuresRequest)(nil),  // 19: zoo.v1.ListEnclosuresRequest
+	(*ListEnclosuresResponse)(nil), // 20: zoo.v1.ListEnclosuresResponse
+	(*GetEnclosureRequest)(nil),    // 21: zoo.v1.GetEnclosureRequest
+	(*CreateEnclosureRequest)(nil), // 22: zoo.v1.CreateEnclosureRequest
+	(*UpdateEnclosureRequest)(nil), // 23: zoo.v1.UpdateEnclosureRequest
+	(*DeleteEnclosureRequest)(nil), // 24: zoo.v1.DeleteEnclosureRequest
+	(*ListSchedulesRequest)(nil),   // 25: zoo.v1.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),  // 26: zoo.v1.ListSchedulesResponse
+	(*AddScheduleRequest)(nil),     // 27: zoo.v1.AddScheduleRequest
+	(*UpdateScheduleRequest)(nil),  // 28: zoo.v1.UpdateScheduleRequest
+	(*MarkDoneRequest)(nil),        // 29: zoo.v1.MarkDoneRequest
+	(*RemoveScheduleRequest)(nil),  // 30: zoo.v1.RemoveScheduleRequest
+	(*TransferAnimalRequest)(nil),  // 31: zoo.v1.TransferAnimalRequest
+	(*TransferMove)(nil),           // 32: zoo.v1.TransferMove
+	(*TransferBatchRequest)(nil),   // 33: zoo.v1.TransferBatchRequest
+	(*TransferMoveResult)(nil),     // 34: zoo.v1.TransferMoveResult
+	(*TransferBatchResponse)(nil),  // 35: zoo.v1.TransferBatchResponse
+	(*GetStatisticsRequest)(nil),   // 36: zoo.v1.GetStatisticsRequest
+	(*SpeciesHealthCount)(nil),     // 37: zoo.v1.SpeciesHealthCount
+	(*EnclosureOccupancy)(nil),     // 38: zoo.v1.EnclosureOccupancy
+	(*DailyCount)(nil),             // 39: zoo.v1.DailyCount
+	(*Statistics)(nil),             // 40: zoo.v1.Statistics
+	(*SubscribeRequest)(nil),       // 41: zoo.v1.SubscribeRequest
+	(*Event)(nil),                  // 42: zoo.v1.Event
+	(*timestamppb.Timestamp)(nil),  // 43: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),          // 44: google.protobuf.Empty
+}
+var file_zoo_proto_depIdxs = []int32{
+	0,  // 0: zoo.v1.Species.animal_type:type_name -> zoo.v1.AnimalType
+	1,  // 1: zoo.v1.Food.food_type:type_name -> zoo.v1.FoodType
+	4,  // 2: zoo.v1.Animal.species:type_name -> zoo.v1.Species
+	43, // 3: zoo.v1.Animal.birth_date:type_name -> google.protobuf.Timestamp
+	2,  // 4: zoo.v1.Animal.health_status:type_name -> zoo.v1.HealthStatus
+	3,  // 5: zoo.v1.Animal.gender:type_name -> zoo.v1.Gender
+	5,  // 6: zoo.v1.Animal.favorite_food:type_name -> zoo.v1.Food
+	0,  // 7: zoo.v1.Enclosure.type:type_name -> zoo.v1.AnimalType
+	6,  // 8: zoo.v1.Enclosure.size:type_name -> zoo.v1.Size
+	43, // 9: zoo.v1.FeedingSchedule.feeding_time:type_name -> google.protobuf.Timestamp
+	1,  // 10: zoo.v1.FeedingSchedule.food_type:type_name -> zoo.v1.FoodType
+	43, // 11: zoo.v1.FeedingSchedule.done_at:type_name -> google.protobuf.Timestamp
+	43, // 12: zoo.v1.Movement.moved_at:type_name -> google.protobuf.Timestamp
+	2,  // 13: zoo.v1.ListAnimalsRequest.health_status:type_name -> zoo.v1.HealthStatus
+	7,  // 14: zoo.v1.ListAnimalsResponse.animals:type_name -> zoo.v1.Animal
+	7,  // 15: zoo.v1.CreateAnimalRequest.animal:type_name -> zoo.v1.Animal
+	7,  // 16: zoo.v1.UpdateAnimalRequest.animal:type_name -> zoo.v1.Animal
+	10, // 17: zoo.v1.ListMovementsResponse.movements:type_name -> zoo.v1.Movement
+	0,  // 18: zoo.v1.ListEnclosuresRequest.type:type_name -> zoo.v1.AnimalType
+	8,  // 19: zoo.v1.ListEnclosuresResponse.enclosures:type_name -> zoo.v1.Enclosure
+	0,  // 20: zoo.v1.CreateEnclosureRequest.type:type_name -> zoo.v1.AnimalType
+	6,  // 21: zoo.v1.CreateEnclosureRequest.size:type_name -> zoo.v1.Size
+	0,  // 22: zoo.v1.UpdateEnclosureRequest.type:type_name -> zoo.v1.AnimalType
+	6,  // 23: zoo.v1.UpdateEnclosureRequest.size:type_name -> zoo.v1.Size
+	9,  // 24: zoo.v1.ListSchedulesResponse.schedules:type_name -> zoo.v1.FeedingSchedule
+	43, // 25: zoo.v1.AddScheduleRequest.feeding_time:type_name -> google.protobuf.Timestamp
+	1,  // 26: zoo.v1.AddScheduleRequest.food_type:type_name -> zoo.v1.FoodType
+	43, // 27: zoo.v1.UpdateScheduleRequest.feeding_time:type_name -> google.protobuf.Timestamp
+	1,  // 28: zoo.v1.UpdateScheduleRequest.food_type:type_name -> zoo.v1.FoodType
+	43, // 29: zoo.v1.RemoveScheduleRequest.feeding_time:type_name -> google.protobuf.Timestamp
+	32, // 30: zoo.v1.TransferBatchRequest.moves:type_name -> zoo.v1.TransferMove
+	32, // 31: zoo.v1.TransferMoveResult.move:type_name -> zoo.v1.TransferMove
+	34, // 32: zoo.v1.TransferBatchResponse.moves:type_name -> zoo.v1.TransferMoveResult
+	2,  // 33: zoo.v1.SpeciesHealthCount.health_status:type_name -> zoo.v1.HealthStatus
+	0,  // 34: zoo.v1.EnclosureOccupancy.type:type_name -> zoo.v1.AnimalType
+	37, // 35: zoo.v1.Statistics.animals_by_species:type_name -> zoo.v1.SpeciesHealthCount
+	38, // 36: zoo.v1.Statistics.occupancy:type_name -> zoo.v1.EnclosureOccupancy
+	39, // 37: zoo.v1.Statistics.transfers_per_day:type_name -> zoo.v1.DailyCount
+	43, // 38: zoo.v1.Event.at:type_name -> google.protobuf.Timestamp
+	11, // 39: zoo.v1.AnimalService.ListAnimals:input_type -> zoo.v1.ListAnimalsRequest
+	13, // 40: zoo.v1.AnimalService.GetAnimal:input_type -> zoo.v1.GetAnimalRequest
+	14, // 41: zoo.v1.AnimalService.CreateAnimal:input_type -> zoo.v1.CreateAnimalRequest
+	15, // 42: zoo.v1.AnimalService.UpdateAnimal:input_type -> zoo.v1.UpdateAnimalRequest
+	16, // 43: zoo.v1.AnimalService.DeleteAnimal:input_type -> zoo.v1.DeleteAnimalRequest
+	17, // 44: zoo.v1.AnimalService.ListMovements:input_type -> zoo.v1.ListMovementsRequest
+	19, // 45: zoo.v1.EnclosureService.ListEnclosures:input_type -> zoo.v1.ListEnclosuresRequest
+	21, // 46: zoo.v1.EnclosureService.GetEnclosure:input_type -> zoo.v1.GetEnclosureRequest
+	22, // 47: zoo.v1.EnclosureService.CreateEnclosure:input_type -> zoo.v1.CreateEnclosureRequest
+	23, // 48: zoo.v1.EnclosureService.UpdateEnclosure:input_type -> zoo.v1.UpdateEnclosureRequest
+	24, // 49: zoo.v1.EnclosureService.DeleteEnclosure:input_type -> zoo.v1.DeleteEnclosureRequest
+	25, // 50: zoo.v1.FeedingService.ListSchedules:input_type -> zoo.v1.ListSchedulesRequest
+	27, // 51: zoo.v1.FeedingService.AddSchedule:input_type -> zoo.v1.AddScheduleRequest
+	28, // 52: zoo.v1.FeedingService.UpdateSchedule:input_type -> zoo.v1.UpdateScheduleRequest
+	29, // 53: zoo.v1.FeedingService.MarkDone:input_type -> zoo.v1.MarkDoneRequest
+	30, // 54: zoo.v1.FeedingService.RemoveSchedule:input_type -> zoo.v1.RemoveScheduleRequest
+	31, // 55: zoo.v1.TransferService.TransferAnimal:input_type -> zoo.v1.TransferAnimalRequest
+	33, // 56: zoo.v1.TransferService.TransferBatch:input_type -> zoo.v1.TransferBatchRequest
+	36, // 57: zoo.v1.StatisticsService.GetStatistics:input_type -> zoo.v1.GetStatisticsRequest
+	41, // 58: zoo.v1.EventService.Subscribe:input_type -> zoo.v1.SubscribeRequest
+	12, // 59: zoo.v1.AnimalService.ListAnimals:output_type -> zoo.v1.ListAnimalsResponse
+	7,  // 60: zoo.v1.AnimalService.GetAnimal:output_type -> zoo.v1.Animal
+	7,  // 61: zoo.v1.AnimalService.CreateAnimal:output_type -> zoo.v1.Animal
+	7,  // 62: zoo.v1.AnimalService.UpdateAnimal:output_type -> zoo.v1.Animal
+	44, // 63: zoo.v1.AnimalService.DeleteAnimal:output_type -> google.protobuf.Empty
+	18, // 64: zoo.v1.AnimalService.ListMovements:output_type -> zoo.v1.ListMovementsResponse
+	20, // 65: zoo.v1.EnclosureService.ListEnclosures:output_type -> zoo.v1.ListEnclosuresResponse
+	8,  // 66: zoo.v1.EnclosureService.GetEnclosure:output_type -> zoo.v1.Enclosure
+	8,  // 67: zoo.v1.EnclosureService.CreateEnclosure:output_type -> zoo.v1.Enclosure
+	8,  // 68: zoo.v1.EnclosureService.UpdateEnclosure:output_type -> zoo.v1.Enclosure
+	44, // 69: zoo.v1.EnclosureService.DeleteEnclosure:output_type -> google.protobuf.Empty
+	26, // 70: zoo.v1.FeedingService.ListSchedules:output_type -> zoo.v1.ListSchedulesResponse
+	9,  // 71: zoo.v1.FeedingService.AddSchedule:output_type -> zoo.v1.FeedingSchedule
+	9,  // 72: zoo.v1.FeedingService.UpdateSchedule:output_type -> zoo.v1.FeedingSchedule
+	9,  // 73: zoo.v1.FeedingService.MarkDone:output_type -> zoo.v1.FeedingSchedule
+	44, // 74: zoo.v1.FeedingService.RemoveSchedule:output_type -> google.protobuf.Empty
+	44, // 75: zoo.v1.TransferService.TransferAnimal:output_type -> google.protobuf.Empty
+	35, // 76: zoo.v1.TransferService.TransferBatch:output_type -> zoo.v1.TransferBatchResponse
+	40, // 77: zoo.v1.StatisticsService.GetStatistics:output_type -> zoo.v1.Statistics
+	42, // 78: zoo.v1.EventService.Subscribe:output_type -> zoo.v1.Event
+	59, // [59:79] is the sub-list for method output_type
+	39, // [39:59] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_zoo_proto_init() }
+func file_zoo_proto_init() {
+	if File_zoo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_zoo_proto_rawDesc), len(file_zoo_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   39,
+			NumExtensions: 0,
+			NumServices:   6,
+		},
+		GoTypes:           file_zoo_proto_goTypes,
+		DependencyIndexes: file_zoo_proto_depIdxs,
+		EnumInfos:         file_zoo_proto_enumTypes,
+		MessageInfos:      file_zoo_proto_msgTypes,
+	}.Build()
+	File_zoo_proto = out.File
+	file_zoo_proto_goTypes = nil
+	file_zoo_proto_depIdxs = nil
+}
@@ -0,0 +1,1180 @@
+// gRPC API зоопарка. Те же прикладные сервисы, что и у REST API,
+// на отдельном порту (grpc.listenAddr). Токен передаётся в метаданных
+// "authorization: Bearer <token>", ID запроса - в "x-request-id".
+//
+// После правки файла перегенерировать код:
+//   go generate ./presentation/grpcapi/...
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: zoo.proto
+
+package zoopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnimalService_ListAnimals_FullMethodName   = "/zoo.v1.AnimalService/ListAnimals"
+	AnimalService_GetAnimal_FullMethodName     = "/zoo.v1.AnimalService/GetAnimal"
+	AnimalService_CreateAnimal_FullMethodName  = "/zoo.v1.AnimalService/CreateAnimal"
+	AnimalService_UpdateAnimal_FullMethodName  = "/zoo.v1.AnimalService/UpdateAnimal"
+	AnimalService_DeleteAnimal_FullMethodName  = "/zoo.v1.AnimalService/DeleteAnimal"
+	AnimalService_ListMovements_FullMethodName = "/zoo.v1.AnimalService/ListMovements"
+)
+
+// AnimalServiceClient is the client API for AnimalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AnimalServiceClient interface {
+	ListAnimals(ctx context.Context, in *ListAnimalsRequest, opts ...grpc.CallOption) (*ListAnimalsResponse, error)
+	GetAnimal(ctx context.Context, in *GetAnimalRequest, opts ...grpc.CallOption) (*Animal, error)
+	CreateAnimal(ctx context.Context, in *CreateAnimalRequest, opts ...grpc.CallOption) (*Animal, error)
+	UpdateAnimal(ctx context.Context, in *UpdateAnimalRequest, opts ...grpc.CallOption) (*Animal, error)
+	DeleteAnimal(ctx context.Context, in *DeleteAnimalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error)
+}
+
+type animalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnimalServiceClient(cc grpc.ClientConnInterface) AnimalServiceClient {
+	return &animalServiceClient{cc}
+}
+
+func (c *animalServiceClient) ListAnimals(ctx context.Context, in *ListAnimalsRequest, opts ...grpc.CallOption) (*ListAnimalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAnimalsResponse)
+	err := c.cc.Invoke(ctx, AnimalService_ListAnimals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animalServiceClient) GetAnimal(ctx context.Context, in *GetAnimalRequest, opts ...grpc.CallOption) (*Animal, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Animal)
+	err := c.cc.Invoke(ctx, AnimalService_GetAnimal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animalServiceClient) CreateAnimal(ctx context.Context, in *CreateAnimalRequest, opts ...grpc.CallOption) (*Animal, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Animal)
+	err := c.cc.Invoke(ctx, AnimalService_CreateAnimal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animalServiceClient) UpdateAnimal(ctx context.Context, in *UpdateAnimalRequest, opts ...grpc.CallOption) (*Animal, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Animal)
+	err := c.cc.Invoke(ctx, AnimalService_UpdateAnimal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animalServiceClient) DeleteAnimal(ctx context.Context, in *DeleteAnimalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AnimalService_DeleteAnimal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *animalServiceClient) ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMovementsResponse)
+	err := c.cc.Invoke(ctx, AnimalService_ListMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnimalServiceServer is the server API for AnimalService service.
+// All implementations must embed UnimplementedAnimalServiceServer
+// for forward compatibility.
+type AnimalServiceServer interface {
+	ListAnimals(context.Context, *ListAnimalsRequest) (*ListAnimalsResponse, error)
+	GetAnimal(context.Context, *GetAnimalRequest) (*Animal, error)
+	CreateAnimal(context.Context, *CreateAnimalRequest) (*Animal, error)
+	UpdateAnimal(context.Context, *UpdateAnimalRequest) (*Animal, error)
+	DeleteAnimal(context.Context, *DeleteAnimalRequest) (*emptypb.Empty, error)
+	ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error)
+	mustEmbedUnimplementedAnimalServiceServer()
+}
+
+// UnimplementedAnimalServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnimalServiceServer struct{}
+
+func (UnimplementedAnimalServiceServer) ListAnimals(context.Context, *ListAnimalsRequest) (*ListAnimalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAnimals not implemented")
+}
+func (UnimplementedAnimalServiceServer) GetAnimal(context.Context, *GetAnimalRequest) (*Animal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnimal not implemented")
+}
+func (UnimplementedAnimalServiceServer) CreateAnimal(context.Context, *CreateAnimalRequest) (*Animal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAnimal not implemented")
+}
+func (UnimplementedAnimalServiceServer) UpdateAnimal(context.Context, *UpdateAnimalRequest) (*Animal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnimal not implemented")
+}
+func (UnimplementedAnimalServiceServer) DeleteAnimal(context.Context, *DeleteAnimalRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAnimal not implemented")
+}
+func (UnimplementedAnimalServiceServer) ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMovements not implemented")
+}
+func (UnimplementedAnimalServiceServer) mustEmbedUnimplementedAnimalServiceServer() {}
+func (UnimplementedAnimalServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeAnimalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnimalServiceServer will
+// result in compilation errors.
+type UnsafeAnimalServiceServer interface {
+	mustEmbedUnimplementedAnimalServiceServer()
+}
+
+func RegisterAnimalServiceServer(s grpc.ServiceRegistrar, srv AnimalServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnimalServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnimalService_ServiceDesc, srv)
+}
+
+func _AnimalService_ListAnimals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAnimalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).ListAnimals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_ListAnimals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).ListAnimals(ctx, req.(*ListAnimalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimalService_GetAnimal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnimalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).GetAnimal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_GetAnimal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).GetAnimal(ctx, req.(*GetAnimalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimalService_CreateAnimal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAnimalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).CreateAnimal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_CreateAnimal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).CreateAnimal(ctx, req.(*CreateAnimalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimalService_UpdateAnimal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAnimalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).UpdateAnimal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_UpdateAnimal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).UpdateAnimal(ctx, req.(*UpdateAnimalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimalService_DeleteAnimal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAnimalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).DeleteAnimal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_DeleteAnimal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).DeleteAnimal(ctx, req.(*DeleteAnimalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnimalService_ListMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnimalServiceServer).ListMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnimalService_ListMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnimalServiceServer).ListMovements(ctx, req.(*ListMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnimalService_ServiceDesc is the grpc.ServiceDesc for AnimalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnimalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zoo.v1.AnimalService",
+	HandlerType: (*AnimalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAnimals",
+			Handler:    _AnimalService_ListAnimals_Handler,
+		},
+		{
+			MethodName: "GetAnimal",
+			Handler:    _AnimalService_GetAnimal_Handler,
+		},
+		{
+			MethodName: "CreateAnimal",
+			Handler:    _AnimalService_CreateAnimal_Handler,
+		},
+		{
+			MethodName: "UpdateAnimal",
+			Handler:    _AnimalService_UpdateAnimal_Handler,
+		},
+		{
+			MethodName: "DeleteAnimal",
+			Handler:    _AnimalService_DeleteAnimal_Handler,
+		},
+		{
+			MethodName: "ListMovements",
+			Handler:    _AnimalService_ListMovements_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "zoo.proto",
+}
+
+const (
+	EnclosureService_ListEnclosures_FullMethodName  = "/zoo.v1.EnclosureService/ListEnclosures"
+	EnclosureService_GetEnclosure_FullMethodName    = "/zoo.v1.EnclosureService/GetEnclosure"
+	EnclosureService_CreateEnclosure_FullMethodName = "/zoo.v1.EnclosureService/CreateEnclosure"
+	EnclosureService_UpdateEnclosure_FullMethodName = "/zoo.v1.EnclosureService/UpdateEnclosure"
+	EnclosureService_DeleteEnclosure_FullMethodName = "/zoo.v1.EnclosureService/DeleteEnclosure"
+)
+
+// EnclosureServiceClient is the client API for EnclosureService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EnclosureServiceClient interface {
+	ListEnclosures(ctx context.Context, in *ListEnclosuresRequest, opts ...grpc.CallOption) (*ListEnclosuresResponse, error)
+	GetEnclosure(ctx context.Context, in *GetEnclosureRequest, opts ...grpc.CallOption) (*Enclosure, error)
+	CreateEnclosure(ctx context.Context, in *CreateEnclosureRequest, opts ...grpc.CallOption) (*Enclosure, error)
+	UpdateEnclosure(ctx context.Context, in *UpdateEnclosureRequest, opts ...grpc.CallOption) (*Enclosure, error)
+	DeleteEnclosure(ctx context.Context, in *DeleteEnclosureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type enclosureServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEnclosureServiceClient(cc grpc.ClientConnInterface) EnclosureServiceClient {
+	return &enclosureServiceClient{cc}
+}
+
+func (c *enclosureServiceClient) ListEnclosures(ctx context.Context, in *ListEnclosuresRequest, opts ...grpc.CallOption) (*ListEnclosuresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEnclosuresResponse)
+	err := c.cc.Invoke(ctx, EnclosureService_ListEnclosures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enclosureServiceClient) GetEnclosure(ctx context.Context, in *GetEnclosureRequest, opts ...grpc.CallOption) (*Enclosure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Enclosure)
+	err := c.cc.Invoke(ctx, EnclosureService_GetEnclosure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enclosureServiceClient) CreateEnclosure(ctx context.Context, in *CreateEnclosureRequest, opts ...grpc.CallOption) (*Enclosure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Enclosure)
+	err := c.cc.Invoke(ctx, EnclosureService_CreateEnclosure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enclosureServiceClient) UpdateEnclosure(ctx context.Context, in *UpdateEnclosureRequest, opts ...grpc.CallOption) (*Enclosure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Enclosure)
+	err := c.cc.Invoke(ctx, EnclosureService_UpdateEnclosure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enclosureServiceClient) DeleteEnclosure(ctx context.Context, in *DeleteEnclosureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, EnclosureService_DeleteEnclosure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EnclosureServiceServer is the server API for EnclosureService service.
+// All implementations must embed UnimplementedEnclosureServiceServer
+// for forward compatibility.
+type EnclosureServiceServer interface {
+	ListEnclosures(context.Context, *ListEnclosuresRequest) (*ListEnclosuresResponse, error)
+	GetEnclosure(context.Context, *GetEnclosureRequest) (*Enclosure, error)
+	CreateEnclosure(context.Context, *CreateEnclosureRequest) (*Enclosure, error)
+	UpdateEnclosure(context.Context, *UpdateEnclosureRequest) (*Enclosure, error)
+	DeleteEnclosure(context.Context, *DeleteEnclosureRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedEnclosureServiceServer()
+}
+
+// UnimplementedEnclosureServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEnclosureServiceServer struct{}
+
+func (UnimplementedEnclosureServiceServer) ListEnclosures(context.Context, *ListEnclosuresRequest) (*ListEnclosuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEnclosures not implemented")
+}
+func (UnimplementedEnclosureServiceServer) GetEnclosure(context.Context, *GetEnclosureRequest) (*Enclosure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnclosure not implemented")
+}
+func (UnimplementedEnclosureServiceServer) CreateEnclosure(context.Context, *CreateEnclosureRequest) (*Enclosure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEnclosure not implemented")
+}
+func (UnimplementedEnclosureServiceServer) UpdateEnclosure(context.Context, *UpdateEnclosureRequest) (*Enclosure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEnclosure not implemented")
+}
+func (UnimplementedEnclosureServiceServer) DeleteEnclosure(context.Context, *DeleteEnclosureRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEnclosure not implemented")
+}
+func (UnimplementedEnclosureServiceServer) mustEmbedUnimplementedEnclosureServiceServer() {}
+func (UnimplementedEnclosureServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeEnclosureServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EnclosureServiceServer will
+// result in compilation errors.
+type UnsafeEnclosureServiceServer interface {
+	mustEmbedUnimplementedEnclosureServiceServer()
+}
+
+func RegisterEnclosureServiceServer(s grpc.ServiceRegistrar, srv EnclosureServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEnclosureServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EnclosureService_ServiceDesc, srv)
+}
+
+func _EnclosureService_ListEnclosures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEnclosuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclosureServiceServer).ListEnclosures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnclosureService_ListEnclosures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclosureServiceServer).ListEnclosures(ctx, req.(*ListEnclosuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnclosureService_GetEnclosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnclosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclosureServiceServer).GetEnclosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnclosureService_GetEnclosure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclosureServiceServer).GetEnclosure(ctx, req.(*GetEnclosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnclosureService_CreateEnclosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEnclosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclosureServiceServer).CreateEnclosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnclosureService_CreateEnclosure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclosureServiceServer).CreateEnclosure(ctx, req.(*CreateEnclosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnclosureService_UpdateEnclosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEnclosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclosureServiceServer).UpdateEnclosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnclosureService_UpdateEnclosure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclosureServiceServer).UpdateEnclosure(ctx, req.(*UpdateEnclosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnclosureService_DeleteEnclosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEnclosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclosureServiceServer).DeleteEnclosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnclosureService_DeleteEnclosure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclosureServiceServer).DeleteEnclosure(ctx, req.(*DeleteEnclosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EnclosureService_ServiceDesc is the grpc.ServiceDesc for EnclosureService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EnclosureService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zoo.v1.EnclosureService",
+	HandlerType: (*EnclosureServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListEnclosures",
+			Handler:    _EnclosureService_ListEnclosures_Handler,
+		},
+		{
+			MethodName: "GetEnclosure",
+			Handler:    _EnclosureService_GetEnclosure_Handler,
+		},
+		{
+			MethodName: "CreateEnclosure",
+			Handler:    _EnclosureService_CreateEnclosure_Handler,
+		},
+		{
+			MethodName: "UpdateEnclosure",
+			Handler:    _EnclosureService_UpdateEnclosure_Handler,
+		},
+		{
+			MethodName: "DeleteEnclosure",
+			Handler:    _EnclosureService_DeleteEnclosure_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "zoo.proto",
+}
+
+const (
+	FeedingService_ListSchedules_FullMethodName  = "/zoo.v1.FeedingService/ListSchedules"
+	FeedingService_AddSchedule_FullMethodName    = "/zoo.v1.FeedingService/AddSchedule"
+	FeedingService_UpdateSchedule_FullMethodName = "/zoo.v1.FeedingService/UpdateSchedule"
+	FeedingService_MarkDone_FullMethodName       = "/zoo.v1.FeedingService/MarkDone"
+	FeedingService_RemoveSchedule_FullMethodName = "/zoo.v1.FeedingService/RemoveSchedule"
+)
+
+// FeedingServiceClient is the client API for FeedingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FeedingServiceClient interface {
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	AddSchedule(ctx context.Context, in *AddScheduleRequest, opts ...grpc.CallOption) (*FeedingSchedule, error)
+	UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*FeedingSchedule, error)
+	MarkDone(ctx context.Context, in *MarkDoneRequest, opts ...grpc.CallOption) (*FeedingSchedule, error)
+	RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type feedingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeedingServiceClient(cc grpc.ClientConnInterface) FeedingServiceClient {
+	return &feedingServiceClient{cc}
+}
+
+func (c *feedingServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, FeedingService_ListSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) AddSchedule(ctx context.Context, in *AddScheduleRequest, opts ...grpc.CallOption) (*FeedingSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedingSchedule)
+	err := c.cc.Invoke(ctx, FeedingService_AddSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*FeedingSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedingSchedule)
+	err := c.cc.Invoke(ctx, FeedingService_UpdateSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) MarkDone(ctx context.Context, in *MarkDoneRequest, opts ...grpc.CallOption) (*FeedingSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedingSchedule)
+	err := c.cc.Invoke(ctx, FeedingService_MarkDone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedingServiceClient) RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FeedingService_RemoveSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeedingServiceServer is the server API for FeedingService service.
+// All implementations must embed UnimplementedFeedingServiceServer
+// for forward compatibility.
+type FeedingServiceServer interface {
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	AddSchedule(context.Context, *AddScheduleRequest) (*FeedingSchedule, error)
+	UpdateSchedule(context.Context, *UpdateScheduleRequest) (*FeedingSchedule, error)
+	MarkDone(context.Context, *MarkDoneRequest) (*FeedingSchedule, error)
+	RemoveSchedule(context.Context, *RemoveScheduleRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedFeedingServiceServer()
+}
+
+// UnimplementedFeedingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFeedingServiceServer struct{}
+
+func (UnimplementedFeedingServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedFeedingServiceServer) AddSchedule(context.Context, *AddScheduleRequest) (*FeedingSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSchedule not implemented")
+}
+func (UnimplementedFeedingServiceServer) UpdateSchedule(context.Context, *UpdateScheduleRequest) (*FeedingSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSchedule not implemented")
+}
+func (UnimplementedFeedingServiceServer) MarkDone(context.Context, *MarkDoneRequest) (*FeedingSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkDone not implemented")
+}
+func (UnimplementedFeedingServiceServer) RemoveSchedule(context.Context, *RemoveScheduleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSchedule not implemented")
+}
+func (UnimplementedFeedingServiceServer) mustEmbedUnimplementedFeedingServiceServer() {}
+func (UnimplementedFeedingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeFeedingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeedingServiceServer will
+// result in compilation errors.
+type UnsafeFeedingServiceServer interface {
+	mustEmbedUnimplementedFeedingServiceServer()
+}
+
+func RegisterFeedingServiceServer(s grpc.ServiceRegistrar, srv FeedingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFeedingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FeedingService_ServiceDesc, srv)
+}
+
+func _FeedingService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_AddSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).AddSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_AddSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).AddSchedule(ctx, req.(*AddScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_UpdateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).UpdateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_UpdateSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).UpdateSchedule(ctx, req.(*UpdateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_MarkDone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkDoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).MarkDone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_MarkDone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).MarkDone(ctx, req.(*MarkDoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedingService_RemoveSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedingServiceServer).RemoveSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedingService_RemoveSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedingServiceServer).RemoveSchedule(ctx, req.(*RemoveScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FeedingService_ServiceDesc is the grpc.ServiceDesc for FeedingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeedingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zoo.v1.FeedingService",
+	HandlerType: (*FeedingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSchedules",
+			Handler:    _FeedingService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "AddSchedule",
+			Handler:    _FeedingService_AddSchedule_Handler,
+		},
+		{
+			MethodName: "UpdateSchedule",
+			Handler:    _FeedingService_UpdateSchedule_Handler,
+		},
+		{
+			MethodName: "MarkDone",
+			Handler:    _FeedingService_MarkDone_Handler,
+		},
+		{
+			MethodName: "RemoveSchedule",
+			Handler:    _FeedingService_RemoveSchedule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "zoo.proto",
+}
+
+const (
+	TransferService_TransferAnimal_FullMethodName = "/zoo.v1.TransferService/TransferAnimal"
+	TransferService_TransferBatch_FullMethodName  = "/zoo.v1.TransferService/TransferBatch"
+)
+
+// TransferServiceClient is the client API for TransferService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TransferServiceClient interface {
+	TransferAnimal(ctx context.Context, in *TransferAnimalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Проверяет перемещения по порядку; в заполненный вольер - только если следующие перемещения освобождают место.
+	// При apply применяет все или ни одного
+	TransferBatch(ctx context.Context, in *TransferBatchRequest, opts ...grpc.CallOption) (*TransferBatchResponse, error)
+}
+
+type transferServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransferServiceClient(cc grpc.ClientConnInterface) TransferServiceClient {
+	return &transferServiceClient{cc}
+}
+
+func (c *transferServiceClient) TransferAnimal(ctx context.Context, in *TransferAnimalRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TransferService_TransferAnimal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transferServiceClient) TransferBatch(ctx context.Context, in *TransferBatchRequest, opts ...grpc.CallOption) (*TransferBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferBatchResponse)
+	err := c.cc.Invoke(ctx, TransferService_TransferBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransferServiceServer is the server API for TransferService service.
+// All implementations must embed UnimplementedTransferServiceServer
+// for forward compatibility.
+type TransferServiceServer interface {
+	TransferAnimal(context.Context, *TransferAnimalRequest) (*emptypb.Empty, error)
+	// Проверяет перемещения по порядку; в заполненный вольер - только если следующие перемещения освобождают место.
+	// При apply применяет все или ни одного
+	TransferBatch(context.Context, *TransferBatchRequest) (*TransferBatchResponse, error)
+	mustEmbedUnimplementedTransferServiceServer()
+}
+
+// UnimplementedTransferServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTransferServiceServer struct{}
+
+func (UnimplementedTransferServiceServer) TransferAnimal(context.Context, *TransferAnimalRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferAnimal not implemented")
+}
+func (UnimplementedTransferServiceServer) TransferBatch(context.Context, *TransferBatchRequest) (*TransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferBatch not implemented")
+}
+func (UnimplementedTransferServiceServer) mustEmbedUnimplementedTransferServiceServer() {}
+func (UnimplementedTransferServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeTransferServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TransferServiceServer will
+// result in compilation errors.
+type UnsafeTransferServiceServer interface {
+	mustEmbedUnimplementedTransferServiceServer()
+}
+
+func RegisterTransferServiceServer(s grpc.ServiceRegistrar, srv TransferServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTransferServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TransferService_ServiceDesc, srv)
+}
+
+func _TransferService_TransferAnimal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferAnimalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).TransferAnimal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_TransferAnimal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).TransferAnimal(ctx, req.(*TransferAnimalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransferService_TransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServiceServer).TransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransferService_TransferBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServiceServer).TransferBatch(ctx, req.(*TransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TransferService_ServiceDesc is the grpc.ServiceDesc for TransferService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TransferService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zoo.v1.TransferService",
+	HandlerType: (*TransferServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TransferAnimal",
+			Handler:    _TransferService_TransferAnimal_Handler,
+		},
+		{
+			MethodName: "TransferBatch",
+			Handler:    _TransferService_TransferBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "zoo.proto",
+}
+
+const (
+	StatisticsService_GetStatistics_FullMethodName = "/zoo.v1.StatisticsService/GetStatistics"
+)
+
+// StatisticsServiceClient is the client API for StatisticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StatisticsServiceClient interface {
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*Statistics, error)
+}
+
+type statisticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStatisticsServiceClient(cc grpc.ClientConnInterface) StatisticsServiceClient {
+	return &statisticsServiceClient{cc}
+}
+
+func (c *statisticsServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*Statistics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Statistics)
+	err := c.cc.Invoke(ctx, StatisticsService_GetStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StatisticsServiceServer is the server API for StatisticsService service.
+// All implementations must embed UnimplementedStatisticsServiceServer
+// for forward compatibility.
+type StatisticsServiceServer interface {
+	GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error)
+	mustEmbedUnimplementedStatisticsServiceServer()
+}
+
+// UnimplementedStatisticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStatisticsServiceServer struct{}
+
+func (UnimplementedStatisticsServiceServer) GetStatistics(context.Context, *GetStatisticsRequest) (*Statistics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatistics not implemented")
+}
+func (UnimplementedStatisticsServiceServer) mustEmbedUnimplementedStatisticsServiceServer() {}
+func (UnimplementedStatisticsServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeStatisticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StatisticsServiceServer will
+// result in compilation errors.
+type UnsafeStatisticsServiceServer interface {
+	mustEmbedUnimplementedStatisticsServiceServer()
+}
+
+func RegisterStatisticsServiceServer(s grpc.ServiceRegistrar, srv StatisticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStatisticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StatisticsService_ServiceDesc, srv)
+}
+
+func _StatisticsService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StatisticsService_ServiceDesc is the grpc.ServiceDesc for StatisticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StatisticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zoo.v1.StatisticsService",
+	HandlerType: (*StatisticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatistics",
+			Handler:    _StatisticsService_GetStatistics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "zoo.proto",
+}
+
+const (
+	EventService_Subscribe_FullMethodName = "/zoo.v1.EventService/Subscribe"
+)
+
+// EventServiceClient is the client API for EventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EventServiceClient interface {
+	// Поток событий, как GET /api/events/stream
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type eventServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventServiceClient(cc grpc.ClientConnInterface) EventServiceClient {
+	return &eventServiceClient{cc}
+}
+
+func (c *eventServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventService_ServiceDesc.Streams[0], EventService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_SubscribeClient = grpc.ServerStreamingClient[Event]
+
+// EventServiceServer is the server API for EventService service.
+// All implementations must embed UnimplementedEventServiceServer
+// for forward compatibility.
+type EventServiceServer interface {
+	// Поток событий, как GET /api/events/stream
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+// UnimplementedEventServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEventServiceServer struct{}
+
+func (UnimplementedEventServiceServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
+func (UnimplementedEventServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventServiceServer will
+// result in compilation errors.
+type UnsafeEventServiceServer interface {
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+func RegisterEventServiceServer(s grpc.ServiceRegistrar, srv EventServiceServer) {
+	// If the following call pancis, it indicates UnimplementedEventServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EventService_ServiceDesc, srv)
+}
+
+func _EventService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventService_SubscribeServer = grpc.ServerStreamingServer[Event]
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "zoo.v1.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _EventService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "zoo.proto",
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	"net/http"
+	"strings"
+)
+
+// Authenticator - пользователь по токену доступа
+type Authenticator interface {
+	Authenticate(token string) (requestcontext.Actor, error)
+}
+
+// Authenticate - проверяет токен из заголовка Authorization: Bearer и кладёт
+// пользователя в context. Запрос без токена проходит дальше анонимным,
+// с недействительным токеном - отклоняется.
+func Authenticate(authenticator Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok {
+				unauthorized(w, "ожидается заголовок Authorization: Bearer <token>")
+				return
+			}
+			actor, err := authenticator.Authenticate(token)
+			if err != nil {
+				unauthorized(w, err.Error())
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(requestcontext.WithActor(r.Context(), actor)))
+		})
+	}
+}
+
+// Require - пропускает запрос, если у роли пользователя есть хотя бы одно из прав
+func Require(permissions ...model.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor := requestcontext.ActorFrom(r.Context())
+			if !actor.IsAuthenticated() {
+				unauthorized(w, model.ErrUnauthorized.Error())
+				return
+			}
+			for _, permission := range permissions {
+				if actor.Role.Can(permission) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			http.Error(w, model.ErrForbidden.Error(), http.StatusForbidden)
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="zoo"`)
+	http.Error(w, message, http.StatusUnauthorized)
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/domain/model"
+	RP "kpo-mini-dz2/domain/repositoriesInterfaces"
+	"log/slog"
+	"net/http"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 10 << 20
+)
+
+// replayedHeaders - заголовки первого ответа, которые возвращаются при повторе
+var replayedHeaders = []string{"Content-Type", "ETag", "Location", "Content-Disposition", "Cache-Control"}
+
+// ErrIdempotencyKeyTooLong - ключ длиннее maxIdempotencyKeyLength
+var ErrIdempotencyKeyTooLong = errors.New("Idempotency-Key is too long")
+
+// IdempotencyGuard - ключи идемпотентности, общие для REST, GraphQL и gRPC:
+// ключ, занятый запросом по одному API, нельзя использовать для другого запроса
+// по другому API. Ключи у каждого пользователя свои.
+type IdempotencyGuard struct {
+	repo      RP.IIdempotencyRepository
+	retention time.Duration
+}
+
+func NewIdempotencyGuard(repo RP.IIdempotencyRepository, retention time.Duration) *IdempotencyGuard {
+	return &IdempotencyGuard{repo: repo, retention: retention}
+}
+
+// Reserve - занимает ключ пользователя под запрос с отпечатком fingerprint.
+// Если ключ уже занят, возвращается запись первого запроса с этим ключом.
+func (g *IdempotencyGuard) Reserve(actor requestcontext.Actor, key string, fingerprint string) (model.IdempotencyRecord, *model.IdempotencyRecord, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return model.IdempotencyRecord{}, nil, ErrIdempotencyKeyTooLong
+	}
+	now := time.Now()
+	record := model.IdempotencyRecord{
+		Key:         actor.ID.String() + ":" + key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(g.retention),
+	}
+	existing, err := g.repo.Reserve(record, now)
+	return record, existing, err
+}
+
+// Complete - сохраняет ответ для повторов; если сохранить не удалось,
+// ключ освобождается
+func (g *IdempotencyGuard) Complete(ctx context.Context, record model.IdempotencyRecord) {
+	if err := g.repo.Complete(record); err != nil {
+		slog.ErrorContext(ctx, "не удалось сохранить ответ для ключа идемпотентности", "error", err)
+		g.Release(ctx, record.Key)
+	}
+}
+
+// Release - освобождает ключ, чтобы запрос можно было повторить
+func (g *IdempotencyGuard) Release(ctx context.Context, key string) {
+	if err := g.repo.Release(key); err != nil {
+		slog.ErrorContext(ctx, "не удалось освободить ключ идемпотентности", "error", err)
+	}
+}
+
+// Idempotency - повтор изменяющего запроса с тем же заголовком Idempotency-Key
+// получает сохранённый первый ответ, а не выполняется ещё раз.
+// Повтор с тем же ключом, но другим запросом отклоняется с 422.
+// Ответы 5xx не сохраняются, чтобы запрос можно было повторить.
+// Ставится после Authenticate.
+func Idempotency(guard *IdempotencyGuard) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			actor := requestcontext.ActorFrom(r.Context())
+			if key == "" || !isMutating(r.Method) || !actor.IsAuthenticated() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBytes))
+			if err != nil {
+				http.Error(w, "Request body is too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			record, existing, err := guard.Reserve(actor, key, requestFingerprint(r, body))
+			if errors.Is(err, ErrIdempotencyKeyTooLong) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if existing != nil {
+				Replay(w, *existing, record.Fingerprint)
+				return
+			}
+
+			completed := false
+			defer func() {
+				// Паника или ошибка сервера - ключ освобождается
+				if !completed {
+					guard.Release(r.Context(), record.Key)
+				}
+			}()
+
+			var buffer bytes.Buffer
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buffer)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			record.StatusCode = status
+			record.Header = make(http.Header)
+			for _, name := range replayedHeaders {
+				for _, value := range w.Header().Values(name) {
+					record.Header.Add(name, value)
+				}
+			}
+			record.Body = buffer.Bytes()
+			guard.Complete(r.Context(), record)
+			completed = true
+		})
+	}
+}
+
+// Replay - ответ на запрос, ключ которого уже занят: сохранённый первый ответ,
+// 422 для другого запроса или 409, пока первый ещё выполняется
+func Replay(w http.ResponseWriter, record model.IdempotencyRecord, fingerprint string) {
+	switch {
+	case record.Fingerprint != fingerprint:
+		http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+	case !record.Completed:
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "A request with this Idempotency-Key is still in progress", http.StatusConflict)
+	default:
+		for name, values := range record.Header {
+			w.Header()[name] = append([]string{}, values...)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(record.StatusCode)
+		w.Write(record.Body)
+	}
+}
+
+// requestFingerprint - метод, путь с параметрами, If-Match и тело запроса
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+"\n"+r.URL.RequestURI()+"\n"+r.Header.Get("If-Match")+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/infrastructure/repositories"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// idempotentServer - Idempotency перед обработчиком, который считает вызовы
+// и отвечает status
+type idempotentServer struct {
+	handler http.Handler
+	calls   int
+	status  int
+}
+
+func newIdempotentServer(actor requestcontext.Actor) *idempotentServer {
+	s := &idempotentServer{status: http.StatusCreated}
+	guard := NewIdempotencyGuard(repositories.NewInMemoryIdempotencyRepository(), time.Hour)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls++
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, s.calls))
+		w.WriteHeader(s.status)
+		fmt.Fprintf(w, "call %d", s.calls)
+	})
+	s.handler = Authenticate(staticAuthenticator(actor))(Idempotency(guard)(next))
+	return s
+}
+
+func (s *idempotentServer) post(key string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/api/animals", strings.NewReader(body))
+	request.Header.Set("Authorization", "Bearer token")
+	request.Header.Set(IdempotencyKeyHeader, key)
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestIdempotencyReplaysFirstResponse(t *testing.T) {
+	s := newIdempotentServer(requestcontext.Actor{ID: uuid.New(), Name: "keeper1", Role: "keeper"})
+
+	first := s.post("key-1", `{"name":"Akela"}`)
+	replayed := s.post("key-1", `{"name":"Akela"}`)
+	if s.calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", s.calls)
+	}
+	if replayed.Code != first.Code || replayed.Body.String() != first.Body.String() || replayed.Header().Get("ETag") != first.Header().Get("ETag") {
+		t.Fatalf("replay = %d %q %q, want %d %q %q", replayed.Code, replayed.Body, replayed.Header().Get("ETag"),
+			first.Code, first.Body, first.Header().Get("ETag"))
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" || replayed.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("%s = %q then %q, want only the replay marked", IdempotentReplayedHeader,
+			first.Header().Get(IdempotentReplayedHeader), replayed.Header().Get(IdempotentReplayedHeader))
+	}
+
+	// Другой ключ - новый запрос
+	if other := s.post("key-2", `{"name":"Akela"}`); other.Body.String() != "call 2" {
+		t.Fatalf("request with another key = %q, want call 2", other.Body)
+	}
+}
+
+func TestIdempotencyRejectsKeyReusedForAnotherRequest(t *testing.T) {
+	s := newIdempotentServer(requestcontext.Actor{ID: uuid.New(), Name: "keeper1", Role: "keeper"})
+
+	s.post("key-1", `{"name":"Akela"}`)
+	if mismatch := s.post("key-1", `{"name":"Raksha"}`); mismatch.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("reused key status = %d, want %d", mismatch.Code, http.StatusUnprocessableEntity)
+	}
+	if s.calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", s.calls)
+	}
+}
+
+func TestIdempotencyDoesNotKeepServerErrors(t *testing.T) {
+	s := newIdempotentServer(requestcontext.Actor{ID: uuid.New(), Name: "keeper1", Role: "keeper"})
+	s.status = http.StatusInternalServerError
+
+	s.post("key-1", `{"name":"Akela"}`)
+	s.status = http.StatusCreated
+	if retry := s.post("key-1", `{"name":"Akela"}`); retry.Code != http.StatusCreated || s.calls != 2 {
+		t.Fatalf("retry after 500 = %d after %d calls, want %d after 2", retry.Code, s.calls, http.StatusCreated)
+	}
+}
+
+func TestIdempotencyKeysBelongToUser(t *testing.T) {
+	guard := NewIdempotencyGuard(repositories.NewInMemoryIdempotencyRepository(), time.Hour)
+	first := requestcontext.Actor{ID: uuid.New(), Name: "keeper1", Role: "keeper"}
+	second := requestcontext.Actor{ID: uuid.New(), Name: "keeper2", Role: "keeper"}
+
+	record, _, err := guard.Reserve(first, "key-1", "fingerprint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	guard.Complete(context.Background(), record)
+	if _, existing, err := guard.Reserve(second, "key-1", "other fingerprint"); err != nil || existing != nil {
+		t.Fatalf("Reserve() for another user = %+v, %v, want a free key", existing, err)
+	}
+	if _, _, err := guard.Reserve(first, strings.Repeat("k", maxIdempotencyKeyLength+1), "fingerprint"); err != ErrIdempotencyKeyTooLong {
+		t.Fatalf("Reserve() with a long key error = %v, want %v", err, ErrIdempotencyKeyTooLong)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"kpo-mini-dz2/application/requestcontext"
+	"log/slog"
+	"net/http"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// Logger - одна структурированная запись slog на каждый запрос.
+// Ставится после RequestID, чтобы запись содержала ID запроса. Пользователя
+// Authenticate определяет позже, внутри /api, поэтому Logger оставляет для него
+// место в context и добавляет в запись после обработки запроса.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		r = r.WithContext(requestcontext.WithActorHolder(r.Context()))
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		ctx := r.Context()
+		if actor := requestcontext.HeldActor(ctx); actor.IsAuthenticated() {
+			ctx = requestcontext.WithActor(ctx, actor)
+		}
+		slog.LogAttrs(ctx, level, "http request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote", r.RemoteAddr),
+		)
+	})
+}
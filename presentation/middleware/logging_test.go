@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"kpo-mini-dz2/application/requestcontext"
+	"kpo-mini-dz2/infrastructure/logging"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type staticAuthenticator requestcontext.Actor
+
+func (a staticAuthenticator) Authenticate(string) (requestcontext.Actor, error) {
+	return requestcontext.Actor(a), nil
+}
+
+func TestLoggerRecordsActorAuthenticatedLater(t *testing.T) {
+	var out bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(&out, false, slog.LevelInfo)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	actor := requestcontext.Actor{ID: uuid.New(), Name: "keeper1", Role: "keeper"}
+	handler := RequestID(Logger(Authenticate(staticAuthenticator(actor))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))))
+	request := httptest.NewRequest(http.MethodGet, "/api/animals", nil)
+	request.Header.Set("Authorization", "Bearer token")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("log record %q: %v", out.String(), err)
+	}
+	if record["actor"] != "keeper1" || record["request_id"] == nil {
+		t.Fatalf("log record = %v, want actor keeper1 and a request_id", record)
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"kpo-mini-dz2/infrastructure/metrics"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// Metrics - считает запросы и их длительность по шаблону маршрута chi,
+// чтобы /api/animals/{id} не порождал отдельный ряд на каждый ID
+func Metrics(requests *metrics.CounterVec, duration *metrics.HistogramVec) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			requests.Inc(r.Method, route, strconv.Itoa(status))
+			duration.Observe(time.Since(start).Seconds(), r.Method, route)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"kpo-mini-dz2/application/requestcontext"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength - более длинный ID клиента заменяется своим, чтобы не раздувать логи
+const maxRequestIDLength = 128
+
+// RequestID - берёт ID запроса из заголовка X-Request-ID или создаёт новый,
+// кладёт его в context и возвращает клиенту
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(requestcontext.WithRequestID(r.Context(), requestID)))
+	})
+}